@@ -29,6 +29,124 @@ const (
 
 	// MetricCollectorReportConditionReasonCollectionSucceeded indicates metric collection succeeded
 	MetricCollectorReportConditionReasonCollectionSucceeded = "CollectionSucceeded"
+
+	// MetricCollectorReportConditionReasonInvalidPrometheusURL indicates the spec's PrometheusURL is empty or invalid
+	MetricCollectorReportConditionReasonInvalidPrometheusURL = "InvalidPrometheusURL"
+
+	// MetricCollectorReportConditionReasonInvalidQueryTemplate indicates the spec's QueryTemplate cannot be rendered
+	MetricCollectorReportConditionReasonInvalidQueryTemplate = "InvalidQueryTemplate"
+
+	// MetricCollectorReportConditionReasonInvalidHealthExpression indicates the spec's HealthExpression is set
+	// together with QueryTemplate
+	MetricCollectorReportConditionReasonInvalidHealthExpression = "InvalidHealthExpression"
+
+	// MetricCollectorReportConditionReasonSpecChanged indicates the spec changed since the last collection and the
+	// metrics collected for the previous spec were cleared while the new spec is collected
+	MetricCollectorReportConditionReasonSpecChanged = "SpecChanged"
+
+	// MetricCollectorReportConditionTypeStaleMetricsReporter indicates whether the metric collector of the cluster has
+	// stopped updating the report. It is set by the optional report watchdog of the approval-request-controller.
+	MetricCollectorReportConditionTypeStaleMetricsReporter = "StaleMetricsReporter"
+
+	// MetricCollectorReportConditionReasonCollectionStale indicates the report has not been collected within the threshold
+	MetricCollectorReportConditionReasonCollectionStale = "CollectionStale"
+
+	// MetricCollectorReportConditionReasonCollectionResumed indicates the report is collected again after being stale
+	MetricCollectorReportConditionReasonCollectionResumed = "CollectionResumed"
+
+	// MetricCollectorReportConditionTypePrometheusAuthResolved indicates whether the Prometheus auth Secret of the
+	// cluster was resolved. It is only set when the metric collector is configured with an auth Secret mapping.
+	MetricCollectorReportConditionTypePrometheusAuthResolved = "PrometheusAuthResolved"
+
+	// MetricCollectorReportConditionReasonAuthSecretResolved indicates Prometheus is queried with the mapped auth Secret
+	MetricCollectorReportConditionReasonAuthSecretResolved = "AuthSecretResolved"
+
+	// MetricCollectorReportConditionReasonAuthMappingNotFound indicates the mapping has no entry for the report
+	// namespace, so Prometheus is queried without authentication
+	MetricCollectorReportConditionReasonAuthMappingNotFound = "AuthMappingNotFound"
+
+	// MetricCollectorReportConditionReasonAuthSecretNotFound indicates the mapped auth Secret does not exist,
+	// so Prometheus is queried without authentication
+	MetricCollectorReportConditionReasonAuthSecretNotFound = "AuthSecretNotFound"
+
+	// MetricCollectorReportConditionReasonAuthSecretCrossNamespace indicates the mapping refers to a Secret outside
+	// the report namespace without cross-namespace references being allowed, so Prometheus is queried without
+	// authentication
+	MetricCollectorReportConditionReasonAuthSecretCrossNamespace = "AuthSecretCrossNamespace"
+
+	// MetricCollectorReportConditionReasonMetricNotFound indicates the query built from the tracked workloads
+	// returned no series and Prometheus has no series of the health metrics at all, which RequireMetricExists
+	// tells apart from a query that matched none of the workloads.
+	MetricCollectorReportConditionReasonMetricNotFound = "MetricNotFound"
+
+	// MetricCollectorReportConditionReasonKubeStatusFallback indicates Prometheus could not be queried and the
+	// health of the pods of the tracked workloads was derived from their Ready condition on the member cluster
+	// instead. The collected metrics have the KubeStatus source.
+	MetricCollectorReportConditionReasonKubeStatusFallback = "KubeStatusFallback"
+
+	// MetricCollectorReportConditionTypeReady indicates whether the metric collector can collect metrics for the
+	// report. Unlike MetricsCollected, which reflects the last collection only, it only turns False after several
+	// consecutive failed collections, so that a transient failure does not flap it.
+	MetricCollectorReportConditionTypeReady = "Ready"
+
+	// MetricCollectorReportConditionReasonCollecting indicates fewer consecutive collections than the threshold failed
+	MetricCollectorReportConditionReasonCollecting = "Collecting"
+
+	// MetricCollectorReportConditionReasonSustainedCollectionFailures indicates at least the threshold of
+	// consecutive collections failed
+	MetricCollectorReportConditionReasonSustainedCollectionFailures = "SustainedCollectionFailures"
+)
+
+const (
+	// WorkloadMetricUnhealthyReasonNaN indicates the pod reported a NaN health value.
+	WorkloadMetricUnhealthyReasonNaN = "NaN"
+
+	// WorkloadMetricUnhealthyReasonInfinite indicates the pod reported a +Inf or -Inf health value.
+	WorkloadMetricUnhealthyReasonInfinite = "Infinite"
+
+	// WorkloadMetricUnhealthyReasonInsufficientUptime indicates the pod reported healthy, but has not been up for
+	// the MinPodUptime of the report, or its uptime is unknown.
+	WorkloadMetricUnhealthyReasonInsufficientUptime = "InsufficientUptime"
+
+	// WorkloadMetricUnhealthyReasonNotReady indicates the Ready condition of the pod is not true, for a metric
+	// derived from the pod status rather than Prometheus.
+	WorkloadMetricUnhealthyReasonNotReady = "NotReady"
+
+	// WorkloadMetricUnhealthyReasonSLOThresholdExceeded indicates the SLOQuery of the tracked workload returned a
+	// value above its SLOThreshold.
+	WorkloadMetricUnhealthyReasonSLOThresholdExceeded = "SLOThresholdExceeded"
+)
+
+const (
+	// WorkloadMetricSourceKubeStatus indicates the health was derived from the Ready condition of the pod on the
+	// member cluster because Prometheus could not be queried.
+	WorkloadMetricSourceKubeStatus = "KubeStatus"
+)
+
+// AggregatedPodName is the PodName of a WorkloadMetric collected from a series without a pod label,
+// which is only accepted when AllowAggregatedSeries is set.
+const AggregatedPodName = "<aggregated>"
+
+// ReplicaMergePolicy defines how metrics collected from multiple Prometheus replicas are merged.
+// +enum
+type ReplicaMergePolicy string
+
+const (
+	// ReplicaMergePolicyAny treats a pod as healthy if any replica reports it healthy.
+	ReplicaMergePolicyAny ReplicaMergePolicy = "Any"
+	// ReplicaMergePolicyAll treats a pod as healthy only if every replica that reports it reports it healthy.
+	ReplicaMergePolicyAll ReplicaMergePolicy = "All"
+)
+
+// PrometheusProtocol defines the protocol used to query Prometheus.
+// +enum
+type PrometheusProtocol string
+
+const (
+	// PrometheusProtocolHTTP queries the Prometheus HTTP API (/api/v1/query).
+	PrometheusProtocolHTTP PrometheusProtocol = "http"
+	// PrometheusProtocolGRPC queries the Thanos Query gRPC API (thanos.Query/Query).
+	PrometheusProtocolGRPC PrometheusProtocol = "grpc"
 )
 
 // +genclient
@@ -38,6 +156,8 @@ const (
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:JSONPath=`.status.workloadsMonitored`,name="Workloads",type=integer
 // +kubebuilder:printcolumn:JSONPath=`.status.lastCollectionTime`,name="Last-Collection",type=date
+// +kubebuilder:printcolumn:JSONPath=`.status.lastCollectionDurationMillis`,name="Collection-Millis",type=integer,priority=1
+// +kubebuilder:printcolumn:JSONPath=`.status.lastQueriedUrl`,name="Prometheus-URL",type=string,priority=1
 // +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
 
 // MetricCollectorReport is created by the approval-request-controller on the hub cluster
@@ -64,12 +184,158 @@ type MetricCollectorReport struct {
 type MetricCollectorReportSpec struct {
 	// PrometheusURL is the URL of the Prometheus server on the member cluster
 	// Example: "http://prometheus.fleet-system.svc.cluster.local:9090"
+	// With the http protocol, a unix:// URL naming the absolute path of a Unix domain socket, e.g.
+	// "unix:///var/run/prometheus/prometheus.sock", sends the queries over HTTP on that socket.
 	PrometheusURL string `json:"prometheusUrl"`
+
+	// Protocol is the protocol used to query PrometheusURL and ReplicaPrometheusURLs.
+	// http (the default) uses the Prometheus HTTP API and http(s):// or unix:// URLs; grpc uses the Thanos Query
+	// gRPC API and grpc:// (plaintext) or grpcs:// (TLS) URLs, e.g. "grpc://thanos-query.monitoring:10901".
+	// +optional
+	// +kubebuilder:validation:Enum=http;grpc
+	// +kubebuilder:default=http
+	Protocol PrometheusProtocol `json:"protocol,omitempty"`
+
+	// ReplicaPrometheusURLs are the URLs of additional Prometheus replicas scraping the same targets
+	// (e.g. the pods behind a headless service). All URLs, including PrometheusURL, are queried and
+	// their results merged according to ReplicaMergePolicy.
+	// +optional
+	ReplicaPrometheusURLs []string `json:"replicaPrometheusUrls,omitempty"`
+
+	// ReplicaMergePolicy defines how results from multiple Prometheus replicas are merged per pod.
+	// Any (the default) treats a pod as healthy if any replica reports it healthy, which smooths over
+	// missed scrapes; All requires every replica reporting the pod to report it healthy.
+	// +optional
+	// +kubebuilder:validation:Enum=Any;All
+	// +kubebuilder:default=Any
+	ReplicaMergePolicy ReplicaMergePolicy `json:"replicaMergePolicy,omitempty"`
+
+	// QueryTemplate is a Go text/template for the PromQL query sent to Prometheus, rendered by the
+	// metric collector with values taken from the report's labels. Only {{.Cluster}}, {{.Stage}} and
+	// {{.UpdateRun}} are available, e.g. `workload_health{cluster="{{.Cluster}}"}`.
+	// If empty, the query is built from the tracked workloads.
+	// +optional
+	QueryTemplate string `json:"queryTemplate,omitempty"`
+
+	// HealthExpression is a PromQL expression that is sent to Prometheus instead of the workload_health query,
+	// to compose the health of a pod from several metrics in Prometheus, e.g.
+	// `(workload_health == bool 1) * on(namespace, pod) (workload_ready == bool 1)`.
+	// It must return an instant vector of 0 (unhealthy) or 1 (healthy) values whose series carry the namespace,
+	// app, workload_kind and pod labels; each series becomes a WorkloadMetric. Only the series of the tracked
+	// workloads are kept. It cannot be combined with QueryTemplate.
+	// +optional
+	HealthExpression string `json:"healthExpression,omitempty"`
+
+	// HealthStateMapping, if set, determines the health of each pod from a label of its series instead of the
+	// sample value, for exporters reporting health as a string-valued state, e.g. `workload_status{state="Running"} 1`.
+	// +optional
+	HealthStateMapping *HealthStateMapping `json:"healthStateMapping,omitempty"`
+
+	// HealthMetricNames lists candidate health metrics in order of preference, for fleets whose workloads expose
+	// different health metrics depending on their framework (e.g. workload_health or app_up). The query built from
+	// the tracked workloads selects all of them, and each workload is judged by the first metric that has series
+	// for it. If empty, workload_health is used. It does not apply to QueryTemplate or HealthExpression.
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z_:][a-zA-Z0-9_:]*$`
+	HealthMetricNames []string `json:"healthMetricNames,omitempty"`
+
+	// WorkloadKinds restricts collection to workload_health series whose workload_kind label
+	// is in this set (e.g., ["Deployment"]). Series with other kinds are excluded from
+	// CollectedMetrics. If empty, no filtering is applied.
+	// +optional
+	WorkloadKinds []string `json:"workloadKinds,omitempty"`
+
+	// ExtraLabelKeys lists additional labels of the workload_health series (e.g. region, version) to carry
+	// through into the ExtraLabels of each collected WorkloadMetric. If empty, no extra labels are kept.
+	// +optional
+	ExtraLabelKeys []string `json:"extraLabelKeys,omitempty"`
+
+	// ReportUnhealthyOnly, if set, keeps reports small in large fleets: CollectedMetrics only holds the metrics
+	// of unhealthy pods, and the healthy pods of each workload are only counted in HealthyWorkloads.
+	// +optional
+	ReportUnhealthyOnly bool `json:"reportUnhealthyOnly,omitempty"`
+
+	// StrictResultType, if set, fails collection with a CollectionFailed reason when Prometheus returns a
+	// result type other than an instant vector, e.g. a matrix from a range selector in QueryTemplate or
+	// HealthExpression, instead of reading the latest sample of each series.
+	// +optional
+	StrictResultType bool `json:"strictResultType,omitempty"`
+
+	// AllowAggregatedSeries, if set, accepts series without a pod label, such as those of recording rules that
+	// aggregate the health of a whole workload, instead of skipping them. Each such series is recorded as a
+	// WorkloadMetric with the PodName "<aggregated>" and counts as a single healthy or unhealthy pod.
+	// +optional
+	AllowAggregatedSeries bool `json:"allowAggregatedSeries,omitempty"`
+
+	// RequireMetricExists, if set, checks whether Prometheus has any series of the health metrics at all when the
+	// query built from the tracked workloads returns none, and fails collection with a MetricNotFound reason if it
+	// has not. This tells a metric that is not scraped, or a gateway answering a backend error with an empty
+	// success, apart from a query that matched none of the workloads. It does not apply to QueryTemplate or
+	// HealthExpression.
+	// +optional
+	RequireMetricExists bool `json:"requireMetricExists,omitempty"`
+
+	// MinPodUptime, if set, only considers a pod healthy once it has been up for this duration, so that a workload
+	// is not approved right after a restart, while its pods report healthy but are not stable yet. The uptime of
+	// each pod is read from PodStartTimeMetric; pods without a start time are considered unhealthy.
+	// +optional
+	MinPodUptime *metav1.Duration `json:"minPodUptime,omitempty"`
+
+	// PodStartTimeMetric is the metric holding the Unix start time of each pod, with namespace and pod labels,
+	// that MinPodUptime is checked against. If empty, process_start_time_seconds is used.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_:][a-zA-Z0-9_:]*$`
+	PodStartTimeMetric string `json:"podStartTimeMetric,omitempty"`
+
+	// QueryTimeout, if set, is sent as the timeout parameter of every query, so that Prometheus aborts
+	// evaluation once it is exceeded instead of applying its --query.timeout. This is separate from the
+	// client-side timeout of the metric collector, which is 30s.
+	// +optional
+	QueryTimeout *metav1.Duration `json:"queryTimeout,omitempty"`
+
+	// WorkloadTrackerRef references the WorkloadTracker listing the workloads this report collects
+	// metrics for. When set, the metric collector only queries and reports those workloads.
+	// +optional
+	WorkloadTrackerRef *WorkloadTrackerReference `json:"workloadTrackerRef,omitempty"`
+}
+
+// HealthStateMapping maps the value of a series label to the health of a pod, for info-style metrics whose
+// value is always 1 and state-set metrics, such as those of kube-state-metrics, with one series per state
+// that is 1 for the current state and 0 for the others. Series with a value of 0 are skipped.
+type HealthStateMapping struct {
+	// Label is the series label holding the state, e.g. "state".
+	// +required
+	Label string `json:"label"`
+
+	// HealthyValues are the values of Label that mean the pod is healthy, e.g. ["Running"].
+	// Any other value, or a series without Label, means the pod is unhealthy.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	HealthyValues []string `json:"healthyValues"`
+}
+
+// WorkloadTrackerReference identifies a ClusterStagedWorkloadTracker or StagedWorkloadTracker.
+type WorkloadTrackerReference struct {
+	// Kind is the kind of the workload tracker.
+	// +required
+	// +kubebuilder:validation:Enum=ClusterStagedWorkloadTracker;StagedWorkloadTracker
+	Kind string `json:"kind"`
+
+	// Name is the name of the workload tracker.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the workload tracker. Empty for ClusterStagedWorkloadTracker.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // MetricCollectorReportStatus contains the collected metrics from the member cluster.
 type MetricCollectorReportStatus struct {
 	// Conditions represent the latest available observations of the report's state.
+	// They are keyed by type, so that the metric collector and the approval-request-controller each own their own.
+	// +listType=map
+	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -77,13 +343,149 @@ type MetricCollectorReportStatus struct {
 	// +optional
 	WorkloadsMonitored int32 `json:"workloadsMonitored,omitempty"`
 
+	// ParseErrors is the number of series skipped at the last collection because their sample was malformed,
+	// e.g. a value array with fewer than two elements, or their value could not be parsed. Such series are
+	// not counted as unhealthy pods, so a non-zero count points at a broken exporter, recording rule or proxy.
+	// +optional
+	ParseErrors int32 `json:"parseErrors,omitempty"`
+
+	// ConsecutiveFailures is the number of collections in a row that failed, with a CollectionFailed or
+	// MetricNotFound reason. It is reset by the next successful collection.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
 	// LastCollectionTime is when metrics were last collected on the member cluster.
 	// +optional
 	LastCollectionTime *metav1.Time `json:"lastCollectionTime,omitempty"`
 
-	// CollectedMetrics contains the most recent metrics from each workload.
+	// LastCollectionDurationMillis is how long the last collection from Prometheus took, in milliseconds.
+	// It helps to diagnose slow member Prometheus instances.
+	// +optional
+	LastCollectionDurationMillis int64 `json:"lastCollectionDurationMillis,omitempty"`
+
+	// LastQueriedURL is the Prometheus URL the metric collector queried at the last collection, to tell which
+	// endpoint a report actually used. Additional replicas queried with it are listed in ReplicaPrometheusURLs.
+	// +optional
+	LastQueriedURL string `json:"lastQueriedUrl,omitempty"`
+
+	// CollectedMetrics contains the most recent metrics from each workload. Every collection replaces the whole list,
+	// so that the metrics of pods and workloads that no longer report are dropped.
+	// +listType=atomic
 	// +optional
 	CollectedMetrics []WorkloadMetric `json:"collectedMetrics,omitempty"`
+
+	// DesiredReplicas contains the desired replica counts reported by kube-state-metrics for the
+	// tracked workloads that use them.
+	// +listType=atomic
+	// +optional
+	DesiredReplicas []WorkloadDesiredReplicas `json:"desiredReplicas,omitempty"`
+
+	// HealthyWorkloads counts the healthy pods of each workload when the spec sets ReportUnhealthyOnly,
+	// in which case those pods are omitted from CollectedMetrics.
+	// +listType=atomic
+	// +optional
+	HealthyWorkloads []WorkloadHealthyCount `json:"healthyWorkloads,omitempty"`
+
+	// RecentCollections summarizes the most recent collections, oldest first, for trend analysis beyond the
+	// last transition of the MetricsCollected condition. The metric collector appends one summary per
+	// successful or failed collection and keeps only the last few.
+	// +listType=atomic
+	// +optional
+	RecentCollections []CollectionSummary `json:"recentCollections,omitempty"`
+
+	// BlockingWorkloads lists the required workloads on this cluster that blocked approval of the ApprovalRequest
+	// at its last evaluation. It is owned and updated on every evaluation by the approval-request-controller,
+	// and is empty once the workloads are healthy, and while the stage is within the InitialGracePeriod of its
+	// WorkloadTracker.
+	// +optional
+	BlockingWorkloads []BlockingWorkload `json:"blockingWorkloads,omitempty"`
+}
+
+// CollectionSummary summarizes a single metric collection of a report.
+type CollectionSummary struct {
+	// Time is when the collection finished.
+	// +required
+	Time metav1.Time `json:"time"`
+
+	// Reason is the reason of the MetricsCollected condition set by the collection,
+	// CollectionSucceeded or CollectionFailed.
+	// +required
+	Reason string `json:"reason"`
+
+	// WorkloadsMonitored is the count of workloads metrics were collected for.
+	// +optional
+	WorkloadsMonitored int32 `json:"workloadsMonitored,omitempty"`
+}
+
+// BlockingWorkload is a required workload on a cluster that blocks approval of an ApprovalRequest.
+type BlockingWorkload struct {
+	// Cluster is the name of the member cluster.
+	// +required
+	Cluster string `json:"cluster"`
+
+	// Namespace of the workload.
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Name of the workload.
+	// +required
+	WorkloadName string `json:"workloadName"`
+
+	// Kind of the workload controller (e.g., Deployment, StatefulSet, DaemonSet).
+	// +optional
+	WorkloadKind string `json:"workloadKind,omitempty"`
+
+	// Reason is why the workload blocks approval: Missing if no metrics were collected for it,
+	// Unhealthy if it has fewer healthy pods than required, Degraded if it has enough healthy pods but not
+	// all of its pods are healthy and the approval-request-controller blocks degraded workloads, Critical if
+	// its lowest health value is below its CriticalThreshold, ParseError if a health value judged by its thresholds
+	// is not a number.
+	// +required
+	// +kubebuilder:validation:Enum=Missing;Unhealthy;Degraded;Critical;ParseError
+	Reason string `json:"reason"`
+
+	// Message is a human-readable description of why the workload blocks approval.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// WorkloadDesiredReplicas represents the desired replica count of a single workload.
+type WorkloadDesiredReplicas struct {
+	// Namespace of the workload.
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Name of the workload.
+	// +required
+	WorkloadName string `json:"workloadName"`
+
+	// Kind of the workload controller (e.g., Deployment, StatefulSet, DaemonSet).
+	// +required
+	WorkloadKind string `json:"workloadKind"`
+
+	// DesiredReplicas is the desired number of replicas of the workload.
+	// +required
+	DesiredReplicas int32 `json:"desiredReplicas"`
+}
+
+// WorkloadHealthyCount is the number of healthy pods of a workload, reported instead of their
+// metrics when the spec of a MetricCollectorReport sets ReportUnhealthyOnly.
+type WorkloadHealthyCount struct {
+	// Namespace of the workload.
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Name of the workload.
+	// +required
+	WorkloadName string `json:"workloadName"`
+
+	// Kind of the workload controller (e.g., Deployment, StatefulSet, DaemonSet).
+	// +required
+	WorkloadKind string `json:"workloadKind"`
+
+	// TotalHealthy is the number of healthy pods of the workload.
+	// +required
+	TotalHealthy int32 `json:"totalHealthy"`
 }
 
 // WorkloadMetric represents metrics collected from a single workload.
@@ -100,13 +502,48 @@ type WorkloadMetric struct {
 	// +optional
 	WorkloadKind string `json:"workloadKind,omitempty"`
 
-	// PodName is the name of the specific pod that reported this metric.
+	// PodName is the name of the specific pod that reported this metric, or "<aggregated>" for a series
+	// without a pod label accepted by AllowAggregatedSeries.
 	// +required
 	PodName string `json:"podName"`
 
 	// Health indicates if the workload is healthy (true=healthy, false=unhealthy).
 	// +required
 	Health bool `json:"health"`
+
+	// UnhealthyReason records why the pod is unhealthy when its health value was not a finite number:
+	// NaN for a NaN value, Infinite for +Inf or -Inf. InsufficientUptime records a pod that reported healthy but
+	// has not been up for the MinPodUptime of the report, NotReady a pod of the KubeStatus source that is not
+	// Ready, and SLOThresholdExceeded an SLOQuery value above the SLOThreshold of the workload. It is empty otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=NaN;Infinite;InsufficientUptime;NotReady;SLOThresholdExceeded
+	UnhealthyReason string `json:"unhealthyReason,omitempty"`
+
+	// HealthMetric is the name of the metric the health of the pod was read from. It is empty if the series
+	// carries no metric name, e.g. the result of a HealthExpression.
+	// +optional
+	HealthMetric string `json:"healthMetric,omitempty"`
+
+	// Value is the raw sample value the health of the pod was read from, e.g. "1", "0.97" or "NaN", for the
+	// WarnThreshold and CriticalThreshold of tracked workloads, or the value computed by the SLOQuery of the
+	// workload, e.g. a p99 latency of "0.42". It is empty for the KubeStatus source.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Source is KubeStatus if the health was derived from the Ready condition of the pod on the member cluster
+	// because Prometheus could not be queried. It is empty for health read from Prometheus.
+	// +optional
+	// +kubebuilder:validation:Enum=KubeStatus
+	Source string `json:"source,omitempty"`
+
+	// UptimeSeconds is how long the pod had been up when its metrics were collected, recorded if the report sets
+	// MinPodUptime and the start time of the pod is known.
+	// +optional
+	UptimeSeconds *int64 `json:"uptimeSeconds,omitempty"`
+
+	// ExtraLabels holds the labels of the Prometheus series listed in the report's ExtraLabelKeys.
+	// +optional
+	ExtraLabels map[string]string `json:"extraLabels,omitempty"`
 }
 
 // +kubebuilder:object:root=true
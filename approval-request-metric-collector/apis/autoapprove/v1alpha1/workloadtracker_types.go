@@ -17,10 +17,21 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ClusterStagedWorkloadTrackerKind is the kind of the ClusterStagedWorkloadTracker.
+	ClusterStagedWorkloadTrackerKind = "ClusterStagedWorkloadTracker"
+	// StagedWorkloadTrackerKind is the kind of the StagedWorkloadTracker.
+	StagedWorkloadTrackerKind = "StagedWorkloadTracker"
+)
+
 // WorkloadReference represents a workload to be tracked
+// +kubebuilder:validation:XValidation:rule="!has(self.sloQuery) || has(self.sloThreshold)",message="sloThreshold is required with sloQuery"
+// +kubebuilder:validation:XValidation:rule="!has(self.sloQuery) || !has(self.healthQuery)",message="sloQuery cannot be combined with healthQuery"
+// +kubebuilder:validation:XValidation:rule="!has(self.sloQuery) || (!has(self.warnThreshold) && !has(self.criticalThreshold))",message="sloQuery cannot be combined with warnThreshold or criticalThreshold"
 type WorkloadReference struct {
 	// Name is the name of the workload
 	// +required
@@ -35,8 +46,77 @@ type WorkloadReference struct {
 	Kind string `json:"kind"`
 
 	// HealthyReplicas is the number of replicas that must be healthy for approval.
+	// When UseDesiredReplicas is set, it is only used if the desired replica count is not available.
 	// +required
 	HealthyReplicas int32 `json:"healthyReplicas"`
+
+	// UseDesiredReplicas requires the workload's desired replica count, as reported by kube-state-metrics
+	// (e.g. kube_deployment_spec_replicas), to be healthy instead of the static HealthyReplicas.
+	// Supported for Deployment, StatefulSet and DaemonSet workloads.
+	// +optional
+	UseDesiredReplicas bool `json:"useDesiredReplicas,omitempty"`
+
+	// Optional marks a best-effort workload (e.g. a monitoring sidecar). Its health is reported in the
+	// ApprovalRequest's conditions and events, but it never blocks approval.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+
+	// AllowMissingAfter considers the workload satisfied if no workload_health metric is reported for it
+	// once this duration has elapsed since the stage started updating. This is for workloads that
+	// legitimately do not export the metric. If unset, a workload without metrics always blocks approval.
+	// +optional
+	AllowMissingAfter *metav1.Duration `json:"allowMissingAfter,omitempty"`
+
+	// HealthQuery is a PromQL query evaluated for this workload instead of its workload_health metric, for
+	// workloads whose health has other semantics, e.g. `kube_pod_status_ready{namespace="shop",pod=~"checkout-.*",condition="true"}`.
+	// It must return an instant vector of 0 (unhealthy) or 1 (healthy) values with a pod label; each series counts
+	// as a pod of this workload. If empty, the workload_health metric is used. It is not evaluated for reports with a
+	// QueryTemplate or HealthExpression, whose query replaces that of every workload.
+	// +optional
+	HealthQuery string `json:"healthQuery,omitempty"`
+
+	// WarnThreshold and CriticalThreshold judge the workload by the value of its health metric, for metrics that
+	// are not a plain 0 or 1, e.g. a success ratio. If either is set, the workload is judged on each cluster by the
+	// lowest value among its pods instead of by the number of its healthy pods: below CriticalThreshold, or NaN,
+	// it blocks approval; below WarnThreshold it is approved with a warning event; otherwise it is healthy.
+	// Pods counted as healthy by a report with ReportUnhealthyOnly have a value of 1.
+	// +optional
+	WarnThreshold *resource.Quantity `json:"warnThreshold,omitempty"`
+
+	// CriticalThreshold is the value below which the workload blocks approval. See WarnThreshold.
+	// +optional
+	CriticalThreshold *resource.Quantity `json:"criticalThreshold,omitempty"`
+
+	// SLOQuery is a PromQL query evaluated for this workload instead of its workload_health metric, whose value is
+	// compared to SLOThreshold, for gating on a service level objective, e.g. a p99 latency of
+	// `histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket{namespace="shop",app="checkout"}[5m])))`.
+	// It must return an instant vector; each series is healthy if its value is at most SLOThreshold, and counts as a
+	// pod of this workload, or as a single aggregated pod if it has no pod label, so set HealthyReplicas to the
+	// number of series expected, typically 1. A NaN value, e.g. without any traffic, is unhealthy. Like HealthQuery,
+	// it is not evaluated for reports with a QueryTemplate or HealthExpression.
+	// +optional
+	SLOQuery string `json:"sloQuery,omitempty"`
+
+	// SLOThreshold is the highest value of SLOQuery that is healthy, e.g. "0.5" or "500m" for 500ms.
+	// Required with SLOQuery.
+	// +optional
+	SLOThreshold *resource.Quantity `json:"sloThreshold,omitempty"`
+}
+
+// StageDependency makes the approval controller evaluate a stage only once a prior stage is approved and stable.
+type StageDependency struct {
+	// Stage is the name of the stage whose evaluation waits.
+	// +required
+	Stage string `json:"stage"`
+
+	// DependsOnStage is the name of the stage whose ApprovalRequests must all be approved
+	// before Stage is evaluated.
+	// +required
+	DependsOnStage string `json:"dependsOnStage"`
+
+	// Cooldown is how long DependsOnStage must have been approved before Stage is evaluated.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
 }
 
 // +genclient
@@ -57,6 +137,45 @@ type ClusterStagedWorkloadTracker struct {
 	// Workloads is a list of workloads to track
 	// +optional
 	Workloads []WorkloadReference `json:"workloads,omitempty"`
+
+	// StageWorkloads maps stage names to the workloads to track for that stage, so that stages can gate
+	// on different workloads. Stages without an entry use Workloads.
+	// +optional
+	StageWorkloads map[string][]WorkloadReference `json:"stageWorkloads,omitempty"`
+
+	// InitialGracePeriod is how long after a stage starts updating unhealthy workloads are not reported,
+	// since pods are briefly unhealthy while starting up. Workload health is still evaluated, and the
+	// stage is approved as soon as all workloads are healthy.
+	// +optional
+	InitialGracePeriod *metav1.Duration `json:"initialGracePeriod,omitempty"`
+
+	// StageDependencies makes the evaluation of a stage wait until a prior stage has been approved.
+	// +optional
+	StageDependencies []StageDependency `json:"stageDependencies,omitempty"`
+
+	// ClusterWeights maps member cluster names to their weight in the approval decision, e.g. to give a
+	// cluster serving more traffic more say. Clusters without an entry have a weight of 1.
+	// Weights are only used if MinHealthyClusterWeightPercent is set.
+	// +kubebuilder:validation:XValidation:rule="self.all(k, self[k] >= 0)",message="cluster weights must not be negative"
+	// +optional
+	ClusterWeights map[string]int32 `json:"clusterWeights,omitempty"`
+
+	// MinHealthyClusterWeightPercent approves a stage once the clusters on which all required workloads are
+	// healthy carry at least this percentage of the total weight of the stage's clusters. If unset, all
+	// required workloads must be healthy on every cluster.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinHealthyClusterWeightPercent *int32 `json:"minHealthyClusterWeightPercent,omitempty"`
+
+	// MinHealthyPodPercent approves a stage once at least this percentage of the pods of the required workloads,
+	// summed across all clusters of the stage, are healthy, whatever the HealthyReplicas of each workload.
+	// Workloads without metrics have no pods to count. Stages with clusters that are still updating are not
+	// approved this way. If unset, all required workloads must be healthy on every cluster.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinHealthyPodPercent *int32 `json:"minHealthyPodPercent,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -69,6 +188,12 @@ type ClusterStagedWorkloadTrackerList struct {
 	Items           []ClusterStagedWorkloadTracker `json:"items"`
 }
 
+// WorkloadsForStage returns the workloads to track for the given stage: its StageWorkloads entry
+// if present, and Workloads otherwise.
+func (t *ClusterStagedWorkloadTracker) WorkloadsForStage(stage string) []WorkloadReference {
+	return workloadsForStage(t.Workloads, t.StageWorkloads, stage)
+}
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope="Namespaced",categories={fleet,fleet-placement}
@@ -86,6 +211,45 @@ type StagedWorkloadTracker struct {
 	// Workloads is a list of workloads to track
 	// +optional
 	Workloads []WorkloadReference `json:"workloads,omitempty"`
+
+	// StageWorkloads maps stage names to the workloads to track for that stage, so that stages can gate
+	// on different workloads. Stages without an entry use Workloads.
+	// +optional
+	StageWorkloads map[string][]WorkloadReference `json:"stageWorkloads,omitempty"`
+
+	// InitialGracePeriod is how long after a stage starts updating unhealthy workloads are not reported,
+	// since pods are briefly unhealthy while starting up. Workload health is still evaluated, and the
+	// stage is approved as soon as all workloads are healthy.
+	// +optional
+	InitialGracePeriod *metav1.Duration `json:"initialGracePeriod,omitempty"`
+
+	// StageDependencies makes the evaluation of a stage wait until a prior stage has been approved.
+	// +optional
+	StageDependencies []StageDependency `json:"stageDependencies,omitempty"`
+
+	// ClusterWeights maps member cluster names to their weight in the approval decision, e.g. to give a
+	// cluster serving more traffic more say. Clusters without an entry have a weight of 1.
+	// Weights are only used if MinHealthyClusterWeightPercent is set.
+	// +kubebuilder:validation:XValidation:rule="self.all(k, self[k] >= 0)",message="cluster weights must not be negative"
+	// +optional
+	ClusterWeights map[string]int32 `json:"clusterWeights,omitempty"`
+
+	// MinHealthyClusterWeightPercent approves a stage once the clusters on which all required workloads are
+	// healthy carry at least this percentage of the total weight of the stage's clusters. If unset, all
+	// required workloads must be healthy on every cluster.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinHealthyClusterWeightPercent *int32 `json:"minHealthyClusterWeightPercent,omitempty"`
+
+	// MinHealthyPodPercent approves a stage once at least this percentage of the pods of the required workloads,
+	// summed across all clusters of the stage, are healthy, whatever the HealthyReplicas of each workload.
+	// Workloads without metrics have no pods to count. Stages with clusters that are still updating are not
+	// approved this way. If unset, all required workloads must be healthy on every cluster.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinHealthyPodPercent *int32 `json:"minHealthyPodPercent,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -98,6 +262,20 @@ type StagedWorkloadTrackerList struct {
 	Items           []StagedWorkloadTracker `json:"items"`
 }
 
+// WorkloadsForStage returns the workloads to track for the given stage: its StageWorkloads entry
+// if present, and Workloads otherwise.
+func (t *StagedWorkloadTracker) WorkloadsForStage(stage string) []WorkloadReference {
+	return workloadsForStage(t.Workloads, t.StageWorkloads, stage)
+}
+
+// workloadsForStage returns the per-stage workloads for the stage if present, falling back to the flat list.
+func workloadsForStage(workloads []WorkloadReference, stageWorkloads map[string][]WorkloadReference, stage string) []WorkloadReference {
+	if perStage, ok := stageWorkloads[stage]; ok {
+		return perStage
+	}
+	return workloads
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterStagedWorkloadTracker{},
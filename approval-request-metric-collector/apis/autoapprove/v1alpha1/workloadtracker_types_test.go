@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWorkloadsForStage(t *testing.T) {
+	defaultWorkloads := []WorkloadReference{{Name: "app", Namespace: "app-ns", Kind: "Deployment"}}
+	canaryWorkloads := []WorkloadReference{{Name: "canary", Namespace: "app-ns", Kind: "Deployment"}}
+	stageWorkloads := map[string][]WorkloadReference{
+		"canary": canaryWorkloads,
+		"empty":  {},
+	}
+	tests := []struct {
+		name  string
+		stage string
+		want  []WorkloadReference
+	}{
+		{
+			name:  "stage with its own workloads",
+			stage: "canary",
+			want:  canaryWorkloads,
+		},
+		{
+			name:  "stage without an entry falls back to the workloads",
+			stage: "prod",
+			want:  defaultWorkloads,
+		},
+		{
+			name:  "stage with an empty entry tracks no workloads",
+			stage: "empty",
+			want:  []WorkloadReference{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterTracker := &ClusterStagedWorkloadTracker{Workloads: defaultWorkloads, StageWorkloads: stageWorkloads}
+			if diff := cmp.Diff(tt.want, clusterTracker.WorkloadsForStage(tt.stage)); diff != "" {
+				t.Errorf("ClusterStagedWorkloadTracker.WorkloadsForStage() mismatch (-want +got):\n%s", diff)
+			}
+			stagedTracker := &StagedWorkloadTracker{Workloads: defaultWorkloads, StageWorkloads: stageWorkloads}
+			if diff := cmp.Diff(tt.want, stagedTracker.WorkloadsForStage(tt.stage)); diff != "" {
+				t.Errorf("StagedWorkloadTracker.WorkloadsForStage() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
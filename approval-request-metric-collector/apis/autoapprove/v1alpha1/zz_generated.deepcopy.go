@@ -25,6 +25,21 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockingWorkload) DeepCopyInto(out *BlockingWorkload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockingWorkload.
+func (in *BlockingWorkload) DeepCopy() *BlockingWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockingWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterStagedWorkloadTracker) DeepCopyInto(out *ClusterStagedWorkloadTracker) {
 	*out = *in
@@ -33,7 +48,56 @@ func (in *ClusterStagedWorkloadTracker) DeepCopyInto(out *ClusterStagedWorkloadT
 	if in.Workloads != nil {
 		in, out := &in.Workloads, &out.Workloads
 		*out = make([]WorkloadReference, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StageWorkloads != nil {
+		in, out := &in.StageWorkloads, &out.StageWorkloads
+		*out = make(map[string][]WorkloadReference, len(*in))
+		for key, val := range *in {
+			var outVal []WorkloadReference
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]WorkloadReference, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.InitialGracePeriod != nil {
+		in, out := &in.InitialGracePeriod, &out.InitialGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StageDependencies != nil {
+		in, out := &in.StageDependencies, &out.StageDependencies
+		*out = make([]StageDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterWeights != nil {
+		in, out := &in.ClusterWeights, &out.ClusterWeights
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MinHealthyClusterWeightPercent != nil {
+		in, out := &in.MinHealthyClusterWeightPercent, &out.MinHealthyClusterWeightPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinHealthyPodPercent != nil {
+		in, out := &in.MinHealthyPodPercent, &out.MinHealthyPodPercent
+		*out = new(int32)
+		**out = **in
 	}
 }
 
@@ -87,12 +151,48 @@ func (in *ClusterStagedWorkloadTrackerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionSummary) DeepCopyInto(out *CollectionSummary) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionSummary.
+func (in *CollectionSummary) DeepCopy() *CollectionSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthStateMapping) DeepCopyInto(out *HealthStateMapping) {
+	*out = *in
+	if in.HealthyValues != nil {
+		in, out := &in.HealthyValues, &out.HealthyValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthStateMapping.
+func (in *HealthStateMapping) DeepCopy() *HealthStateMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthStateMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricCollectorReport) DeepCopyInto(out *MetricCollectorReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -149,6 +249,46 @@ func (in *MetricCollectorReportList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricCollectorReportSpec) DeepCopyInto(out *MetricCollectorReportSpec) {
 	*out = *in
+	if in.ReplicaPrometheusURLs != nil {
+		in, out := &in.ReplicaPrometheusURLs, &out.ReplicaPrometheusURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthStateMapping != nil {
+		in, out := &in.HealthStateMapping, &out.HealthStateMapping
+		*out = new(HealthStateMapping)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthMetricNames != nil {
+		in, out := &in.HealthMetricNames, &out.HealthMetricNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkloadKinds != nil {
+		in, out := &in.WorkloadKinds, &out.WorkloadKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraLabelKeys != nil {
+		in, out := &in.ExtraLabelKeys, &out.ExtraLabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinPodUptime != nil {
+		in, out := &in.MinPodUptime, &out.MinPodUptime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.QueryTimeout != nil {
+		in, out := &in.QueryTimeout, &out.QueryTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WorkloadTrackerRef != nil {
+		in, out := &in.WorkloadTrackerRef, &out.WorkloadTrackerRef
+		*out = new(WorkloadTrackerReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricCollectorReportSpec.
@@ -178,6 +318,30 @@ func (in *MetricCollectorReportStatus) DeepCopyInto(out *MetricCollectorReportSt
 	if in.CollectedMetrics != nil {
 		in, out := &in.CollectedMetrics, &out.CollectedMetrics
 		*out = make([]WorkloadMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DesiredReplicas != nil {
+		in, out := &in.DesiredReplicas, &out.DesiredReplicas
+		*out = make([]WorkloadDesiredReplicas, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthyWorkloads != nil {
+		in, out := &in.HealthyWorkloads, &out.HealthyWorkloads
+		*out = make([]WorkloadHealthyCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecentCollections != nil {
+		in, out := &in.RecentCollections, &out.RecentCollections
+		*out = make([]CollectionSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockingWorkloads != nil {
+		in, out := &in.BlockingWorkloads, &out.BlockingWorkloads
+		*out = make([]BlockingWorkload, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -192,6 +356,26 @@ func (in *MetricCollectorReportStatus) DeepCopy() *MetricCollectorReportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageDependency) DeepCopyInto(out *StageDependency) {
+	*out = *in
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageDependency.
+func (in *StageDependency) DeepCopy() *StageDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(StageDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StagedWorkloadTracker) DeepCopyInto(out *StagedWorkloadTracker) {
 	*out = *in
@@ -200,7 +384,56 @@ func (in *StagedWorkloadTracker) DeepCopyInto(out *StagedWorkloadTracker) {
 	if in.Workloads != nil {
 		in, out := &in.Workloads, &out.Workloads
 		*out = make([]WorkloadReference, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StageWorkloads != nil {
+		in, out := &in.StageWorkloads, &out.StageWorkloads
+		*out = make(map[string][]WorkloadReference, len(*in))
+		for key, val := range *in {
+			var outVal []WorkloadReference
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]WorkloadReference, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.InitialGracePeriod != nil {
+		in, out := &in.InitialGracePeriod, &out.InitialGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StageDependencies != nil {
+		in, out := &in.StageDependencies, &out.StageDependencies
+		*out = make([]StageDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterWeights != nil {
+		in, out := &in.ClusterWeights, &out.ClusterWeights
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MinHealthyClusterWeightPercent != nil {
+		in, out := &in.MinHealthyClusterWeightPercent, &out.MinHealthyClusterWeightPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinHealthyPodPercent != nil {
+		in, out := &in.MinHealthyPodPercent, &out.MinHealthyPodPercent
+		*out = new(int32)
+		**out = **in
 	}
 }
 
@@ -254,9 +487,51 @@ func (in *StagedWorkloadTrackerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadDesiredReplicas) DeepCopyInto(out *WorkloadDesiredReplicas) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadDesiredReplicas.
+func (in *WorkloadDesiredReplicas) DeepCopy() *WorkloadDesiredReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadDesiredReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadHealthyCount) DeepCopyInto(out *WorkloadHealthyCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadHealthyCount.
+func (in *WorkloadHealthyCount) DeepCopy() *WorkloadHealthyCount {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadHealthyCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadMetric) DeepCopyInto(out *WorkloadMetric) {
 	*out = *in
+	if in.UptimeSeconds != nil {
+		in, out := &in.UptimeSeconds, &out.UptimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExtraLabels != nil {
+		in, out := &in.ExtraLabels, &out.ExtraLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadMetric.
@@ -272,6 +547,26 @@ func (in *WorkloadMetric) DeepCopy() *WorkloadMetric {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadReference) DeepCopyInto(out *WorkloadReference) {
 	*out = *in
+	if in.AllowMissingAfter != nil {
+		in, out := &in.AllowMissingAfter, &out.AllowMissingAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WarnThreshold != nil {
+		in, out := &in.WarnThreshold, &out.WarnThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CriticalThreshold != nil {
+		in, out := &in.CriticalThreshold, &out.CriticalThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.SLOThreshold != nil {
+		in, out := &in.SLOThreshold, &out.SLOThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReference.
@@ -283,3 +578,18 @@ func (in *WorkloadReference) DeepCopy() *WorkloadReference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadTrackerReference) DeepCopyInto(out *WorkloadTrackerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadTrackerReference.
+func (in *WorkloadTrackerReference) DeepCopy() *WorkloadTrackerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadTrackerReference)
+	in.DeepCopyInto(out)
+	return out
+}
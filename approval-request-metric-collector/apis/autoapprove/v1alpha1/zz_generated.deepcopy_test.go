@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	quantityType = reflect.TypeOf(resource.Quantity{})
+	timeType     = reflect.TypeOf(metav1.Time{})
+)
+
+// fillValue sets every settable field reachable from v to a non-zero value, allocating pointers, and giving
+// slices and maps a single element, so that a DeepCopy that misses a field leaves it shared with the copy.
+func fillValue(v reflect.Value) {
+	switch v.Type() {
+	case quantityType:
+		v.Set(reflect.ValueOf(resource.MustParse("1")))
+		return
+	case timeType:
+		v.Set(reflect.ValueOf(metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))))
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("a")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillValue(v.Elem())
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillValue(v.Index(0))
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		fillValue(key)
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fillValue(elem)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, elem)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				fillValue(v.Field(i))
+			}
+		}
+	}
+}
+
+// mutateValue changes every value reachable from v in place: through pointers, slice elements and map entries
+// rather than by replacing them, so that the change shows up in any object that shares them.
+func mutateValue(v reflect.Value) {
+	switch v.Type() {
+	case quantityType:
+		v.Set(reflect.ValueOf(resource.MustParse("2")))
+		return
+	case timeType:
+		v.Set(reflect.ValueOf(metav1.NewTime(v.Interface().(metav1.Time).Add(time.Hour))))
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(v.String() + "-mutated")
+	case reflect.Bool:
+		v.SetBool(!v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(v.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(v.Uint() + 1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(v.Float() + 1)
+	case reflect.Pointer:
+		if !v.IsNil() {
+			mutateValue(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			mutateValue(v.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			mutateValue(elem)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				mutateValue(v.Field(i))
+			}
+		}
+	}
+}
+
+func TestDeepCopyObjectIndependence(t *testing.T) {
+	tests := []struct {
+		name   string
+		newObj func() runtime.Object
+	}{
+		{name: "MetricCollectorReport", newObj: func() runtime.Object { return &MetricCollectorReport{} }},
+		{name: "MetricCollectorReportList", newObj: func() runtime.Object { return &MetricCollectorReportList{} }},
+		{name: "StagedWorkloadTracker", newObj: func() runtime.Object { return &StagedWorkloadTracker{} }},
+		{name: "StagedWorkloadTrackerList", newObj: func() runtime.Object { return &StagedWorkloadTrackerList{} }},
+		{name: "ClusterStagedWorkloadTracker", newObj: func() runtime.Object { return &ClusterStagedWorkloadTracker{} }},
+		{name: "ClusterStagedWorkloadTrackerList", newObj: func() runtime.Object { return &ClusterStagedWorkloadTrackerList{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.newObj()
+			fillValue(reflect.ValueOf(original).Elem())
+			want := tt.newObj()
+			fillValue(reflect.ValueOf(want).Elem())
+
+			copied := original.DeepCopyObject()
+			if !apiequality.Semantic.DeepEqual(original, copied) {
+				t.Fatalf("DeepCopyObject() = %+v, want %+v", copied, original)
+			}
+			mutateValue(reflect.ValueOf(copied).Elem())
+			if apiequality.Semantic.DeepEqual(original, copied) {
+				t.Fatalf("mutating the copy did not change it")
+			}
+			if !apiequality.Semantic.DeepEqual(want, original) {
+				t.Errorf("mutating the copy changed the original: got %+v, want %+v", original, want)
+			}
+		})
+	}
+}
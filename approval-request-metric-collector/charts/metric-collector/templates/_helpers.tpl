@@ -48,6 +48,13 @@ app.kubernetes.io/name: {{ include "metric-collector.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
 
+{{/*
+Hub namespace of the member cluster, in which its MetricCollectorReports live
+*/}}
+{{- define "metric-collector.hubNamespace" -}}
+{{- printf .Values.memberCluster.namespaceFormat .Values.memberCluster.name }}
+{{- end }}
+
 {{/*
 Create the name of the service account to use
 */}}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// approvalreplay replays the approval decision of an ApprovalRequest against a snapshot of the hub objects it
+// depends on, to explain after the fact why it was or was not approved. Capture the snapshot with kubectl, e.g.
+//
+//	kubectl get clusterapprovalrequest,clusterstagedupdaterun,clusterstagedworkloadtracker -o yaml > snapshot.yaml
+//	echo --- >> snapshot.yaml
+//	kubectl get metriccollectorreports -A -o yaml >> snapshot.yaml
+//
+// Usage:
+//
+//	approvalreplay --snapshot-file=snapshot.yaml --name=<approval-request> [--namespace=<namespace>]
+//
+// It prints the decision and the evaluation behind it as JSON, and exits with 1 if the ApprovalRequest would
+// not be approved.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	fleetutils "github.com/kubefleet-dev/kubefleet/pkg/utils"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+	approvalcontroller "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/controllers/approvalrequest"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/logging"
+)
+
+var (
+	snapshotFile   = flag.String("snapshot-file", "", "Path to a YAML or JSON snapshot holding the ApprovalRequest, its UpdateRun, its WorkloadTracker and the MetricCollectorReports of the stage.")
+	name           = flag.String("name", "", "Name of the ApprovalRequest to replay.")
+	namespace      = flag.String("namespace", "", "Namespace of the ApprovalRequest. If empty, the ClusterApprovalRequest of that name is replayed.")
+	memberNSFormat = flag.String("member-namespace-format", fleetutils.NamespaceNameFormat, "Format of the hub namespace of a member cluster, as set on the approval-request-controller.")
+	blockDegraded  = flag.Bool("block-degraded-workloads", false, "Block approval on degraded workloads, as set on the approval-request-controller.")
+	defaultTracker = flag.String("default-workload-tracker", "", "Name of the default WorkloadTracker, as set on the approval-request-controller.")
+	emptyTracker   = flag.String("empty-tracker-behavior", string(approvalcontroller.EmptyTrackerBehaviorBlock), "What a WorkloadTracker without workloads for the stage means, block or approve, as set on the approval-request-controller.")
+)
+
+func main() {
+	logOpts := logging.BindFlags(flag.CommandLine)
+	flag.Parse()
+	logging.Setup(flag.CommandLine, logOpts)
+
+	if *snapshotFile == "" || *name == "" {
+		fmt.Fprintln(os.Stderr, "--snapshot-file and --name are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(placementv1beta1.AddToScheme(scheme))
+	utilruntime.Must(autoapprovev1alpha1.AddToScheme(scheme))
+
+	file, err := os.Open(*snapshotFile)
+	if err != nil {
+		klog.ErrorS(err, "Failed to open snapshot", "file", *snapshotFile)
+		os.Exit(2)
+	}
+	snapshot, err := approvalcontroller.LoadSnapshot(scheme, file)
+	file.Close()
+	if err != nil {
+		klog.ErrorS(err, "Failed to load snapshot", "file", *snapshotFile)
+		os.Exit(2)
+	}
+
+	reconciler := &approvalcontroller.Reconciler{
+		MemberNamespaceFormat:  *memberNSFormat,
+		BlockDegradedWorkloads: *blockDegraded,
+		DefaultWorkloadTracker: *defaultTracker,
+		EmptyTrackerBehavior:   approvalcontroller.EmptyTrackerBehavior(*emptyTracker),
+	}
+	key := types.NamespacedName{Namespace: *namespace, Name: *name}
+	approved, err := reconciler.Replay(context.Background(), scheme, snapshot, key, os.Stdout)
+	if err != nil {
+		klog.ErrorS(err, "Failed to replay ApprovalRequest", "approvalRequest", key)
+		os.Exit(2)
+	}
+	if !approved {
+		os.Exit(1)
+	}
+}
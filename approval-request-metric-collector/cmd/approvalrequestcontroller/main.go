@@ -18,31 +18,46 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
 	approvalcontroller "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/controllers/approvalrequest"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/controllers/reportwatchdog"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/logging"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/tracing"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/utils"
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	fleetutils "github.com/kubefleet-dev/kubefleet/pkg/utils"
 )
 
 var (
 	scheme = runtime.NewScheme()
 )
 
+// metricNameRegexp matches valid Prometheus metric names, which are put into PromQL selectors unquoted.
+var metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(placementv1beta1.AddToScheme(scheme))
@@ -53,22 +68,158 @@ func init() {
 func main() {
 	var metricsAddr string
 	var probeAddr string
+	var pprofAddr string
+	var debugAddr string
+	var metricsSecure bool
+	var metricsCertDir string
+	var requeueJitter float64
+	var disableFinalizers bool
+	var finalizeCompletedRequests bool
+	var defaultWorkloadTracker string
+	var queryTemplate string
+	var healthExpression string
+	var healthStateLabel string
+	var healthyStates string
+	var healthMetricNames string
+	var prometheusURL string
+	var prometheusProtocol string
+	var prometheusQueryTimeout time.Duration
+	var extraLabelKeys string
+	var approvalReasonTemplate string
+	var approvalMessageTemplate string
+	var reportWriteQPS float64
+	var reportWriteBurst int
+	var otlpEndpoint string
+	var reportUnhealthyOnly bool
+	var strictResultType bool
+	var allowAggregatedSeries bool
+	var requireMetricExists bool
+	var minPodUptime time.Duration
+	var podStartTimeMetric string
+	var blockDegradedWorkloads bool
+	var recordUpdateRunEvents bool
+	var reportStaleThreshold time.Duration
+	var reportWatchdogInterval time.Duration
+	var memberNamespaceFormat string
+	var healthCheckCacheTTL time.Duration
+	var emptyTrackerBehavior string
 
-	// Add klog flags to support -v for verbosity
-	klog.InitFlags(nil)
+	// Add klog flags to support -v for verbosity, and the zap flags configuring the log format
+	logOpts := logging.BindFlags(flag.CommandLine)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "", "The address the pprof endpoint binds to. Debug only: pprof may expose sensitive data. Empty disables it.")
+	flag.StringVar(&debugAddr, "debug-bind-address", "", "The address the decision-state debug endpoint (/debug/approvalrequest) binds to. Debug only. Empty disables it.")
+	flag.BoolVar(&metricsSecure, "metrics-secure", false, "Serve the metrics endpoint over HTTPS instead of HTTP.")
+	flag.StringVar(&metricsCertDir, "metrics-cert-dir", "", "Directory containing tls.crt and tls.key for the metrics endpoint. If empty and --metrics-secure is set, a self-signed certificate is generated.")
+	flag.BoolVar(&disableFinalizers, "disable-finalizers", false, "Do not add the cleanup finalizer to ApprovalRequests and clean up MetricCollectorReports on a best-effort basis instead. For ephemeral test environments only.")
+	flag.StringVar(&defaultWorkloadTracker, "default-workload-tracker", "", "Name of the WorkloadTracker used for UpdateRuns without a WorkloadTracker named after them: a ClusterStagedWorkloadTracker for ClusterStagedUpdateRuns, a StagedWorkloadTracker in the namespace of the StagedUpdateRun otherwise. If empty, every UpdateRun needs its own WorkloadTracker.")
+	flag.BoolVar(&finalizeCompletedRequests, "finalize-completed-requests", false, "Also add the cleanup finalizer to ApprovalRequests that are already approved or rejected when first reconciled, e.g. after running with --disable-finalizers, so that their MetricCollectorReports are cleaned up on deletion. By default only ApprovalRequests seen pending get the finalizer.")
+	flag.StringVar(&queryTemplate, "query-template", "", `PromQL query template set on every MetricCollectorReport, e.g. 'workload_health{cluster="{{.Cluster}}"}'. Only {{.Cluster}}, {{.Stage}} and {{.UpdateRun}} are available. If empty, the query is built from the tracked workloads.`)
+	flag.StringVar(&healthExpression, "health-expression", "", "PromQL expression set on every MetricCollectorReport and evaluated as the health of each pod instead of workload_health, e.g. '(workload_health == bool 1) * on(namespace, pod) (workload_ready == bool 1)'. It must return an instant vector of 0/1 values with the namespace, app, workload_kind and pod labels. Cannot be combined with --query-template.")
+	flag.StringVar(&healthStateLabel, "health-state-label", "", `Series label the metric collector reads the health of each pod from instead of the sample value, for exporters reporting a state such as workload_status{state="Running"}. Series with a value of 0 are skipped. Requires --healthy-states.`)
+	flag.StringVar(&healthyStates, "healthy-states", "", "Comma-separated values of --health-state-label (e.g. Running,Ready) that mean a pod is healthy.")
+	flag.StringVar(&healthMetricNames, "health-metric-names", "", "Comma-separated candidate health metrics in order of preference (e.g. workload_health,app_up), for fleets whose workloads expose different health metrics. The metric collector queries all of them and judges each workload by the first one with series for it. If empty, workload_health is used.")
+	flag.StringVar(&prometheusURL, "prometheus-url", "", "Prometheus URL set on every MetricCollectorReport. If empty, http://prometheus.prometheus.svc.cluster.local:9090 is used.")
+	flag.StringVar(&prometheusProtocol, "prometheus-protocol", string(autoapprovev1alpha1.PrometheusProtocolHTTP), "Protocol the metric collector queries --prometheus-url with: http for the Prometheus HTTP API, or grpc for the Thanos Query gRPC API with a grpc:// or grpcs:// URL.")
+	flag.DurationVar(&prometheusQueryTimeout, "prometheus-query-timeout", 0, "Server-side timeout (e.g. 10s) the metric collector sends with every Prometheus query, separate from its 30s client-side timeout. 0 leaves it to the --query.timeout of Prometheus.")
+	flag.StringVar(&extraLabelKeys, "extra-label-keys", "", "Comma-separated Prometheus series labels (e.g. region,version) to carry through into the collected metrics of every MetricCollectorReport.")
+	flag.StringVar(&approvalReasonTemplate, "approval-reason-template", approvalcontroller.DefaultApprovalReasonTemplate, "text/template of the Approved condition reason. Must render to a CamelCase condition reason. Fields: {{.ApprovalRequest}}, {{.Namespace}}, {{.UpdateRun}}, {{.Stage}}, {{.Clusters}}, {{.RequiredWorkloads}}, {{.OptionalStatus}}, {{.ExcludedStatus}}, {{.Timestamp}}, and {{.DefaultReason}} and {{.DefaultMessage}}, the reason and message of the approval path.")
+	flag.StringVar(&approvalMessageTemplate, "approval-message-template", approvalcontroller.DefaultApprovalMessageTemplate, "text/template of the Approved condition message, with the same fields as --approval-reason-template.")
+	flag.Float64Var(&reportWriteQPS, "report-write-qps", 0, "Maximum rate of MetricCollectorReport writes (create, update, delete) to the hub per second, shaping write bursts such as after a restart. 0 disables the limit.")
+	flag.IntVar(&reportWriteBurst, "report-write-burst", 10, "Maximum burst of MetricCollectorReport writes when --report-write-qps is set.")
+	flag.BoolVar(&reportUnhealthyOnly, "report-unhealthy-only", false, "Have the metric collector report only the metrics of unhealthy pods and a count of healthy pods per workload, to keep MetricCollectorReports small in large fleets.")
+	flag.BoolVar(&strictResultType, "strict-result-type", false, "Have the metric collector fail collection with a CollectionFailed reason when a query returns anything but an instant vector, e.g. a matrix from a range selector in --query-template or --health-expression.")
+	flag.BoolVar(&allowAggregatedSeries, "allow-aggregated-series", false, "Have the metric collector accept series without a pod label, e.g. from recording rules aggregating the health of a whole workload, as a single pod named <aggregated> instead of skipping them. Such workloads should require 1 healthy replica.")
+	flag.BoolVar(&requireMetricExists, "require-metric-exists", false, "Have the metric collector check whether Prometheus has any series of the health metrics at all when the query of the tracked workloads returns none, and fail collection with a MetricNotFound reason if not, instead of reporting no metrics. Does not apply to --query-template or --health-expression.")
+	flag.DurationVar(&minPodUptime, "min-pod-uptime", 0, "Have the metric collector only consider pods healthy once they have been up for this duration (e.g. 5m), so that workloads are not approved right after a restart. Pods without a start time are considered unhealthy. 0 disables the check.")
+	flag.StringVar(&podStartTimeMetric, "pod-start-time-metric", "", "Metric with the Unix start time of each pod and namespace and pod labels that --min-pod-uptime is checked against. If empty, process_start_time_seconds is used.")
+	flag.BoolVar(&recordUpdateRunEvents, "record-update-run-events", false, "Also record a StageApproved event on the ClusterStagedUpdateRun or StagedUpdateRun when one of its stages is approved, with the stage name and cluster count.")
+	flag.BoolVar(&blockDegradedWorkloads, "block-degraded-workloads", false, "Block approval on degraded workloads, which have enough healthy pods but not all of their pods healthy, like on unhealthy ones. By default they are approved with a DegradedWorkloads warning event.")
+	flag.DurationVar(&reportStaleThreshold, "report-stale-threshold", 0, "Age of the last collection after which the report watchdog marks a MetricCollectorReport with StaleMetricsReporter=True and emits a warning event, signaling that the cluster's metric collector stopped. 0 disables the watchdog.")
+	flag.DurationVar(&reportWatchdogInterval, "report-watchdog-interval", time.Minute, "How often the report watchdog scans MetricCollectorReports when --report-stale-threshold is set.")
+	flag.StringVar(&memberNamespaceFormat, "member-namespace-format", fleetutils.NamespaceNameFormat, "Format of the hub namespace of a member cluster, with one %s for the cluster name. MetricCollectorReports are created in these namespaces. Only change it for fleet installs with a custom member namespace prefix, together with the metric collectors.")
+	flag.DurationVar(&healthCheckCacheTTL, "health-check-cache-ttl", 0, "Skip the workload health check of an ApprovalRequest that was not approved by its last check while none of its MetricCollectorReports, its WorkloadTracker, its generation, the clusters of its stage and their maintenance annotations have changed, for up to this duration (e.g. 1m). Changes to time-based settings take up to this long to be picked up. 0 checks on every reconcile.")
+	flag.StringVar(&emptyTrackerBehavior, "empty-tracker-behavior", string(approvalcontroller.EmptyTrackerBehaviorBlock), "What a WorkloadTracker that lists no workloads for a stage means: block leaves the ApprovalRequest for a manual decision, approve approves it right away since there is nothing to check.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "", "OTLP/HTTP endpoint (e.g. http://otel-collector:4318) to export OpenTelemetry traces of reconciliations and workload health checks to. Empty disables tracing.")
+	flag.Float64Var(&requeueJitter, "requeue-jitter-fraction", 0.1, "Random jitter applied to the requeue interval as a fraction of it (e.g. 0.1 for ±10%). 0 disables jitter.")
 
-	opts := zap.Options{
-		Development: true,
-	}
-	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	logging.Setup(flag.CommandLine, logOpts)
 
 	klog.InfoS("Starting ApprovalRequest Controller")
+	if disableFinalizers {
+		klog.Warning("Finalizers are disabled: MetricCollectorReports are cleaned up on a best-effort basis and may be left behind. Do not use this in production.")
+	}
+
+	if healthExpression != "" && queryTemplate != "" {
+		klog.ErrorS(nil, "--health-expression cannot be combined with --query-template")
+		os.Exit(1)
+	}
+
+	var healthStateMapping *autoapprovev1alpha1.HealthStateMapping
+	if healthStateLabel != "" || healthyStates != "" {
+		if healthStateLabel == "" || len(splitCommaSeparated(healthyStates)) == 0 {
+			klog.ErrorS(nil, "--health-state-label and --healthy-states must be set together")
+			os.Exit(1)
+		}
+		healthStateMapping = &autoapprovev1alpha1.HealthStateMapping{
+			Label:         healthStateLabel,
+			HealthyValues: splitCommaSeparated(healthyStates),
+		}
+	}
+
+	for _, metricName := range splitCommaSeparated(healthMetricNames) {
+		if !metricNameRegexp.MatchString(metricName) {
+			klog.ErrorS(nil, "--health-metric-names must list valid Prometheus metric names", "metricName", metricName)
+			os.Exit(1)
+		}
+	}
+	if errs := validation.IsDNS1123Subdomain(defaultWorkloadTracker); defaultWorkloadTracker != "" && len(errs) > 0 {
+		klog.ErrorS(nil, "--default-workload-tracker must be a valid object name", "defaultWorkloadTracker", defaultWorkloadTracker, "errors", errs)
+		os.Exit(1)
+	}
+	if podStartTimeMetric != "" && !metricNameRegexp.MatchString(podStartTimeMetric) {
+		klog.ErrorS(nil, "--pod-start-time-metric must be a valid Prometheus metric name", "metricName", podStartTimeMetric)
+		os.Exit(1)
+	}
+
+	if prometheusProtocol != string(autoapprovev1alpha1.PrometheusProtocolHTTP) && prometheusProtocol != string(autoapprovev1alpha1.PrometheusProtocolGRPC) {
+		klog.ErrorS(nil, "--prometheus-protocol must be http or grpc", "prometheusProtocol", prometheusProtocol)
+		os.Exit(1)
+	}
+	// An empty --prometheus-url falls back to the in-cluster http:// URL, which the grpc protocol cannot query
+	if prometheusURL != "" || prometheusProtocol == string(autoapprovev1alpha1.PrometheusProtocolGRPC) {
+		if err := utils.ValidatePrometheusURL(prometheusURL, autoapprovev1alpha1.PrometheusProtocol(prometheusProtocol)); err != nil {
+			klog.ErrorS(err, "Invalid --prometheus-url for --prometheus-protocol", "prometheusProtocol", prometheusProtocol)
+			os.Exit(1)
+		}
+	}
+
+	if emptyTrackerBehavior != string(approvalcontroller.EmptyTrackerBehaviorBlock) && emptyTrackerBehavior != string(approvalcontroller.EmptyTrackerBehaviorApprove) {
+		klog.ErrorS(nil, "--empty-tracker-behavior must be block or approve", "emptyTrackerBehavior", emptyTrackerBehavior)
+		os.Exit(1)
+	}
+
+	if err := utils.ValidateMemberNamespaceFormat(memberNamespaceFormat); err != nil {
+		klog.ErrorS(err, "Invalid --member-namespace-format")
+		os.Exit(1)
+	}
+
+	if err := approvalcontroller.ValidateApprovalTemplates(approvalReasonTemplate, approvalMessageTemplate); err != nil {
+		klog.ErrorS(err, "Invalid approval templates")
+		os.Exit(1)
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	shutdownTracing, err := tracing.Setup(ctx, otlpEndpoint, "approval-request-controller")
+	if err != nil {
+		klog.ErrorS(err, "Unable to set up tracing")
+		os.Exit(1)
+	}
+	tracer := tracing.Tracer("approval-request-controller")
 
 	config := ctrl.GetConfigOrDie()
 
@@ -81,33 +232,98 @@ func main() {
 	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
-			BindAddress: metricsAddr,
+			BindAddress:   metricsAddr,
+			SecureServing: metricsSecure,
+			CertDir:       metricsCertDir,
 		},
 		HealthProbeBindAddress: probeAddr,
+		PprofBindAddress:       pprofAddr,
 	})
 	if err != nil {
 		klog.ErrorS(err, "Unable to create manager")
 		os.Exit(1)
 	}
 
-	// Setup ApprovalRequest controller
-	approvalRequestReconciler := &approvalcontroller.Reconciler{
-		Client: mgr.GetClient(),
+	// Both controllers list MetricCollectorReports through the same index of the manager cache
+	if err := approvalcontroller.SetupReportIndexer(mgr); err != nil {
+		klog.ErrorS(err, "Unable to set up MetricCollectorReport index")
+		os.Exit(1)
+	}
+
+	// Both reconcilers share one limiter so that it bounds the total report write rate
+	var reportWriteLimiter flowcontrol.RateLimiter
+	if reportWriteQPS > 0 {
+		reportWriteLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(reportWriteQPS), reportWriteBurst)
+		klog.InfoS("Rate limiting MetricCollectorReport writes", "qps", reportWriteQPS, "burst", reportWriteBurst)
+	}
+
+	// Each controller gets its own reconciler, configured from the same flags
+	newReconciler := func() *approvalcontroller.Reconciler {
+		return &approvalcontroller.Reconciler{
+			Client:                    mgr.GetClient(),
+			RequeueJitterFraction:     requeueJitter,
+			DisableFinalizers:         disableFinalizers,
+			FinalizeCompletedRequests: finalizeCompletedRequests,
+			DefaultWorkloadTracker:    defaultWorkloadTracker,
+			QueryTemplate:             queryTemplate,
+			HealthExpression:          healthExpression,
+			HealthStateMapping:        healthStateMapping,
+			HealthMetricNames:         splitCommaSeparated(healthMetricNames),
+			PrometheusURL:             prometheusURL,
+			PrometheusProtocol:        autoapprovev1alpha1.PrometheusProtocol(prometheusProtocol),
+			PrometheusQueryTimeout:    prometheusQueryTimeout,
+			ExtraLabelKeys:            splitCommaSeparated(extraLabelKeys),
+			ApprovalReasonTemplate:    approvalReasonTemplate,
+			ApprovalMessageTemplate:   approvalMessageTemplate,
+			ReportWriteLimiter:        reportWriteLimiter,
+			ReportUnhealthyOnly:       reportUnhealthyOnly,
+			StrictResultType:          strictResultType,
+			AllowAggregatedSeries:     allowAggregatedSeries,
+			RequireMetricExists:       requireMetricExists,
+			MinPodUptime:              minPodUptime,
+			PodStartTimeMetric:        podStartTimeMetric,
+			BlockDegradedWorkloads:    blockDegradedWorkloads,
+			RecordUpdateRunEvents:     recordUpdateRunEvents,
+			MemberNamespaceFormat:     memberNamespaceFormat,
+			HealthCheckCacheTTL:       healthCheckCacheTTL,
+			EmptyTrackerBehavior:      approvalcontroller.EmptyTrackerBehavior(emptyTrackerBehavior),
+			Tracer:                    tracer,
+		}
 	}
+
+	// Setup ApprovalRequest controller
+	approvalRequestReconciler := newReconciler()
 	if err = approvalRequestReconciler.SetupWithManagerForApprovalRequest(mgr); err != nil {
 		klog.ErrorS(err, "Unable to create controller", "controller", "ApprovalRequest")
 		os.Exit(1)
 	}
 
 	// Setup ClusterApprovalRequest controller
-	clusterApprovalRequestReconciler := &approvalcontroller.Reconciler{
-		Client: mgr.GetClient(),
-	}
+	clusterApprovalRequestReconciler := newReconciler()
 	if err = clusterApprovalRequestReconciler.SetupWithManagerForClusterApprovalRequest(mgr); err != nil {
 		klog.ErrorS(err, "Unable to create controller", "controller", "ClusterApprovalRequest")
 		os.Exit(1)
 	}
 
+	if reportStaleThreshold > 0 {
+		watchdog := &reportwatchdog.Watchdog{
+			Client:    mgr.GetClient(),
+			Threshold: reportStaleThreshold,
+			Interval:  reportWatchdogInterval,
+		}
+		if err := watchdog.SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up MetricCollectorReport watchdog")
+			os.Exit(1)
+		}
+	}
+
+	if debugAddr != "" {
+		if err := mgr.Add(newDebugServer(debugAddr, approvalRequestReconciler.DebugHandler())); err != nil {
+			klog.ErrorS(err, "Unable to set up debug endpoint")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		klog.ErrorS(err, "Unable to set up health check")
 		os.Exit(1)
@@ -119,7 +335,14 @@ func main() {
 	}
 
 	klog.InfoS("Starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctx)
+	// Flush the pending spans before exiting
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		klog.ErrorS(shutdownErr, "Failed to shut down tracing")
+	}
+	cancel()
+	if err != nil {
 		klog.ErrorS(err, "Problem running manager")
 		os.Exit(1)
 	}
@@ -165,3 +388,36 @@ func checkRequiredCRDs(config *rest.Config) error {
 	klog.InfoS("All required CRDs are installed")
 	return nil
 }
+
+// splitCommaSeparated splits a comma-separated flag value, dropping empty entries.
+func splitCommaSeparated(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// newDebugServer returns a runnable serving the decision-state debug handler on addr until the manager stops.
+func newDebugServer(addr string, handler http.Handler) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/approvalrequest", handler)
+		server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		go func() {
+			<-ctx.Done()
+			if err := server.Shutdown(context.Background()); err != nil {
+				klog.ErrorS(err, "Failed to shut down debug endpoint")
+			}
+		}()
+
+		klog.InfoS("Serving debug endpoint", "address", addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+}
@@ -18,36 +18,73 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
 	metriccollector "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/controllers/metriccollector"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/logging"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/tracing"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/utils"
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+	fleetutils "github.com/kubefleet-dev/kubefleet/pkg/utils"
 )
 
 var (
 	hubQPS            = flag.Int("hub-qps", 100, "QPS for hub cluster client")
 	hubBurst          = flag.Int("hub-burst", 200, "Burst for hub cluster client")
 	metricsAddr       = flag.String("metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	metricsSecure     = flag.Bool("metrics-secure", false, "Serve the metrics endpoint over HTTPS instead of HTTP.")
+	metricsCertDir    = flag.String("metrics-cert-dir", "", "Directory containing tls.crt and tls.key for the metrics endpoint. If empty and --metrics-secure is set, a self-signed certificate is generated.")
 	probeAddr         = flag.String("health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	pprofAddr         = flag.String("pprof-bind-address", "", "The address the pprof endpoint binds to. Debug only: pprof may expose sensitive data. Empty disables it.")
 	leaderElectionID  = flag.String("leader-election-id", "metric-collector-leader", "The leader election ID.")
 	enableLeaderElect = flag.Bool("leader-elect", true, "Enable leader election for controller manager.")
+	promProxyURL      = flag.String("prometheus-proxy-url", "", "Proxy URL used to reach Prometheus. If empty, the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables apply.")
+	promPostThreshold = flag.Int("prometheus-post-query-threshold", 2048, "Encoded query length in bytes above which Prometheus queries are sent with POST instead of GET. 0 sends every query with POST.")
+	memberCacheNS     = flag.String("member-cache-namespaces", "", "Comma-separated member cluster namespaces whose workloads are cached. If empty, all namespaces are cached.")
+	memberCacheKinds  = flag.String("member-cache-workload-kinds", "", "Comma-separated workload kinds (Deployment, StatefulSet, DaemonSet) cached on the member cluster for workload status cross-checks. If empty, the member cache is disabled.")
+	promUserAgent     = flag.String("prometheus-user-agent", "", "User-Agent header sent with Prometheus queries. If empty, kubefleet-metric-collector/<version> is used.")
+	requeueJitter     = flag.Float64("requeue-jitter-fraction", 0.1, "Random jitter applied to the collection interval as a fraction of it (e.g. 0.1 for ±10%). 0 disables jitter.")
+	promAuthConfigMap = flag.String("prometheus-auth-configmap", "", "Hub ConfigMap, as <namespace>/<name>, mapping each fleet-member-<cluster> namespace to the Secret in that namespace with the cluster's Prometheus credentials. If empty, Prometheus is queried without authentication.")
+	crossNSAuth       = flag.Bool("allow-cross-namespace-auth-secrets", false, "Allow --prometheus-auth-configmap to map a fleet-member-<cluster> namespace to a Secret in another namespace, given as <namespace>/<name>. Such references are rejected by default to keep credentials scoped to the cluster's namespace.")
+	readyFailures     = flag.Int("ready-failure-threshold", 3, "Number of consecutive failed collections after which the Ready condition of a MetricCollectorReport turns False, so that transient failures do not flap it.")
+	recentCollections = flag.Int("recent-collections", 10, "Number of collection summaries (time, result and workload count) kept in the recentCollections status of each MetricCollectorReport for trend analysis. 0 disables the history.")
+	maxMetrics        = flag.Int("max-collected-metrics", 5000, "Maximum number of collected metrics written to a MetricCollectorReport, keeping it well below the etcd object size limit. Metrics of healthy pods are dropped first. 0 disables the cap.")
+	maxReconciles     = flag.Int("max-concurrent-reconciles", 1, "Number of MetricCollectorReports collected concurrently.")
+	maxQueries        = flag.Int("max-concurrent-prometheus-queries", 10, "Maximum number of Prometheus queries in flight across all reports, independent of --max-concurrent-reconciles, so that a burst of collections such as after a restart does not overwhelm the member Prometheus. 0 disables the limit.")
+	runOnce           = flag.Bool("run-once", false, "Collect metrics for every MetricCollectorReport once and exit instead of running as a controller, e.g. from a CronJob. Exits non-zero if any collection failed.")
+	memberNSFormat    = flag.String("member-namespace-format", fleetutils.NamespaceNameFormat, "Format of the hub namespace of the member cluster, with one %s for MEMBER_CLUSTER_NAME. Must match --member-namespace-format of the approval-request-controller.")
+	kubeFallback      = flag.Bool("fallback-to-kube-status", false, "When Prometheus cannot be queried, derive the health of the pods of the tracked workloads from their Ready condition on the member cluster instead of failing collection. The metrics are marked with the KubeStatus source. Requires read access to pods and workloads on the member cluster.")
+	scrapeSelector    = flag.String("scrape-endpoint-selector", "", "Label selector of the member cluster EndpointSlices whose pods are scraped for the health metrics directly, instead of querying Prometheus. The port named metrics, or the only port of a slice, is scraped at /metrics. Requires read access to EndpointSlices and pods on the member cluster. Empty queries Prometheus.")
+	startupPromURL    = flag.String("startup-check-prometheus-url", "", "Prometheus URL (e.g. http://prometheus.prometheus.svc.cluster.local:9090) queried once at startup, logging an error if it cannot be queried, for early feedback on a misconfigured Prometheus rather than with the first failed collection. Queried over HTTP without credentials. Empty skips the check.")
+	otlpEndpoint      = flag.String("otlp-endpoint", "", "OTLP/HTTP endpoint (e.g. http://otel-collector:4318) to export OpenTelemetry traces of reconciliations and Prometheus queries to. Empty disables tracing.")
 )
 
 func main() {
-	klog.InitFlags(nil)
+	logOpts := logging.BindFlags(flag.CommandLine)
 	flag.Parse()
+	logging.Setup(flag.CommandLine, logOpts)
 
 	klog.InfoS("Starting MetricCollector Controller")
 
@@ -59,9 +96,28 @@ func main() {
 	}
 
 	// Construct hub namespace
-	hubNamespace := fmt.Sprintf("fleet-member-%s", memberClusterName)
+	if err := utils.ValidateMemberNamespaceFormat(*memberNSFormat); err != nil {
+		klog.ErrorS(err, "Invalid --member-namespace-format")
+		os.Exit(1)
+	}
+	hubNamespace := fmt.Sprintf(*memberNSFormat, memberClusterName)
+	if errs := validation.IsDNS1123Label(hubNamespace); len(errs) > 0 {
+		// The approval-request-controller can only create reports in valid namespaces,
+		// so watching any other namespace would silently never see a report
+		klog.ErrorS(nil, "Hub namespace derived from MEMBER_CLUSTER_NAME is not a valid namespace name",
+			"namespace", hubNamespace, "errors", errs)
+		os.Exit(1)
+	}
 	klog.InfoS("Using hub namespace", "namespace", hubNamespace, "memberCluster", memberClusterName)
 
+	// The startup check queries the HTTP API, so a grpc:// URL could never pass it
+	if *startupPromURL != "" {
+		if err := utils.ValidatePrometheusURL(*startupPromURL, autoapprovev1alpha1.PrometheusProtocolHTTP); err != nil {
+			klog.ErrorS(err, "Invalid --startup-check-prometheus-url")
+			os.Exit(1)
+		}
+	}
+
 	// Build hub cluster config
 	hubConfig, err := buildHubConfig()
 	if err != nil {
@@ -71,42 +127,179 @@ func main() {
 	hubConfig.QPS = float32(*hubQPS)
 	hubConfig.Burst = *hubBurst
 
+	ctx := ctrl.SetupSignalHandler()
+	shutdownTracing, err := tracing.Setup(ctx, *otlpEndpoint, "metric-collector")
+	if err != nil {
+		klog.ErrorS(err, "Failed to set up tracing")
+		os.Exit(1)
+	}
+
 	// Start controller
-	if err := Start(ctrl.SetupSignalHandler(), hubConfig, memberClusterName, hubNamespace); err != nil {
+	err = Start(ctx, hubConfig, memberClusterName, hubNamespace)
+	// Flush the pending spans before exiting
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		klog.ErrorS(shutdownErr, "Failed to shut down tracing")
+	}
+	cancel()
+	if err != nil {
 		klog.ErrorS(err, "Failed to start controller")
 		os.Exit(1)
 	}
 }
 
-// buildHubConfig creates hub cluster config using token-based authentication
-// with TLS verification disabled (insecure mode)
+// buildHubConfig creates hub cluster config from the environment.
+//
+// Authentication uses a client certificate if IDENTITY_CERT and IDENTITY_KEY point to its files,
+// and otherwise the token read from CONFIG_PATH (defaults to /var/run/secrets/hub/token).
+// The hub server certificate is verified against HUB_CERTIFICATE_AUTHORITY (base64-encoded CA data),
+// the CA file at CA_BUNDLE, or the system roots if neither is set. TLS verification is only
+// skipped when TLS_INSECURE=true is set explicitly, which is meant for development only.
 func buildHubConfig() (*rest.Config, error) {
 	hubURL := os.Getenv("HUB_SERVER_URL")
 	if hubURL == "" {
 		return nil, fmt.Errorf("HUB_SERVER_URL environment variable not set")
 	}
 
-	// Get token path (defaults to /var/run/secrets/hub/token)
-	configPath := os.Getenv("CONFIG_PATH")
-	if configPath == "" {
-		configPath = "/var/run/secrets/hub/token"
+	hubConfig := &rest.Config{
+		Host: hubURL,
 	}
 
-	// Read token file
-	tokenData, err := os.ReadFile(configPath)
+	// Configure authentication
+	identityCert := os.Getenv("IDENTITY_CERT")
+	identityKey := os.Getenv("IDENTITY_KEY")
+	switch {
+	case identityCert != "" && identityKey != "":
+		klog.InfoS("Using certificate-based authentication for hub cluster", "cert", identityCert)
+		hubConfig.TLSClientConfig.CertFile = identityCert
+		hubConfig.TLSClientConfig.KeyFile = identityKey
+	case identityCert != "" || identityKey != "":
+		return nil, fmt.Errorf("IDENTITY_CERT and IDENTITY_KEY must be set together")
+	default:
+		// Get token path (defaults to /var/run/secrets/hub/token)
+		configPath := os.Getenv("CONFIG_PATH")
+		if configPath == "" {
+			configPath = "/var/run/secrets/hub/token"
+		}
+
+		// Read token file
+		tokenData, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hub token from %s: %w", configPath, err)
+		}
+		klog.InfoS("Using token-based authentication for hub cluster")
+		hubConfig.BearerToken = string(tokenData)
+	}
+
+	// Configure TLS verification of the hub server
+	insecure := false
+	if value := os.Getenv("TLS_INSECURE"); value != "" {
+		var err error
+		if insecure, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("failed to parse TLS_INSECURE %q: %w", value, err)
+		}
+	}
+	hubCA := os.Getenv("HUB_CERTIFICATE_AUTHORITY")
+	caBundle := os.Getenv("CA_BUNDLE")
+	switch {
+	case insecure:
+		if hubCA != "" || caBundle != "" {
+			return nil, fmt.Errorf("TLS_INSECURE cannot be combined with HUB_CERTIFICATE_AUTHORITY or CA_BUNDLE")
+		}
+		klog.Warning("TLS verification of the hub cluster is disabled (TLS_INSECURE=true); do not use this in production")
+		hubConfig.TLSClientConfig.Insecure = true
+	case hubCA != "":
+		caData, err := base64.StdEncoding.DecodeString(hubCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode HUB_CERTIFICATE_AUTHORITY: %w", err)
+		}
+		klog.InfoS("Verifying hub cluster with the CA from HUB_CERTIFICATE_AUTHORITY")
+		hubConfig.TLSClientConfig.CAData = caData
+	case caBundle != "":
+		klog.InfoS("Verifying hub cluster with the CA bundle", "caBundle", caBundle)
+		hubConfig.TLSClientConfig.CAFile = caBundle
+	default:
+		klog.InfoS("Verifying hub cluster with the system root CAs")
+	}
+
+	return hubConfig, nil
+}
+
+// parsePrometheusProxyURL parses the --prometheus-proxy-url flag, returning nil if it is not set.
+func parsePrometheusProxyURL() (*url.URL, error) {
+	if *promProxyURL == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(*promProxyURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read hub token from %s: %w", configPath, err)
+		return nil, fmt.Errorf("failed to parse %q: %w", *promProxyURL, err)
+	}
+	if proxyURL.Scheme == "" || proxyURL.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q must include a scheme and host", *promProxyURL)
+	}
+	return proxyURL, nil
+}
+
+// parsePrometheusAuthConfigMap parses the --prometheus-auth-configmap flag.
+func parsePrometheusAuthConfigMap() (types.NamespacedName, error) {
+	if *promAuthConfigMap == "" {
+		return types.NamespacedName{}, nil
+	}
+	namespace, name, ok := strings.Cut(*promAuthConfigMap, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("%q must be of the form <namespace>/<name>", *promAuthConfigMap)
 	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
 
-	klog.InfoS("Using token-based authentication with insecure TLS for hub cluster")
+// newReconciler creates the MetricCollectorReport reconciler from the flags.
+func newReconciler(hubClient client.Client) (*metriccollector.Reconciler, error) {
+	proxyURL, err := parsePrometheusProxyURL()
+	if err != nil {
+		return nil, fmt.Errorf("invalid Prometheus proxy URL: %w", err)
+	}
+	authConfigMap, err := parsePrometheusAuthConfigMap()
+	if err != nil {
+		return nil, fmt.Errorf("invalid Prometheus auth ConfigMap: %w", err)
+	}
+	var scrapeEndpointSelector labels.Selector
+	if *scrapeSelector != "" {
+		scrapeEndpointSelector, err = labels.Parse(*scrapeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scrape endpoint selector %q: %w", *scrapeSelector, err)
+		}
+	}
+	var memberReader client.Reader
+	if *kubeFallback || scrapeEndpointSelector != nil {
+		memberCfg, err := ctrl.GetConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get member cluster config: %w", err)
+		}
+		// Read the member cluster directly rather than keeping a cache of every pod
+		memberReader, err = client.New(memberCfg, client.Options{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create member cluster client: %w", err)
+		}
+	}
 
-	// Create hub config with token auth and insecure TLS
-	return &rest.Config{
-		Host:        hubURL,
-		BearerToken: string(tokenData),
-		TLSClientConfig: rest.TLSClientConfig{
-			Insecure: true,
-		},
+	return &metriccollector.Reconciler{
+		HubClient:                      hubClient,
+		RequeueJitterFraction:          *requeueJitter,
+		PrometheusProxyURL:             proxyURL,
+		PrometheusPostQueryThreshold:   *promPostThreshold,
+		PrometheusUserAgent:            *promUserAgent,
+		Tracer:                         tracing.Tracer("metric-collector"),
+		PrometheusAuthConfigMap:        authConfigMap,
+		AllowCrossNamespaceAuthSecrets: *crossNSAuth,
+		ReadyFailureThreshold:          *readyFailures,
+		RecentCollectionsLimit:         *recentCollections,
+		MaxCollectedMetrics:            *maxMetrics,
+		MaxConcurrentReconciles:        *maxReconciles,
+		MaxConcurrentQueries:           *maxQueries,
+		FallbackToKubeStatus:           *kubeFallback,
+		KubeStatusReader:               memberReader,
+		ScrapeEndpointSelector:         scrapeEndpointSelector,
+		EndpointReader:                 memberReader,
 	}, nil
 }
 
@@ -124,6 +317,20 @@ func Start(ctx context.Context, hubCfg *rest.Config, memberClusterName, hubNames
 		return fmt.Errorf("failed to add placement v1beta1 API to scheme: %w", err)
 	}
 
+	if *runOnce {
+		// Without a manager, reads go straight to the hub API server
+		hubClient, err := client.New(hubCfg, client.Options{Scheme: scheme})
+		if err != nil {
+			return fmt.Errorf("failed to create hub client: %w", err)
+		}
+		reconciler, err := newReconciler(hubClient)
+		if err != nil {
+			return err
+		}
+		defer reconciler.Close()
+		return reconciler.CollectOnce(ctx, hubNamespace)
+	}
+
 	// Create hub cluster manager - watches MetricCollectorReport in hub namespace
 	hubMgr, err := ctrl.NewManager(hubCfg, ctrl.Options{
 		Scheme: scheme,
@@ -132,10 +339,25 @@ func Start(ctx context.Context, hubCfg *rest.Config, memberClusterName, hubNames
 				hubNamespace: {}, // Only watch fleet-member-<memberClusterName>
 			},
 		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// WorkloadTrackers and the Prometheus auth ConfigMap live outside the watched namespace,
+				// and Secrets are not worth watching, so read them directly
+				DisableFor: []client.Object{
+					&autoapprovev1alpha1.ClusterStagedWorkloadTracker{},
+					&autoapprovev1alpha1.StagedWorkloadTracker{},
+					&corev1.ConfigMap{},
+					&corev1.Secret{},
+				},
+			},
+		},
 		Metrics: metricsserver.Options{
-			BindAddress: *metricsAddr,
+			BindAddress:   *metricsAddr,
+			SecureServing: *metricsSecure,
+			CertDir:       *metricsCertDir,
 		},
 		HealthProbeBindAddress: *probeAddr,
+		PprofBindAddress:       *pprofAddr,
 		LeaderElection:         *enableLeaderElect,
 		LeaderElectionID:       *leaderElectionID,
 	})
@@ -143,10 +365,40 @@ func Start(ctx context.Context, hubCfg *rest.Config, memberClusterName, hubNames
 		return fmt.Errorf("failed to create hub manager: %w", err)
 	}
 
+	reconciler, err := newReconciler(hubMgr.GetClient())
+	if err != nil {
+		return err
+	}
+	// Close the gRPC connections to Prometheus once the manager stops
+	defer reconciler.Close()
+
+	if *startupPromURL != "" {
+		// Only log: Prometheus may come up after the collector, and reports may point at another URL anyway
+		if err := reconciler.CheckPrometheus(ctx, *startupPromURL); err != nil {
+			klog.ErrorS(err, "Prometheus cannot be queried at startup, collections from it will fail until it can", "url", *startupPromURL)
+		}
+	}
+
+	// Cache the configured member workloads so status cross-checks read from informers instead of the API server
+	if kinds := splitCommaSeparated(*memberCacheKinds); len(kinds) > 0 {
+		memberCfg, err := ctrl.GetConfig()
+		if err != nil {
+			return fmt.Errorf("failed to get member cluster config: %w", err)
+		}
+		namespaces := splitCommaSeparated(*memberCacheNS)
+		memberCluster, err := metriccollector.NewMemberCluster(ctx, memberCfg, scheme, namespaces, kinds)
+		if err != nil {
+			return fmt.Errorf("failed to set up member cache: %w", err)
+		}
+		if err := hubMgr.Add(memberCluster); err != nil {
+			return fmt.Errorf("failed to add member cache to manager: %w", err)
+		}
+		reconciler.MemberClient = memberCluster.GetClient()
+		klog.InfoS("Caching member cluster workloads", "kinds", kinds, "namespaces", namespaces)
+	}
+
 	// Setup MetricCollectorReport controller (watches hub, queries member Prometheus)
-	if err := (&metriccollector.Reconciler{
-		HubClient: hubMgr.GetClient(),
-	}).SetupWithManager(hubMgr); err != nil {
+	if err := reconciler.SetupWithManager(hubMgr); err != nil {
 		return fmt.Errorf("failed to setup controller: %w", err)
 	}
 
@@ -163,9 +415,21 @@ func Start(ctx context.Context, hubCfg *rest.Config, memberClusterName, hubNames
 		"hubNamespace", hubNamespace,
 		"memberCluster", memberClusterName,
 		"metricsAddr", *metricsAddr,
+		"metricsSecure", *metricsSecure,
 		"probeAddr", *probeAddr)
 
 	// Start hub manager (watches MetricCollectorReport on hub, queries Prometheus on member)
 	klog.InfoS("Starting hub manager", "namespace", hubNamespace)
 	return hubMgr.Start(ctx)
 }
+
+// splitCommaSeparated splits a comma-separated flag value, dropping empty entries.
+func splitCommaSeparated(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
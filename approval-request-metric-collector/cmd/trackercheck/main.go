@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// trackercheck checks the workloads of a WorkloadTracker against the workload_health series currently in Prometheus,
+// to surface label mismatches (namespace, app, workload_kind) before auto-approval relies on the tracker.
+//
+// Usage:
+//
+//	trackercheck --tracker-file=tracker.yaml --prometheus-url=http://localhost:9090 [--stage=staging]
+//
+// It exits with 1 if any required workload is missing or does not have enough healthy pods.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+	metriccollector "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/controllers/metriccollector"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/logging"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/utils"
+)
+
+var (
+	trackerFile   = flag.String("tracker-file", "", "Path to a ClusterStagedWorkloadTracker or StagedWorkloadTracker manifest (YAML or JSON).")
+	prometheusURL = flag.String("prometheus-url", "", "URL of the Prometheus to check, e.g. http://localhost:9090 through kubectl port-forward.")
+	stage         = flag.String("stage", "", "Stage whose workloads are checked. If empty, the tracker's default workloads are checked.")
+	timeout       = flag.Duration("timeout", 30*time.Second, "Timeout of the Prometheus query.")
+)
+
+func main() {
+	logOpts := logging.BindFlags(flag.CommandLine)
+	flag.Parse()
+	logging.Setup(flag.CommandLine, logOpts)
+
+	if *trackerFile == "" || *prometheusURL == "" {
+		fmt.Fprintln(os.Stderr, "--tracker-file and --prometheus-url are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	workloads, err := loadTrackedWorkloads(*trackerFile, *stage)
+	if err != nil {
+		klog.ErrorS(err, "Failed to load WorkloadTracker", "file", *trackerFile)
+		os.Exit(2)
+	}
+	if len(workloads) == 0 {
+		fmt.Println("The WorkloadTracker lists no workloads for this stage; auto-approval does not check any workload and follows --empty-tracker-behavior of the controller.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	promClient := metriccollector.NewPrometheusClient(*prometheusURL, "", nil)
+	metrics, err := metriccollector.CollectWorkloadMetrics(ctx, promClient, workloads)
+	if err != nil {
+		klog.ErrorS(err, "Failed to query Prometheus", "prometheusUrl", *prometheusURL)
+		os.Exit(2)
+	}
+
+	failed, missing := false, false
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tKIND\tOPTIONAL\tSTATE\tHEALTHY PODS\tTOTAL PODS\tHEALTHY REPLICAS")
+	for _, workload := range workloads {
+		healthyPods, totalPods := utils.CountHealthyPodsForWorkload(metrics, workload)
+		state := "Healthy"
+		switch {
+		case totalPods == 0:
+			state = "Missing"
+			missing = true
+		case utils.HasValueThresholds(workload):
+			// Judged by the lowest value of its health metric rather than by the number of its healthy pods
+			value, _, err := utils.LowestValueForWorkload(metrics, workload)
+			if err != nil {
+				state = "ParseError"
+				break
+			}
+			switch utils.ValueTier(value, workload) {
+			case utils.ValueTierCritical:
+				state = "Critical"
+			case utils.ValueTierWarn:
+				state = "Warning"
+			}
+		case healthyPods < workload.HealthyReplicas:
+			state = "Unhealthy"
+		case healthyPods < totalPods:
+			// Degraded workloads only block approval with --block-degraded-workloads
+			state = "Degraded"
+		}
+		if (state == "Missing" || state == "Unhealthy" || state == "Critical" || state == "ParseError") && !workload.Optional {
+			failed = true
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%d\t%d\t%d\n",
+			workload.Namespace, workload.Name, workload.Kind, workload.Optional, state, healthyPods, totalPods, workload.HealthyReplicas)
+	}
+	if err := w.Flush(); err != nil {
+		klog.ErrorS(err, "Failed to write results")
+		os.Exit(2)
+	}
+
+	if missing {
+		fmt.Println("\nMissing workloads have no workload_health series with matching namespace, app and workload_kind labels.")
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// loadTrackedWorkloads reads a ClusterStagedWorkloadTracker or StagedWorkloadTracker manifest
+// and returns the workloads it tracks for the given stage.
+func loadTrackedWorkloads(path, stage string) ([]autoapprovev1alpha1.WorkloadReference, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	jsonData, err := utilyaml.ToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert manifest to JSON: %w", err)
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(jsonData, &typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	switch typeMeta.Kind {
+	case autoapprovev1alpha1.ClusterStagedWorkloadTrackerKind:
+		tracker := &autoapprovev1alpha1.ClusterStagedWorkloadTracker{}
+		if err := json.Unmarshal(jsonData, tracker); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", typeMeta.Kind, err)
+		}
+		return tracker.WorkloadsForStage(stage), nil
+	case autoapprovev1alpha1.StagedWorkloadTrackerKind:
+		tracker := &autoapprovev1alpha1.StagedWorkloadTracker{}
+		if err := json.Unmarshal(jsonData, tracker); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", typeMeta.Kind, err)
+		}
+		return tracker.WorkloadsForStage(stage), nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %q, must be %s or %s", typeMeta.Kind,
+			autoapprovev1alpha1.ClusterStagedWorkloadTrackerKind, autoapprovev1alpha1.StagedWorkloadTrackerKind)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultApprovalReasonTemplate is the default template of the Approved=True condition reason: the reason of
+	// the approval path, e.g. AllWorkloadsHealthy.
+	DefaultApprovalReasonTemplate = "{{.DefaultReason}}"
+
+	// DefaultApprovalMessageTemplate is the default template of the Approved=True condition message: the message
+	// of the approval path.
+	DefaultApprovalMessageTemplate = "{{.DefaultMessage}}"
+)
+
+// conditionReasonRegexp is the format the API server enforces on condition reasons.
+var conditionReasonRegexp = regexp.MustCompile(`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`)
+
+// approvalTemplateData holds the values available to the approval reason and message templates.
+type approvalTemplateData struct {
+	// ApprovalRequest is the name of the ApprovalRequest or ClusterApprovalRequest.
+	ApprovalRequest string
+	// Namespace is the namespace of the ApprovalRequest; empty for a ClusterApprovalRequest.
+	Namespace string
+	// UpdateRun is the name of the targeted update run.
+	UpdateRun string
+	// Stage is the name of the targeted stage.
+	Stage string
+	// Clusters is the number of clusters in the stage, not counting those in maintenance.
+	Clusters int
+	// RequiredWorkloads is the number of tracked workloads that are not optional.
+	RequiredWorkloads int
+	// OptionalStatus lists the optional workloads that are not healthy, prefixed by "; ", or is empty.
+	OptionalStatus string
+	// ExcludedStatus lists the clusters in maintenance that were left out, prefixed by "; ", or is empty.
+	ExcludedStatus string
+	// Timestamp is the approval time in RFC 3339 format (UTC).
+	Timestamp string
+	// DefaultReason is the reason of the approval path: AllWorkloadsHealthy, HealthyClusterWeightMet,
+	// HealthyPodPercentMet or NoWorkloadsTracked.
+	DefaultReason string
+	// DefaultMessage is the message of the approval path, e.g. listing the workloads that are not healthy on
+	// a HealthyClusterWeightMet approval.
+	DefaultMessage string
+}
+
+// ValidateApprovalTemplates checks that the approval reason and message templates parse and render,
+// and that the reason renders to a valid condition reason. Empty templates select the defaults.
+func ValidateApprovalTemplates(reasonTemplate, messageTemplate string) error {
+	sample := approvalTemplateData{
+		ApprovalRequest:   "example-approval-request",
+		Namespace:         "example-namespace",
+		UpdateRun:         "example-update-run",
+		Stage:             "example-stage",
+		Clusters:          1,
+		RequiredWorkloads: 1,
+		Timestamp:         time.Now().UTC().Format(time.RFC3339),
+		DefaultReason:     approvalReasonAllWorkloadsHealthy,
+		DefaultMessage:    "All 1 required workloads have sufficient healthy replicas across 1 clusters",
+	}
+	if _, err := renderApprovalReason(reasonTemplate, sample); err != nil {
+		return err
+	}
+	if _, err := renderApprovalMessage(messageTemplate, sample); err != nil {
+		return err
+	}
+	return nil
+}
+
+// renderApproval renders the configured approval reason and message. Each falls back to its default
+// if the configured template fails to render, so that a bad template never blocks an approval.
+func (r *Reconciler) renderApproval(data approvalTemplateData) (string, string) {
+	reason, err := renderApprovalReason(r.ApprovalReasonTemplate, data)
+	if err != nil {
+		klog.ErrorS(err, "Failed to render approval reason template, using the default", "template", r.ApprovalReasonTemplate)
+		reason, _ = renderApprovalReason(DefaultApprovalReasonTemplate, data)
+	}
+	message, err := renderApprovalMessage(r.ApprovalMessageTemplate, data)
+	if err != nil {
+		klog.ErrorS(err, "Failed to render approval message template, using the default", "template", r.ApprovalMessageTemplate)
+		message, _ = renderApprovalMessage(DefaultApprovalMessageTemplate, data)
+	}
+	return reason, message
+}
+
+// renderApprovalReason renders the approval reason template and checks that the result is a valid condition reason.
+func renderApprovalReason(reasonTemplate string, data approvalTemplateData) (string, error) {
+	if reasonTemplate == "" {
+		reasonTemplate = DefaultApprovalReasonTemplate
+	}
+	reason, err := renderApprovalTemplate("reason", reasonTemplate, data)
+	if err != nil {
+		return "", err
+	}
+	if !conditionReasonRegexp.MatchString(reason) {
+		return "", fmt.Errorf("approval reason %q rendered from template is not a valid condition reason, it must match %s", reason, conditionReasonRegexp.String())
+	}
+	return reason, nil
+}
+
+// renderApprovalMessage renders the approval message template, using the default if it is empty.
+func renderApprovalMessage(messageTemplate string, data approvalTemplateData) (string, error) {
+	if messageTemplate == "" {
+		messageTemplate = DefaultApprovalMessageTemplate
+	}
+	return renderApprovalTemplate("message", messageTemplate, data)
+}
+
+// renderApprovalTemplate renders the named approval template with the given data.
+func renderApprovalTemplate(name, text string, data approvalTemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse approval %s template: %w", name, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render approval %s template: %w", name, err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"testing"
+)
+
+func TestValidateApprovalTemplates(t *testing.T) {
+	tests := []struct {
+		name            string
+		reasonTemplate  string
+		messageTemplate string
+		wantErr         bool
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:            "custom templates",
+			reasonTemplate:  "Approved{{.Clusters}}Clusters",
+			messageTemplate: "Stage {{.Stage}} of {{.UpdateRun}} approved at {{.Timestamp}}: {{.DefaultMessage}}",
+		},
+		{
+			name:           "reason that is not a valid condition reason",
+			reasonTemplate: "approved by {{.ApprovalRequest}}",
+			wantErr:        true,
+		},
+		{
+			name:            "message that does not parse",
+			messageTemplate: "{{.Stage",
+			wantErr:         true,
+		},
+		{
+			name:            "message with an unknown field",
+			messageTemplate: "{{.Unknown}}",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateApprovalTemplates(tt.reasonTemplate, tt.messageTemplate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateApprovalTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderApproval(t *testing.T) {
+	data := approvalTemplateData{
+		ApprovalRequest: "approval",
+		Stage:           "canary",
+		UpdateRun:       "run",
+		Clusters:        3,
+		DefaultReason:   approvalReasonAllWorkloadsHealthy,
+		DefaultMessage:  "All 2 required workloads have sufficient healthy replicas across 3 clusters",
+	}
+	tests := []struct {
+		name            string
+		reasonTemplate  string
+		messageTemplate string
+		wantReason      string
+		wantMessage     string
+	}{
+		{
+			name:        "defaults use the reason and message of the approval path",
+			wantReason:  approvalReasonAllWorkloadsHealthy,
+			wantMessage: data.DefaultMessage,
+		},
+		{
+			name:            "custom templates",
+			reasonTemplate:  "StageApproved",
+			messageTemplate: "Stage {{.Stage}} of {{.UpdateRun}}: {{.DefaultMessage}}",
+			wantReason:      "StageApproved",
+			wantMessage:     "Stage canary of run: " + data.DefaultMessage,
+		},
+		{
+			name:            "templates that fail to render fall back to the defaults",
+			reasonTemplate:  "not a reason",
+			messageTemplate: "{{.Unknown}}",
+			wantReason:      approvalReasonAllWorkloadsHealthy,
+			wantMessage:     data.DefaultMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{ApprovalReasonTemplate: tt.reasonTemplate, ApprovalMessageTemplate: tt.messageTemplate}
+			reason, message := r.renderApproval(data)
+			if reason != tt.wantReason {
+				t.Errorf("renderApproval() reason = %q, want %q", reason, tt.wantReason)
+			}
+			if message != tt.wantMessage {
+				t.Errorf("renderApproval() message = %q, want %q", message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -21,41 +21,258 @@ package approvalrequest
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/tracing"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/utils"
 	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
-	"github.com/kubefleet-dev/kubefleet/pkg/utils"
+	fleetutils "github.com/kubefleet-dev/kubefleet/pkg/utils"
 )
 
 const (
 	// metricCollectorFinalizer is the finalizer added to ApprovalRequest objects for cleanup.
 	metricCollectorFinalizer = "kubernetes-fleet.io/metric-collector-report-cleanup"
 
-	// prometheusURL is the default Prometheus URL to use for all clusters
-	prometheusURL = "http://prometheus.prometheus.svc.cluster.local:9090"
+	// defaultPrometheusURL is the default Prometheus URL to use for all clusters
+	defaultPrometheusURL = "http://prometheus.prometheus.svc.cluster.local:9090"
+
+	// defaultRequeueInterval is the interval for re-checking workload health of an ApprovalRequest
+	defaultRequeueInterval = 15 * time.Second
+
+	// namespacePendingRequeueInterval is the shorter interval for re-checking an ApprovalRequest while the
+	// fleet-member namespace of one of its clusters has not been created yet
+	namespacePendingRequeueInterval = 5 * time.Second
 
 	// parentApprovalRequestLabel is the label key used to track which ApprovalRequest owns the MetricCollectorReport
 	parentApprovalRequestLabel = "kubernetes-fleet.io/parent-approval-request"
+
+	// updateRunLabel is the label key recording the UpdateRun a MetricCollectorReport was created for
+	updateRunLabel = "kubernetes-fleet.io/update-run"
+
+	// stageLabel is the label key recording the stage a MetricCollectorReport was created for
+	stageLabel = "kubernetes-fleet.io/stage"
+
+	// memberClusterLabel is the label key recording the member cluster a MetricCollectorReport collects from
+	memberClusterLabel = "kubernetes-fleet.io/member-cluster"
+
+	// approvedByAnnotation is the annotation key recording on a MetricCollectorReport the ApprovalRequest,
+	// as <namespace>/<name> or <name> for a ClusterApprovalRequest, that was approved based on it
+	approvedByAnnotation = "kubernetes-fleet.io/approved-by"
+
+	// approvedAtAnnotation is the annotation key recording on a MetricCollectorReport when the ApprovalRequest
+	// of approvedByAnnotation was approved, in RFC 3339
+	approvedAtAnnotation = "kubernetes-fleet.io/approved-at"
+
+	// reportUpdateRunStageIndex is the field index on MetricCollectorReport keyed by "<updateRun>/<stage>",
+	// built from the update-run and stage labels.
+	reportUpdateRunStageIndex = "updateRunStage"
+
+	// approvalRequestConditionConflicting indicates that another ApprovalRequest targeting the same
+	// update run and stage was created first, so this one is not processed by the controller.
+	approvalRequestConditionConflicting = "ConflictingApprovalRequest"
+
+	// approvalRequestConditionProgressing indicates whether the health check of the ApprovalRequest can make
+	// progress. It is False with one of the blocked reasons below while a dependency is missing.
+	approvalRequestConditionProgressing = "Progressing"
+
+	// progressingReasonUpdateRunNotFound indicates the target UpdateRun does not exist.
+	progressingReasonUpdateRunNotFound = "UpdateRunNotFound"
+	// progressingReasonScopeMismatch indicates the target UpdateRun only exists with the other scope, e.g. a
+	// namespaced ApprovalRequest targets a ClusterStagedUpdateRun; ApprovalRequests only target runs of their own scope.
+	progressingReasonScopeMismatch = "ScopeMismatch"
+	// progressingReasonStageNotFound indicates the target stage does not exist in the UpdateRun status.
+	progressingReasonStageNotFound = "StageNotFound"
+	// progressingReasonUpdateRunNotInitialized indicates the target UpdateRun is not initialized yet, so the cluster
+	// lists of its stages may not be complete.
+	progressingReasonUpdateRunNotInitialized = "UpdateRunNotInitialized"
+	// progressingReasonWorkloadTrackerNotFound indicates the WorkloadTracker for the UpdateRun does not exist.
+	progressingReasonWorkloadTrackerNotFound = "WorkloadTrackerNotFound"
+	// progressingReasonReportNotReady indicates a MetricCollectorReport for a cluster in the stage does not exist yet.
+	progressingReasonReportNotReady = "ReportNotReady"
+	// progressingReasonWaitingForReports indicates that the MetricCollectorReport of some cluster in the stage has
+	// not completed a collection for its current spec yet, so workload health is not evaluated.
+	progressingReasonWaitingForReports = "WaitingForReports"
+	// progressingReasonCheckingWorkloadHealth indicates all dependencies exist and workload health is being checked.
+	progressingReasonCheckingWorkloadHealth = "CheckingWorkloadHealth"
+	// progressingReasonWaitingForPriorStage indicates the WorkloadTracker makes the stage depend on a prior stage
+	// that is not approved yet or was approved less than the cooldown ago.
+	progressingReasonWaitingForPriorStage = "WaitingForPriorStage"
+	// progressingReasonInitialGracePeriod indicates workloads are not healthy yet, but the stage started updating
+	// within the WorkloadTracker's initial grace period, so the unhealthy details are not reported.
+	progressingReasonInitialGracePeriod = "InitialGracePeriod"
+	// progressingReasonNoEffectiveClusters indicates there is no cluster whose workload health could be verified,
+	// either because the stage has no clusters, all of them are in maintenance or all of them carry a weight of 0.
+	// Such a stage is never approved vacuously.
+	progressingReasonNoEffectiveClusters = "NoEffectiveClusters"
+	// progressingReasonClustersUpdating indicates some clusters of the stage are still updating, so their
+	// workload health is not checked yet.
+	progressingReasonClustersUpdating = "ClustersUpdating"
+	// progressingReasonInvalidApprovalRequest indicates the ApprovalRequest does not name its target UpdateRun
+	// or stage. It is terminal: no finalizer is added and no reports are created for it.
+	progressingReasonInvalidApprovalRequest = "InvalidApprovalRequest"
+	// progressingReasonNoWorkloadsTracked indicates the WorkloadTracker lists no workloads for the stage and
+	// EmptyTrackerBehavior is block, so the ApprovalRequest is left for a manual decision.
+	progressingReasonNoWorkloadsTracked = "NoWorkloadsTracked"
+
+	// maintenanceAnnotation excludes a member cluster from the health check of every stage when set to "true"
+	// on its fleet-member namespace, e.g. during a maintenance window. The cluster then neither blocks approval
+	// nor counts towards it.
+	maintenanceAnnotation = "kubernetes-fleet.io/maintenance"
+
+	// reconcilePausedAnnotation pauses reconciliation of an ApprovalRequest when set to "true",
+	// e.g. to freeze auto-approval during incident response.
+	reconcilePausedAnnotation = "kubernetes-fleet.io/reconcile-paused"
+
+	// approvalRequestConditionPaused indicates whether reconciliation of the ApprovalRequest is paused
+	// through the reconcile-paused annotation.
+	approvalRequestConditionPaused = "Paused"
+	// pausedReasonAnnotationSet indicates reconciliation is paused by the reconcile-paused annotation.
+	pausedReasonAnnotationSet = "ReconcilePausedAnnotationSet"
+	// pausedReasonAnnotationRemoved indicates reconciliation resumed after the reconcile-paused annotation was removed.
+	pausedReasonAnnotationRemoved = "ReconcilePausedAnnotationRemoved"
+
+	// approvalReasonAllWorkloadsHealthy is the Approved=True reason used when all tracked workloads are healthy.
+	approvalReasonAllWorkloadsHealthy = "AllWorkloadsHealthy"
+	// approvalReasonHealthyClusterWeightMet is the Approved=True reason used when not all clusters are healthy,
+	// but the healthy clusters carry the WorkloadTracker's MinHealthyClusterWeightPercent.
+	approvalReasonHealthyClusterWeightMet = "HealthyClusterWeightMet"
+	// approvalReasonHealthyPodPercentMet is the Approved=True reason used when not all workloads are healthy,
+	// but the WorkloadTracker's MinHealthyPodPercent of the pods across the stage are.
+	approvalReasonHealthyPodPercentMet = "HealthyPodPercentMet"
+	// approvalReasonNoWorkloadsTracked is the Approved=True reason used when the WorkloadTracker lists no workloads
+	// for the stage and EmptyTrackerBehavior is approve.
+	approvalReasonNoWorkloadsTracked = "NoWorkloadsTracked"
+)
+
+// EmptyTrackerBehavior is what a WorkloadTracker that lists no workloads for a stage means for its ApprovalRequests.
+type EmptyTrackerBehavior string
+
+const (
+	// EmptyTrackerBehaviorBlock leaves the ApprovalRequest neither approved nor rejected, for a manual decision.
+	EmptyTrackerBehaviorBlock EmptyTrackerBehavior = "block"
+	// EmptyTrackerBehaviorApprove approves the ApprovalRequest right away, since there is nothing to check.
+	EmptyTrackerBehaviorApprove EmptyTrackerBehavior = "approve"
 )
 
 // Reconciler reconciles an ApprovalRequest object and creates MetricCollectorReport resources
-// on the hub cluster in fleet-member-{clusterName} namespaces.
+// on the hub cluster in fleet-member-{clusterName} namespaces, or those of MemberNamespaceFormat.
 type Reconciler struct {
 	client.Client
+	// RequeueJitterFraction spreads requeues by a random ±fraction of the requeue interval
+	// so that ApprovalRequests do not reconcile in lockstep. Zero disables jitter.
+	RequeueJitterFraction float64
+	// DisableFinalizers skips adding the cleanup finalizer to ApprovalRequests. MetricCollectorReports are then
+	// cleaned up on a best-effort basis when the controller observes that the ApprovalRequest is gone.
+	// This is a convenience for ephemeral test environments only.
+	DisableFinalizers bool
+	// FinalizeCompletedRequests adds the cleanup finalizer to approved and rejected ApprovalRequests that do not
+	// have it yet, e.g. those completed while finalizers were disabled, so that every ApprovalRequest the controller
+	// has seen cleans up its MetricCollectorReports on deletion. By default they are left without a finalizer.
+	FinalizeCompletedRequests bool
+	// DefaultWorkloadTracker, if set, is the name of the WorkloadTracker used for UpdateRuns without a WorkloadTracker
+	// of their own, so that fleets running many UpdateRuns over the same workloads need only one tracker. It is a
+	// ClusterStagedWorkloadTracker for ClusterStagedUpdateRuns and a StagedWorkloadTracker in the namespace of the
+	// StagedUpdateRun otherwise. A tracker named after the UpdateRun always takes precedence.
+	DefaultWorkloadTracker string
+	// QueryTemplate, if set, is copied into every MetricCollectorReport so that the metric collector
+	// renders it per cluster, stage and update run instead of building the query from the tracked workloads.
+	QueryTemplate string
+	// HealthExpression, if set, is copied into every MetricCollectorReport so that the metric collector evaluates
+	// this PromQL expression of 0/1 values as the health of each pod instead of querying workload_health.
+	HealthExpression string
+	// HealthStateMapping, if set, is copied into every MetricCollectorReport so that the metric collector reads
+	// the health of each pod from a state label of its series instead of the sample value.
+	HealthStateMapping *autoapprovev1alpha1.HealthStateMapping
+	// HealthMetricNames, if set, is copied into every MetricCollectorReport so that the metric collector queries these
+	// candidate health metrics instead of workload_health and judges each workload by the first one it exposes.
+	HealthMetricNames []string
+	// PrometheusURL, if set, is the Prometheus URL set on every MetricCollectorReport instead of the default
+	// Prometheus service URL. PrometheusProtocol is the protocol the metric collector queries it with.
+	PrometheusURL      string
+	PrometheusProtocol autoapprovev1alpha1.PrometheusProtocol
+	// PrometheusQueryTimeout, if non-zero, is copied into every MetricCollectorReport so that the metric
+	// collector sends it as the server-side timeout of its queries.
+	PrometheusQueryTimeout time.Duration
+	// ExtraLabelKeys, if set, is copied into every MetricCollectorReport so that the metric collector carries
+	// these Prometheus series labels through into the collected metrics.
+	ExtraLabelKeys []string
+	// ApprovalReasonTemplate and ApprovalMessageTemplate are text/template templates of the Approved=True
+	// condition reason and message. Empty templates select DefaultApprovalReasonTemplate and DefaultApprovalMessageTemplate.
+	ApprovalReasonTemplate  string
+	ApprovalMessageTemplate string
+	// ReportWriteLimiter, if set, rate limits the MetricCollectorReport writes of the controller. It is shared by
+	// the reconcilers of both ApprovalRequest kinds so that it shapes the total write rate to the hub.
+	ReportWriteLimiter flowcontrol.RateLimiter
+	// ReportUnhealthyOnly, if set, is copied into every MetricCollectorReport so that the metric collector only
+	// reports the metrics of unhealthy pods and counts the healthy ones, keeping reports small in large fleets.
+	ReportUnhealthyOnly bool
+	// StrictResultType, if set, is copied into every MetricCollectorReport so that the metric collector fails
+	// collection when a query returns anything but an instant vector, surfacing query mistakes.
+	StrictResultType bool
+	// AllowAggregatedSeries, if set, is copied into every MetricCollectorReport so that the metric collector
+	// accepts series without a pod label as a single pod of their workload instead of skipping them.
+	AllowAggregatedSeries bool
+	// RequireMetricExists, if set, is copied into every MetricCollectorReport so that the metric collector fails
+	// collection with a MetricNotFound reason when Prometheus has no series of the health metrics at all.
+	RequireMetricExists bool
+	// MinPodUptime, if non-zero, is copied into every MetricCollectorReport so that the metric collector only
+	// considers pods healthy once they have been up that long, read from PodStartTimeMetric if set.
+	MinPodUptime       time.Duration
+	PodStartTimeMetric string
+	// BlockDegradedWorkloads, if set, makes degraded workloads, which have enough healthy pods but not all of
+	// their pods healthy, block approval like unhealthy ones. By default they are approved with a warning event.
+	BlockDegradedWorkloads bool
+	// RecordUpdateRunEvents, if set, also records an event on the UpdateRun when a stage of it is approved, for
+	// operators who watch UpdateRuns rather than individual ApprovalRequests.
+	RecordUpdateRunEvents bool
+	// MemberNamespaceFormat, if set, formats the hub namespace of a member cluster from its name instead of the
+	// upstream fleet-member-%s, for fleet installs with a custom member namespace prefix.
+	MemberNamespaceFormat string
+	// HealthCheckCacheTTL, if non-zero, skips the workload health check of an ApprovalRequest that was not approved
+	// by its last check while none of its MetricCollectorReports, its generation and the clusters of its stage have
+	// changed, for up to this long. Other inputs, such as the WorkloadTracker, are picked up once the TTL expires.
+	HealthCheckCacheTTL time.Duration
+	// EmptyTrackerBehavior is what a WorkloadTracker that lists no workloads for the stage means. Empty means
+	// EmptyTrackerBehaviorBlock.
+	EmptyTrackerBehavior EmptyTrackerBehavior
+	// Tracer, if set, records spans around reconciliation and workload health checks.
+	Tracer   trace.Tracer
 	recorder record.EventRecorder
+	// healthChecks caches the last workload health checks for HealthCheckCacheTTL.
+	healthChecks *healthCheckCache
+}
+
+// memberNamespace returns the hub namespace of the member cluster, in which its MetricCollectorReport lives.
+func (r *Reconciler) memberNamespace(clusterName string) string {
+	if r.MemberNamespaceFormat == "" {
+		return fmt.Sprintf(fleetutils.NamespaceNameFormat, clusterName)
+	}
+	return fmt.Sprintf(r.MemberNamespaceFormat, clusterName)
 }
 
 // Reconcile reconciles an ApprovalRequest or ClusterApprovalRequest object.
@@ -66,18 +283,35 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		latency := time.Since(startTime).Milliseconds()
 		klog.V(2).InfoS("ApprovalRequest reconciliation ends", "request", req.NamespacedName, "latency", latency)
 	}()
+	ctx, span := tracing.OrNoop(r.Tracer).Start(ctx, "ApprovalRequest.Reconcile", trace.WithAttributes(
+		attribute.String("approvalrequest.namespace", req.Namespace),
+		attribute.String("approvalrequest.name", req.Name),
+	))
+	defer span.End()
 
 	approvalReqObj, err := r.getApprovalRequestObj(ctx, req)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			r.healthChecks.forget(req.NamespacedName)
+			if r.DisableFinalizers {
+				// Without a finalizer, the ApprovalRequest is gone by now; clean up its reports on a best-effort basis
+				klog.V(2).InfoS("ApprovalRequest not found, cleaning up MetricCollectorReports", "request", req.NamespacedName)
+				if _, err := r.deleteMetricCollectorReports(ctx, parentApprovalRequestLabelValue(req.Namespace, req.Name)); err != nil {
+					klog.ErrorS(err, "Failed to clean up MetricCollectorReports", "request", req.NamespacedName)
+				}
+				return ctrl.Result{}, nil
+			}
 			klog.V(2).InfoS("ApprovalRequest not found, ignoring", "request", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		klog.ErrorS(err, "Failed to get ApprovalRequest", "request", req.NamespacedName)
+		tracing.RecordError(span, err)
 		return ctrl.Result{}, err
 	}
 
-	return r.reconcileApprovalRequestObj(ctx, approvalReqObj)
+	result, err := r.reconcileApprovalRequestObj(ctx, approvalReqObj)
+	tracing.RecordError(span, err)
+	return result, err
 }
 
 // getApprovalRequestObj fetches either ApprovalRequest or ClusterApprovalRequest based on the request namespace.
@@ -103,26 +337,78 @@ func (r *Reconciler) getApprovalRequestObj(ctx context.Context, req ctrl.Request
 func (r *Reconciler) reconcileApprovalRequestObj(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj) (ctrl.Result, error) {
 	approvalReqRef := klog.KObj(approvalReqObj)
 
-	// Handle deletion
+	// Handle deletion first, so that approved and rejected ApprovalRequests, which stop reconciling below,
+	// still clean up their reports and drop the finalizer
 	if !approvalReqObj.GetDeletionTimestamp().IsZero() {
 		return r.handleDelete(ctx, approvalReqObj)
 	}
 
 	// Check if the approval request is already approved or rejected - stop reconciliation if so
 	approvedCond := meta.FindStatusCondition(approvalReqObj.GetApprovalRequestStatus().Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
-	if approvedCond != nil && approvedCond.Status == metav1.ConditionTrue {
-		klog.V(2).InfoS("ApprovalRequest has been approved, stopping reconciliation", "approvalRequest", approvalReqRef)
+	if approvedCond != nil && approvedCond.Status != metav1.ConditionUnknown {
+		// Completed ApprovalRequests only get the finalizer on request; by default only those the controller
+		// saw pending have it
+		if r.FinalizeCompletedRequests {
+			if err := r.ensureFinalizer(ctx, approvalReqObj); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if approvedCond.Status == metav1.ConditionTrue {
+			klog.V(2).InfoS("ApprovalRequest has been approved, stopping reconciliation", "approvalRequest", approvalReqRef)
+		} else {
+			klog.V(2).InfoS("ApprovalRequest has been rejected, stopping reconciliation", "approvalRequest", approvalReqRef, "reason", approvedCond.Reason)
+		}
 		return ctrl.Result{}, nil
 	}
 
-	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(approvalReqObj, metricCollectorFinalizer) {
-		controllerutil.AddFinalizer(approvalReqObj, metricCollectorFinalizer)
-		if err := r.Client.Update(ctx, approvalReqObj); err != nil {
-			klog.ErrorS(err, "Failed to add finalizer", "approvalRequest", approvalReqRef)
+	// Honor the reconcile-paused annotation; unpausing is picked up by the annotation-change predicate
+	if isReconcilePaused(approvalReqObj) {
+		klog.InfoS("Reconciliation is paused by annotation, skipping", "approvalRequest", approvalReqRef, "annotation", reconcilePausedAnnotation)
+		if err := r.setPausedCondition(ctx, approvalReqObj, metav1.ConditionTrue, pausedReasonAnnotationSet,
+			fmt.Sprintf("Reconciliation is paused by the %s annotation", reconcilePausedAnnotation)); err != nil {
+			klog.ErrorS(err, "Failed to update Paused condition", "approvalRequest", approvalReqRef)
 			return ctrl.Result{}, err
 		}
-		klog.V(2).InfoS("Added finalizer to ApprovalRequest", "approvalRequest", approvalReqRef)
+		return ctrl.Result{}, nil
+	}
+	if meta.IsStatusConditionTrue(approvalReqObj.GetApprovalRequestStatus().Conditions, approvalRequestConditionPaused) {
+		if err := r.setPausedCondition(ctx, approvalReqObj, metav1.ConditionFalse, pausedReasonAnnotationRemoved,
+			"Reconciliation resumed"); err != nil {
+			klog.ErrorS(err, "Failed to update Paused condition", "approvalRequest", approvalReqRef)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// A malformed ApprovalRequest without a target would otherwise produce report names like mc-- and
+	// look up UpdateRuns that cannot exist; retrying does not fix it
+	if message := invalidApprovalRequestMessage(approvalReqObj); message != "" {
+		klog.InfoS("ApprovalRequest is invalid, stopping reconciliation", "approvalRequest", approvalReqRef, "message", message)
+		if err := r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionFalse, progressingReasonInvalidApprovalRequest, message); err != nil {
+			klog.ErrorS(err, "Failed to update Progressing condition", "approvalRequest", approvalReqRef)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Guard against another ApprovalRequest targeting the same update run and stage.
+	// Both would otherwise fight over the same MetricCollectorReports; the one created first wins.
+	conflictingName, err := r.findConflictingApprovalRequest(ctx, approvalReqObj)
+	if err != nil {
+		klog.ErrorS(err, "Failed to check for conflicting ApprovalRequests", "approvalRequest", approvalReqRef)
+		return ctrl.Result{}, err
+	}
+	if err := r.setConflictingCondition(ctx, approvalReqObj, conflictingName); err != nil {
+		klog.ErrorS(err, "Failed to update conflicting condition", "approvalRequest", approvalReqRef)
+		return ctrl.Result{}, err
+	}
+	if conflictingName != "" {
+		klog.V(2).InfoS("ApprovalRequest conflicts with an older ApprovalRequest for the same stage, skipping", "approvalRequest", approvalReqRef, "conflictingApprovalRequest", conflictingName)
+		// Requeue to pick up the stage once the older ApprovalRequest is gone
+		return ctrl.Result{RequeueAfter: utils.JitterDuration(defaultRequeueInterval, r.RequeueJitterFraction)}, nil
+	}
+
+	if err := r.ensureFinalizer(ctx, approvalReqObj); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	// Get the UpdateRun (ClusterStagedUpdateRun or StagedUpdateRun)
@@ -130,35 +416,49 @@ func (r *Reconciler) reconcileApprovalRequestObj(ctx context.Context, approvalRe
 	updateRunName := spec.TargetUpdateRun
 	stageName := spec.TargetStage
 
-	var stageStatus *placementv1beta1.StageUpdatingStatus
-	if approvalReqObj.GetNamespace() == "" {
-		updateRun := &placementv1beta1.ClusterStagedUpdateRun{}
-		if err := r.Client.Get(ctx, types.NamespacedName{Name: updateRunName}, updateRun); err != nil {
-			klog.ErrorS(err, "Failed to get ClusterStagedUpdateRun", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
-			return ctrl.Result{}, err
-		}
-
-		// Find the stage
-		for i := range updateRun.Status.StagesStatus {
-			if updateRun.Status.StagesStatus[i].StageName == stageName {
-				stageStatus = &updateRun.Status.StagesStatus[i]
-				break
+	stageStatus, initialized, err := r.getStageStatus(ctx, approvalReqObj, updateRunName, stageName)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get UpdateRun", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
+		if errors.IsNotFound(err) {
+			// Tell a run of the wrong scope apart from a missing one; retrying does not fix a scope mismatch
+			mismatch, mismatchErr := r.findUpdateRunOfOtherScope(ctx, approvalReqObj, updateRunName)
+			if mismatchErr != nil {
+				klog.ErrorS(mismatchErr, "Failed to look up UpdateRun of the other scope", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
+				return ctrl.Result{}, mismatchErr
 			}
+			if mismatch != "" {
+				klog.InfoS("ApprovalRequest targets an UpdateRun of the other scope", "approvalRequest", approvalReqRef, "updateRun", mismatch)
+				if condErr := r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionFalse, progressingReasonScopeMismatch,
+					scopeMismatchMessage(approvalReqObj, mismatch)); condErr != nil {
+					klog.ErrorS(condErr, "Failed to update Progressing condition", "approvalRequest", approvalReqRef)
+					return ctrl.Result{}, condErr
+				}
+				return ctrl.Result{}, nil
+			}
+			message := fmt.Sprintf("ClusterStagedUpdateRun %s not found", updateRunName)
+			if approvalReqObj.GetNamespace() != "" {
+				message = fmt.Sprintf("StagedUpdateRun %s/%s not found", approvalReqObj.GetNamespace(), updateRunName)
+			}
+			if condErr := r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionFalse, progressingReasonUpdateRunNotFound, message); condErr != nil {
+				klog.ErrorS(condErr, "Failed to update Progressing condition", "approvalRequest", approvalReqRef)
+				return ctrl.Result{}, condErr
+			}
+			// UpdateRuns are not watched, so check back for one created after its ApprovalRequest without backing off
+			return ctrl.Result{RequeueAfter: utils.JitterDuration(defaultRequeueInterval, r.RequeueJitterFraction)}, nil
 		}
-	} else {
-		updateRun := &placementv1beta1.StagedUpdateRun{}
-		if err := r.Client.Get(ctx, types.NamespacedName{Name: updateRunName, Namespace: approvalReqObj.GetNamespace()}, updateRun); err != nil {
-			klog.ErrorS(err, "Failed to get StagedUpdateRun", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
-			return ctrl.Result{}, err
-		}
+		return ctrl.Result{}, err
+	}
 
-		// Find the stage
-		for i := range updateRun.Status.StagesStatus {
-			if updateRun.Status.StagesStatus[i].StageName == stageName {
-				stageStatus = &updateRun.Status.StagesStatus[i]
-				break
-			}
+	if !initialized {
+		// The stages and their clusters are only complete once the UpdateRun is initialized; creating reports from
+		// a partial cluster list would check, and possibly approve, only some clusters of the stage
+		klog.V(2).InfoS("UpdateRun is not initialized yet, requeueing", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
+		if err := r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionFalse, progressingReasonUpdateRunNotInitialized,
+			fmt.Sprintf("Waiting for UpdateRun %s to be initialized before reading the clusters of stage %s", updateRunName, stageName)); err != nil {
+			klog.ErrorS(err, "Failed to update Progressing condition", "approvalRequest", approvalReqRef)
+			return ctrl.Result{}, err
 		}
+		return ctrl.Result{RequeueAfter: utils.JitterDuration(defaultRequeueInterval, r.RequeueJitterFraction)}, nil
 	}
 
 	if stageStatus == nil {
@@ -167,232 +467,1020 @@ func (r *Reconciler) reconcileApprovalRequestObj(ctx context.Context, approvalRe
 		// This is a non-retriable error - retrying won't fix the underlying issue
 		klog.ErrorS(nil, "Unexpected state: stage not found in UpdateRun - this indicates unexpected behavior as ApprovalRequest should only be created for initialized stages", "approvalRequest", approvalReqRef, "updateRun", updateRunName, "stage", stageName)
 		r.recorder.Event(approvalReqObj, "Warning", "UnexpectedState", fmt.Sprintf("Stage %s not found in UpdateRun %s", stageName, updateRunName))
+		if err := r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionFalse, progressingReasonStageNotFound,
+			fmt.Sprintf("Stage %s not found in UpdateRun %s", stageName, updateRunName)); err != nil {
+			klog.ErrorS(err, "Failed to update Progressing condition", "approvalRequest", approvalReqRef)
+			return ctrl.Result{}, err
+		}
 		// Don't return error to avoid retries - this won't be fixed by reconciliation
 		return ctrl.Result{}, nil
 	}
 
-	// Get all cluster names from the stage
-	clusterNames := make([]string, 0, len(stageStatus.Clusters))
-	for _, cluster := range stageStatus.Clusters {
-		clusterNames = append(clusterNames, cluster.ClusterName)
-	}
+	// Get all cluster names from the stage, and the clusters whose update within the stage is still in progress
+	clusterNames, updatingClusters := stageClusters(stageStatus)
 
+	// There is nothing to verify without clusters, so do not create reports or approve
 	if len(clusterNames) == 0 {
 		klog.V(2).InfoS("No clusters in stage, skipping", "approvalRequest", approvalReqRef, "stage", stageName)
+		if err := r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionFalse, progressingReasonNoEffectiveClusters,
+			fmt.Sprintf("Stage %s of UpdateRun %s has no clusters whose workload health could be verified", stageName, updateRunName)); err != nil {
+			klog.ErrorS(err, "Failed to update Progressing condition", "approvalRequest", approvalReqRef)
+			return ctrl.Result{}, err
+		}
 		return ctrl.Result{}, nil
 	}
 
 	klog.V(2).InfoS("Found clusters in stage", "approvalRequest", approvalReqRef, "stage", stageName, "clusters", clusterNames)
 
 	// Create or update MetricCollectorReport resources in fleet-member namespaces
-	if err := r.ensureMetricCollectorReports(ctx, approvalReqObj, clusterNames, updateRunName, stageName); err != nil {
+	trackerName, err := r.workloadTrackerName(ctx, approvalReqObj, updateRunName)
+	if err != nil {
+		klog.ErrorS(err, "Failed to look up WorkloadTracker", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
+		return ctrl.Result{}, err
+	}
+	pendingNamespaces, updatedReports, err := r.ensureMetricCollectorReports(ctx, approvalReqObj, clusterNames, updateRunName, stageName, trackerName)
+	if err != nil {
 		klog.ErrorS(err, "Failed to ensure MetricCollectorReport resources", "approvalRequest", approvalReqRef)
 		return ctrl.Result{}, err
 	}
+	requeueInterval := defaultRequeueInterval
+	if len(pendingNamespaces) > 0 {
+		// The fleet hub agent creates the namespace when the member cluster joins; until then the cluster has no
+		// report and blocks approval, so check back soon instead of failing the reconcile
+		klog.InfoS("Fleet member namespaces do not exist yet, requeueing", "approvalRequest", approvalReqRef, "namespaces", pendingNamespaces)
+		requeueInterval = namespacePendingRequeueInterval
+	} else {
+		klog.V(2).InfoS("Successfully ensured MetricCollectorReport resources", "approvalRequest", approvalReqRef, "clusters", clusterNames)
+	}
 
-	klog.V(2).InfoS("Successfully ensured MetricCollectorReport resources", "approvalRequest", approvalReqRef, "clusters", clusterNames)
+	if updatedReports > 0 {
+		// The updated reports still hold the metrics collected for their previous spec, e.g. before the ApprovalRequest
+		// was edited, and the cache may not have the update yet; evaluate them once they are collected again
+		klog.V(2).InfoS("MetricCollectorReports were updated, checking workload health on the next reconcile", "approvalRequest", approvalReqRef, "updated", updatedReports)
+		return ctrl.Result{RequeueAfter: utils.JitterDuration(requeueInterval, r.RequeueJitterFraction)}, nil
+	}
 
 	// Check workload health and approve if all workloads are healthy
-	if err := r.checkWorkloadHealthAndApprove(ctx, approvalReqObj, clusterNames, updateRunName, stageName); err != nil {
+	if err := r.checkWorkloadHealthAndApprove(ctx, approvalReqObj, clusterNames, updatingClusters, updateRunName, stageName, stageStatus.StartTime); err != nil {
+		if errors.IsConflict(err) {
+			// The ApprovalRequest changed since it was read, e.g. its TargetStage was edited. Its status is written
+			// with its resourceVersion, so nothing was approved based on the old spec; evaluate the new one instead
+			klog.V(2).InfoS("ApprovalRequest changed while checking workload health, re-evaluating", "approvalRequest", approvalReqRef, "generation", approvalReqObj.GetGeneration())
+			return ctrl.Result{RequeueAfter: utils.JitterDuration(requeueInterval, r.RequeueJitterFraction)}, nil
+		}
 		klog.ErrorS(err, "Failed to check workload health", "approvalRequest", approvalReqRef)
 		return ctrl.Result{}, err
 	}
 
-	// Requeue after 15 seconds to check again (will stop if approved in next reconciliation)
-	return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	// Requeue after ~15 seconds, or ~5 seconds while namespaces are pending, to check again
+	// (will stop if approved in next reconciliation)
+	return ctrl.Result{RequeueAfter: utils.JitterDuration(requeueInterval, r.RequeueJitterFraction)}, nil
+}
+
+// getStageStatus fetches the ClusterStagedUpdateRun or StagedUpdateRun targeted by the ApprovalRequest and returns
+// the status of the given stage, or nil if the UpdateRun has no such stage. It also reports whether the UpdateRun is
+// initialized; the stage status is nil until it is.
+func (r *Reconciler) getStageStatus(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	updateRunName, stageName string,
+) (*placementv1beta1.StageUpdatingStatus, bool, error) {
+	var stagesStatus []placementv1beta1.StageUpdatingStatus
+	var conditions []metav1.Condition
+	if approvalReqObj.GetNamespace() == "" {
+		updateRun := &placementv1beta1.ClusterStagedUpdateRun{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: updateRunName}, updateRun); err != nil {
+			return nil, false, err
+		}
+		stagesStatus = updateRun.Status.StagesStatus
+		conditions = updateRun.Status.Conditions
+	} else {
+		updateRun := &placementv1beta1.StagedUpdateRun{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: updateRunName, Namespace: approvalReqObj.GetNamespace()}, updateRun); err != nil {
+			return nil, false, err
+		}
+		stagesStatus = updateRun.Status.StagesStatus
+		conditions = updateRun.Status.Conditions
+	}
+	// The UpdateRun computes the clusters of all its stages before it reports Initialized=True, so only then is
+	// the cluster list of a stage complete
+	if !meta.IsStatusConditionTrue(conditions, string(placementv1beta1.StagedUpdateRunConditionInitialized)) {
+		return nil, false, nil
+	}
+
+	// Find the stage
+	for i := range stagesStatus {
+		if stagesStatus[i].StageName == stageName {
+			return &stagesStatus[i], true, nil
+		}
+	}
+	return nil, true, nil
+}
+
+// stageClusters returns the names of the clusters in a stage, and the set of those whose update within the stage
+// has started but not completed yet. Their workloads may not run the new version yet, so their health is not
+// checked until they finish. Clusters that have not started are not considered updating: an approval before
+// the stage is requested before any cluster starts, and must still check them.
+func stageClusters(stageStatus *placementv1beta1.StageUpdatingStatus) ([]string, map[string]bool) {
+	clusterNames := make([]string, 0, len(stageStatus.Clusters))
+	updatingClusters := make(map[string]bool)
+	for _, cluster := range stageStatus.Clusters {
+		clusterNames = append(clusterNames, cluster.ClusterName)
+		if meta.IsStatusConditionTrue(cluster.Conditions, string(placementv1beta1.ClusterUpdatingConditionStarted)) &&
+			meta.FindStatusCondition(cluster.Conditions, string(placementv1beta1.ClusterUpdatingConditionSucceeded)) == nil {
+			updatingClusters[cluster.ClusterName] = true
+		}
+	}
+	return clusterNames, updatingClusters
+}
+
+// findUpdateRunOfOtherScope looks for an UpdateRun named updateRunName with the other scope than the ApprovalRequest:
+// a ClusterStagedUpdateRun for a namespaced ApprovalRequest, or a StagedUpdateRun in any namespace for a
+// ClusterApprovalRequest. It returns the namespace/name of the first one found, or an empty string if there is none.
+func (r *Reconciler) findUpdateRunOfOtherScope(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	updateRunName string,
+) (string, error) {
+	if approvalReqObj.GetNamespace() != "" {
+		updateRun := &placementv1beta1.ClusterStagedUpdateRun{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: updateRunName}, updateRun); err != nil {
+			if errors.IsNotFound(err) {
+				return "", nil
+			}
+			return "", fmt.Errorf("failed to get ClusterStagedUpdateRun: %w", err)
+		}
+		return updateRun.Name, nil
+	}
+
+	updateRunList := &placementv1beta1.StagedUpdateRunList{}
+	if err := r.Client.List(ctx, updateRunList); err != nil {
+		return "", fmt.Errorf("failed to list StagedUpdateRuns: %w", err)
+	}
+	for i := range updateRunList.Items {
+		if updateRunList.Items[i].Name == updateRunName {
+			return fmt.Sprintf("%s/%s", updateRunList.Items[i].Namespace, updateRunList.Items[i].Name), nil
+		}
+	}
+	return "", nil
+}
+
+// invalidApprovalRequestMessage describes the missing target fields of an ApprovalRequest, or returns an empty
+// string if both TargetUpdateRun and TargetStage are set.
+func invalidApprovalRequestMessage(approvalReqObj placementv1beta1.ApprovalRequestObj) string {
+	spec := approvalReqObj.GetApprovalRequestSpec()
+	var missing []string
+	if spec.TargetUpdateRun == "" {
+		missing = append(missing, "spec.parentStageRollout")
+	}
+	if spec.TargetStage == "" {
+		missing = append(missing, "spec.targetStage")
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("ApprovalRequest does not set %s", strings.Join(missing, " and "))
+}
+
+// scopeMismatchMessage describes an ApprovalRequest that targets an UpdateRun of the other scope.
+func scopeMismatchMessage(approvalReqObj placementv1beta1.ApprovalRequestObj, otherScopeUpdateRun string) string {
+	if approvalReqObj.GetNamespace() != "" {
+		return fmt.Sprintf("ApprovalRequest is namespaced but its target UpdateRun %s is a ClusterStagedUpdateRun; a namespaced ApprovalRequest must target a StagedUpdateRun in namespace %s",
+			otherScopeUpdateRun, approvalReqObj.GetNamespace())
+	}
+	return fmt.Sprintf("ClusterApprovalRequest is cluster-scoped but its target UpdateRun %s is a StagedUpdateRun; a ClusterApprovalRequest must target a ClusterStagedUpdateRun",
+		otherScopeUpdateRun)
+}
+
+// findConflictingApprovalRequest returns the name of another, older ApprovalRequest (or ClusterApprovalRequest)
+// that targets the same update run and stage, or an empty string if there is none.
+// Creation timestamps decide which request wins; ties are broken by name.
+func (r *Reconciler) findConflictingApprovalRequest(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj) (string, error) {
+	approvalReqs, err := r.listSiblingApprovalRequests(ctx, approvalReqObj)
+	if err != nil {
+		return "", err
+	}
+
+	spec := approvalReqObj.GetApprovalRequestSpec()
+	created := approvalReqObj.GetCreationTimestamp()
+	for _, other := range approvalReqs {
+		if other.GetUID() == approvalReqObj.GetUID() || !other.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		otherSpec := other.GetApprovalRequestSpec()
+		if otherSpec.TargetUpdateRun != spec.TargetUpdateRun || otherSpec.TargetStage != spec.TargetStage {
+			continue
+		}
+		otherCreated := other.GetCreationTimestamp()
+		if otherCreated.Before(&created) || (otherCreated.Equal(&created) && other.GetName() < approvalReqObj.GetName()) {
+			return other.GetName(), nil
+		}
+	}
+	return "", nil
+}
+
+// listSiblingApprovalRequests lists the ApprovalRequests of the same scope as approvalReqObj: all
+// ClusterApprovalRequests, or the ApprovalRequests in the same namespace.
+func (r *Reconciler) listSiblingApprovalRequests(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj) ([]placementv1beta1.ApprovalRequestObj, error) {
+	var approvalReqList placementv1beta1.ApprovalRequestObjList
+	var listOptions []client.ListOption
+	if approvalReqObj.GetNamespace() == "" {
+		approvalReqList = &placementv1beta1.ClusterApprovalRequestList{}
+	} else {
+		approvalReqList = &placementv1beta1.ApprovalRequestList{}
+		listOptions = append(listOptions, client.InNamespace(approvalReqObj.GetNamespace()))
+	}
+	if err := r.Client.List(ctx, approvalReqList, listOptions...); err != nil {
+		return nil, fmt.Errorf("failed to list ApprovalRequests: %w", err)
+	}
+	return approvalReqList.GetApprovalRequestObjs(), nil
+}
+
+// checkStageDependency returns a non-empty message describing why the stage is blocked if the WorkloadTracker makes
+// it depend on a prior stage whose ApprovalRequests of the same update run are not all approved, or whose latest
+// approval is more recent than the cooldown.
+func (r *Reconciler) checkStageDependency(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	stageDependencies []autoapprovev1alpha1.StageDependency,
+	updateRunName, stageName string,
+) (string, error) {
+	var dependency *autoapprovev1alpha1.StageDependency
+	for i := range stageDependencies {
+		if stageDependencies[i].Stage == stageName {
+			dependency = &stageDependencies[i]
+			break
+		}
+	}
+	if dependency == nil {
+		return "", nil
+	}
+
+	approvalReqs, err := r.listSiblingApprovalRequests(ctx, approvalReqObj)
+	if err != nil {
+		return "", err
+	}
+
+	var latestApproval time.Time
+	found := false
+	for _, other := range approvalReqs {
+		otherSpec := other.GetApprovalRequestSpec()
+		if otherSpec.TargetUpdateRun != updateRunName || otherSpec.TargetStage != dependency.DependsOnStage || !other.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		found = true
+		approvedCond := meta.FindStatusCondition(other.GetApprovalRequestStatus().Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+		if approvedCond == nil || approvedCond.Status != metav1.ConditionTrue {
+			return fmt.Sprintf("Waiting for ApprovalRequest %s of prior stage %s to be approved", other.GetName(), dependency.DependsOnStage), nil
+		}
+		if approvedCond.LastTransitionTime.After(latestApproval) {
+			latestApproval = approvedCond.LastTransitionTime.Time
+		}
+	}
+	if !found {
+		return fmt.Sprintf("Waiting for an ApprovalRequest of prior stage %s", dependency.DependsOnStage), nil
+	}
+	if dependency.Cooldown != nil {
+		if remaining := dependency.Cooldown.Duration - time.Since(latestApproval); remaining > 0 {
+			return fmt.Sprintf("Prior stage %s was approved at %s, waiting for the cooldown of %s",
+				dependency.DependsOnStage, latestApproval.UTC().Format(time.RFC3339), dependency.Cooldown.Duration), nil
+		}
+	}
+	return "", nil
+}
+
+// setConflictingCondition sets the ConflictingApprovalRequest condition when conflictingName is not empty
+// and removes it otherwise. The status is only written when the condition changes.
+func (r *Reconciler) setConflictingCondition(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj, conflictingName string) error {
+	spec := approvalReqObj.GetApprovalRequestSpec()
+	status := approvalReqObj.GetApprovalRequestStatus()
+	var changed bool
+	if conflictingName == "" {
+		changed = meta.RemoveStatusCondition(&status.Conditions, approvalRequestConditionConflicting)
+	} else {
+		changed = meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               approvalRequestConditionConflicting,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: approvalReqObj.GetGeneration(),
+			Reason:             "OlderApprovalRequestExists",
+			Message:            fmt.Sprintf("ApprovalRequest %s was created first for update run %s stage %s", conflictingName, spec.TargetUpdateRun, spec.TargetStage),
+		})
+	}
+	if !changed {
+		return nil
+	}
+
+	approvalReqObj.SetApprovalRequestStatus(*status)
+	if err := r.Client.Status().Update(ctx, approvalReqObj); err != nil {
+		return fmt.Errorf("failed to update ApprovalRequest status: %w", err)
+	}
+	if conflictingName != "" {
+		r.recorder.Event(approvalReqObj, "Warning", approvalRequestConditionConflicting, fmt.Sprintf("ApprovalRequest %s already targets the same update run and stage", conflictingName))
+	}
+	return nil
 }
 
-// ensureMetricCollectorReports creates MetricCollectorReport in each fleet-member-{clusterName} namespace
+// ensureMetricCollectorReports creates MetricCollectorReport in each fleet-member-{clusterName} namespace.
+// The existing reports of the update run and stage are listed once from the cache and compared with the desired
+// state, so that writes are only issued for reports that are missing or out of date.
+// A cluster whose report cannot be written does not stop the reports of the other clusters from being ensured;
+// the errors of all such clusters are returned together. Namespaces that do not exist yet are not an error and
+// are returned as pending instead. The number of existing reports that were updated is returned as well.
 func (r *Reconciler) ensureMetricCollectorReports(
 	ctx context.Context,
 	approvalReq placementv1beta1.ApprovalRequestObj,
 	clusterNames []string,
-	updateRunName, stageName string,
-) error {
+	updateRunName, stageName, trackerName string,
+) ([]string, int, error) {
 	// Generate report name (same for all clusters, different namespaces)
 	reportName := fmt.Sprintf("mc-%s-%s", updateRunName, stageName)
 
+	reportList := &autoapprovev1alpha1.MetricCollectorReportList{}
+	if err := r.Client.List(ctx, reportList,
+		client.MatchingFields{reportUpdateRunStageIndex: updateRunStageIndexValue(updateRunName, stageName)},
+	); err != nil {
+		return nil, 0, fmt.Errorf("failed to list MetricCollectorReports: %w", err)
+	}
+	existingByNamespace := make(map[string]*autoapprovev1alpha1.MetricCollectorReport, len(reportList.Items))
+	for i := range reportList.Items {
+		if reportList.Items[i].Name == reportName {
+			existingByNamespace[reportList.Items[i].Namespace] = &reportList.Items[i]
+		}
+	}
+
 	// Create MetricCollectorReport in each fleet-member namespace
 	// Note: We cannot use owner references here because Kubernetes does not allow cross-namespace
 	// owner references. The ApprovalRequest (in one namespace or cluster-scoped) cannot be set as
 	// the owner of MetricCollectorReports in different fleet-member-* namespaces. Instead, we use
 	// a finalizer on the ApprovalRequest to ensure proper cleanup when it's deleted.
+	var created, updated, unchanged int
+	var errs []error
+	var pendingNamespaces []string
 	for _, clusterName := range clusterNames {
-		reportNamespace := fmt.Sprintf(utils.NamespaceNameFormat, clusterName)
+		reportNamespace := r.memberNamespace(clusterName)
+
+		existing, ok := existingByNamespace[reportNamespace]
+		if !ok {
+			report := &autoapprovev1alpha1.MetricCollectorReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      reportName,
+					Namespace: reportNamespace,
+				},
+			}
+			r.mutateMetricCollectorReport(report, approvalReq, clusterName, updateRunName, stageName, trackerName)
+			err := r.writeReport(ctx, func() error { return r.Client.Create(ctx, report) })
+			if errors.IsAlreadyExists(err) {
+				// The report exists without the labels of the index, so fall back to reading and updating it
+				err = r.writeReport(ctx, func() error {
+					_, err := controllerutil.CreateOrUpdate(ctx, r.Client, report, func() error {
+						r.mutateMetricCollectorReport(report, approvalReq, clusterName, updateRunName, stageName, trackerName)
+						return nil
+					})
+					return err
+				})
+			}
+			if errors.IsNotFound(err) {
+				// Creating a namespaced object only fails with NotFound if the namespace does not exist
+				klog.V(2).InfoS("Fleet member namespace does not exist yet", "report", reportName, "namespace", reportNamespace, "cluster", clusterName)
+				pendingNamespaces = append(pendingNamespaces, reportNamespace)
+				continue
+			}
+			if err != nil {
+				klog.ErrorS(err, "Failed to create MetricCollectorReport", "report", reportName, "namespace", reportNamespace, "cluster", clusterName)
+				errs = append(errs, fmt.Errorf("failed to create MetricCollectorReport in %s: %w", reportNamespace, err))
+				continue
+			}
+			created++
+			klog.V(2).InfoS("Created MetricCollectorReport", "report", reportName, "namespace", reportNamespace, "cluster", clusterName)
+			continue
+		}
 
-		report := &autoapprovev1alpha1.MetricCollectorReport{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      reportName,
-				Namespace: reportNamespace,
-			},
+		desired := existing.DeepCopy()
+		r.mutateMetricCollectorReport(desired, approvalReq, clusterName, updateRunName, stageName, trackerName)
+		if equality.Semantic.DeepEqual(existing, desired) {
+			unchanged++
+			continue
+		}
+		if err := r.writeReport(ctx, func() error { return r.Client.Update(ctx, desired) }); err != nil {
+			klog.ErrorS(err, "Failed to update MetricCollectorReport", "report", reportName, "namespace", reportNamespace, "cluster", clusterName)
+			errs = append(errs, fmt.Errorf("failed to update MetricCollectorReport in %s: %w", reportNamespace, err))
+			continue
 		}
+		updated++
+		klog.V(2).InfoS("Updated MetricCollectorReport", "report", reportName, "namespace", reportNamespace, "cluster", clusterName)
+	}
 
-		// Create or update MetricCollectorReport using controllerutil
-		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, report, func() error {
-			// Set labels
-			if report.Labels == nil {
-				report.Labels = make(map[string]string)
-			}
+	klog.V(2).InfoS("Ensured MetricCollectorReports", "approvalRequest", klog.KObj(approvalReq), "report", reportName, "created", created, "updated", updated, "unchanged", unchanged, "pending", len(pendingNamespaces), "failed", len(errs))
+	// Only record the reconciles that created reports, so that the periodic requeues do not repeat the event
+	if created > 0 {
+		r.recorder.Event(approvalReq, "Normal", "MetricCollectorReportsCreated",
+			fmt.Sprintf("Created MetricCollectorReport %s for %d of %d clusters in stage %s", reportName, created, len(clusterNames), stageName))
+	}
+	return pendingNamespaces, updated, utilerrors.NewAggregate(errs)
+}
 
-			// Set parent-approval-request label to uniquely identify the ApprovalRequest
-			// For cluster-scoped ApprovalRequests: just the name
-			// For namespace-scoped ApprovalRequests: namespace.name format (using dot as separator)
-			if approvalReq.GetNamespace() == "" {
-				// Cluster-scoped: ClusterApprovalRequest
-				report.Labels[parentApprovalRequestLabel] = approvalReq.GetName()
-			} else {
-				// Namespace-scoped: ApprovalRequest (use dot instead of slash for valid label)
-				report.Labels[parentApprovalRequestLabel] = fmt.Sprintf("%s.%s", approvalReq.GetNamespace(), approvalReq.GetName())
-			}
+// mutateMetricCollectorReport sets the labels and spec the controller owns on the MetricCollectorReport of a cluster.
+// The report references the WorkloadTracker trackerName, which is the one named after the UpdateRun unless the
+// default WorkloadTracker applies.
+func (r *Reconciler) mutateMetricCollectorReport(
+	report *autoapprovev1alpha1.MetricCollectorReport,
+	approvalReq placementv1beta1.ApprovalRequestObj,
+	clusterName, updateRunName, stageName, trackerName string,
+) {
+	// Set labels
+	if report.Labels == nil {
+		report.Labels = make(map[string]string)
+	}
 
-			// Set spec
-			// PrometheusURL is a configurable spec field that could differ per cluster.
-			// For setup simplicity, we use a constant value pointing to the Prometheus service
-			// deployed via examples/prometheus/service.yaml and propagated to all clusters.
-			// This assumes Prometheus is deployed with the same service name/namespace on all member clusters.
-			report.Spec.PrometheusURL = prometheusURL
+	// Set parent-approval-request label to uniquely identify the ApprovalRequest
+	report.Labels[parentApprovalRequestLabel] = parentApprovalRequestLabelValue(approvalReq.GetNamespace(), approvalReq.GetName())
+	// Set workload identity labels used by the update-run/stage field index
+	report.Labels[updateRunLabel] = updateRunName
+	report.Labels[stageLabel] = stageName
+	report.Labels[memberClusterLabel] = clusterName
+
+	// Set spec
+	// PrometheusURL is a configurable spec field that could differ per cluster.
+	// For setup simplicity, we use a constant value pointing to the Prometheus service
+	// deployed via examples/prometheus/service.yaml and propagated to all clusters.
+	// This assumes Prometheus is deployed with the same service name/namespace on all member clusters.
+	// The URL and protocol can be overridden, e.g. to query a Thanos querier over gRPC.
+	report.Spec.PrometheusURL = defaultPrometheusURL
+	if r.PrometheusURL != "" {
+		report.Spec.PrometheusURL = r.PrometheusURL
+	}
+	report.Spec.Protocol = r.PrometheusProtocol
+	report.Spec.QueryTimeout = nil
+	if r.PrometheusQueryTimeout > 0 {
+		report.Spec.QueryTimeout = &metav1.Duration{Duration: r.PrometheusQueryTimeout}
+	}
 
-			return nil
-		})
+	// Reference the WorkloadTracker (named after the UpdateRun, or the default one) so the metric collector
+	// only collects metrics for the workloads this controller checks.
+	report.Spec.WorkloadTrackerRef = &autoapprovev1alpha1.WorkloadTrackerReference{
+		Kind:      autoapprovev1alpha1.ClusterStagedWorkloadTrackerKind,
+		Name:      trackerName,
+		Namespace: approvalReq.GetNamespace(),
+	}
+	if approvalReq.GetNamespace() != "" {
+		report.Spec.WorkloadTrackerRef.Kind = autoapprovev1alpha1.StagedWorkloadTrackerKind
+	}
+	report.Spec.QueryTemplate = r.QueryTemplate
+	report.Spec.HealthExpression = r.HealthExpression
+	report.Spec.HealthStateMapping = r.HealthStateMapping
+	report.Spec.HealthMetricNames = r.HealthMetricNames
+	report.Spec.ExtraLabelKeys = r.ExtraLabelKeys
+	report.Spec.ReportUnhealthyOnly = r.ReportUnhealthyOnly
+	report.Spec.StrictResultType = r.StrictResultType
+	report.Spec.AllowAggregatedSeries = r.AllowAggregatedSeries
+	report.Spec.RequireMetricExists = r.RequireMetricExists
+	report.Spec.MinPodUptime = nil
+	if r.MinPodUptime > 0 {
+		report.Spec.MinPodUptime = &metav1.Duration{Duration: r.MinPodUptime}
+	}
+	report.Spec.PodStartTimeMetric = r.PodStartTimeMetric
+}
 
-		if err != nil {
-			return fmt.Errorf("failed to create or update MetricCollectorReport in %s: %w", reportNamespace, err)
+// unhealthyReasonsForWorkload counts the unique unhealthy pods of a given workload per UnhealthyReason,
+// i.e. the pods whose health value was NaN or infinite rather than a number below 1, or that were not up
+// for the minimum pod uptime.
+func unhealthyReasonsForWorkload(
+	collectedMetrics []autoapprovev1alpha1.WorkloadMetric,
+	workload autoapprovev1alpha1.WorkloadReference,
+) map[string]int {
+	podsByReason := make(map[string]map[string]bool)
+	for _, metric := range collectedMetrics {
+		if metric.Health || metric.UnhealthyReason == "" ||
+			metric.Namespace != workload.Namespace || metric.WorkloadName != workload.Name || metric.WorkloadKind != workload.Kind {
+			continue
 		}
-
-		klog.V(2).InfoS("Ensured MetricCollectorReport", "report", reportName, "namespace", reportNamespace, "cluster", clusterName, "operation", op)
+		if podsByReason[metric.UnhealthyReason] == nil {
+			podsByReason[metric.UnhealthyReason] = make(map[string]bool)
+		}
+		podsByReason[metric.UnhealthyReason][metric.PodName] = true
 	}
 
-	return nil
+	counts := make(map[string]int, len(podsByReason))
+	for reason, pods := range podsByReason {
+		counts[reason] = len(pods)
+	}
+	return counts
 }
 
-// countHealthyPodsForWorkload counts the number of unique healthy pods for a given workload
-// from the collected metrics. It returns the count of healthy pods and the total count of pods found.
-func countHealthyPodsForWorkload(
-	collectedMetrics []autoapprovev1alpha1.WorkloadMetric,
+// expectedHealthyReplicasForWorkload returns the number of healthy replicas required for a workload.
+// If the workload uses desired replicas and the report carries its desired replica count from
+// kube-state-metrics, that count is used; otherwise the static HealthyReplicas is used.
+func expectedHealthyReplicasForWorkload(
+	desiredReplicas []autoapprovev1alpha1.WorkloadDesiredReplicas,
 	workload autoapprovev1alpha1.WorkloadReference,
-) (healthyCount int32, totalCount int32) {
-	// Use a map to track unique pods and their health status
-	healthyPods := make(map[string]bool)
-	allPods := make(map[string]bool)
+) int32 {
+	if !workload.UseDesiredReplicas {
+		return workload.HealthyReplicas
+	}
+	for _, desired := range desiredReplicas {
+		if desired.Namespace == workload.Namespace &&
+			desired.WorkloadName == workload.Name &&
+			desired.WorkloadKind == workload.Kind {
+			return desired.DesiredReplicas
+		}
+	}
+	return workload.HealthyReplicas
+}
 
-	for _, metric := range collectedMetrics {
-		// Match workload by namespace, name, and kind
-		if metric.Namespace == workload.Namespace &&
-			metric.WorkloadName == workload.Name &&
-			workload.Kind == metric.WorkloadKind {
-			// Track all pods
-			allPods[metric.PodName] = true
-			// Track healthy pods
-			if metric.Health {
-				healthyPods[metric.PodName] = true
+// workloadHealthEvaluation is the outcome of evaluating the health of the tracked workloads of an ApprovalRequest's
+// stage. checkWorkloadHealthAndApprove acts on it, and the debug endpoint serves it as JSON.
+type workloadHealthEvaluation struct {
+	UpdateRun       string `json:"updateRun"`
+	Stage           string `json:"stage"`
+	WorkloadTracker string `json:"workloadTracker,omitempty"`
+	// BlockedReason and BlockedMessage are set if workload health could not be evaluated;
+	// they are surfaced as a Progressing=False condition.
+	BlockedReason  string `json:"blockedReason,omitempty"`
+	BlockedMessage string `json:"blockedMessage,omitempty"`
+	// NoWorkloads is true if the WorkloadTracker lists no workloads for the stage, in which case EmptyTrackerBehavior
+	// decides whether the ApprovalRequest is approved.
+	NoWorkloads              bool                      `json:"noWorkloads,omitempty"`
+	InGracePeriod            bool                      `json:"inGracePeriod"`
+	AllHealthy               bool                      `json:"allHealthy"`
+	RequiredWorkloads        int                       `json:"requiredWorkloads"`
+	Clusters                 []clusterHealthEvaluation `json:"clusters,omitempty"`
+	UnhealthyDetails         []string                  `json:"unhealthyDetails,omitempty"`
+	OptionalUnhealthyDetails []string                  `json:"optionalUnhealthyDetails,omitempty"`
+	// DegradedDetails describe the degraded workloads that do not block approval, which is then approved with
+	// a warning event. They are empty if BlockDegradedWorkloads is set.
+	DegradedDetails []string `json:"degradedDetails,omitempty"`
+	// WarningDetails describe the workloads whose lowest health value is below their WarnThreshold but not their
+	// CriticalThreshold; they do not block approval, which is then approved with a warning event.
+	WarningDetails []string `json:"warningDetails,omitempty"`
+	// UpdatingClusters are the clusters whose update within the stage is still in progress; their workload health
+	// is not checked, and they block approval without being reported as unhealthy.
+	UpdatingClusters []string `json:"updatingClusters,omitempty"`
+	// ExcludedClusters are the clusters of the stage in maintenance; they are left out of the evaluation.
+	ExcludedClusters []string `json:"excludedClusters,omitempty"`
+	// HealthyClusterWeight and TotalClusterWeight are the summed weights of the healthy and of all clusters,
+	// compared against MinHealthyClusterWeightPercent if the WorkloadTracker sets it.
+	HealthyClusterWeight           int32  `json:"healthyClusterWeight"`
+	TotalClusterWeight             int32  `json:"totalClusterWeight"`
+	MinHealthyClusterWeightPercent *int32 `json:"minHealthyClusterWeightPercent,omitempty"`
+	// HealthyPods and TotalPods are the pods of the required workloads summed across the evaluated clusters,
+	// compared against MinHealthyPodPercent if the WorkloadTracker sets it.
+	HealthyPods          int32  `json:"healthyPods"`
+	TotalPods            int32  `json:"totalPods"`
+	MinHealthyPodPercent *int32 `json:"minHealthyPodPercent,omitempty"`
+
+	initialGracePeriod time.Duration
+	stageStartTime     *metav1.Time
+}
+
+// clusterHealthEvaluation is the evaluation of the tracked workloads on a single member cluster.
+type clusterHealthEvaluation struct {
+	Cluster            string                   `json:"cluster"`
+	Report             string                   `json:"report,omitempty"`
+	LastCollectionTime *metav1.Time             `json:"lastCollectionTime,omitempty"`
+	ReportAgeSeconds   *float64                 `json:"reportAgeSeconds,omitempty"`
+	Workloads          []workloadHealthDecision `json:"workloads,omitempty"`
+	// Updating is true if the cluster's update within the stage is still in progress, in which case its
+	// workloads are not evaluated.
+	Updating bool `json:"updating,omitempty"`
+	// Healthy is true if all required workloads are healthy on the cluster.
+	Healthy bool  `json:"healthy"`
+	Weight  int32 `json:"weight"`
+
+	report *autoapprovev1alpha1.MetricCollectorReport
+}
+
+// healthyClusterWeightMet reports whether the healthy clusters carry at least the MinHealthyClusterWeightPercent
+// of the total cluster weight. It is always false if the WorkloadTracker does not set MinHealthyClusterWeightPercent,
+// if every cluster has a weight of 0, since no cluster then counts towards approval, or if some clusters are still
+// updating, since their health is not checked yet.
+func (e *workloadHealthEvaluation) healthyClusterWeightMet() bool {
+	if e.MinHealthyClusterWeightPercent == nil || e.TotalClusterWeight == 0 || len(e.UpdatingClusters) > 0 {
+		return false
+	}
+	return int64(e.HealthyClusterWeight)*100 >= int64(*e.MinHealthyClusterWeightPercent)*int64(e.TotalClusterWeight)
+}
+
+// healthyPodPercentMet reports whether at least MinHealthyPodPercent of the pods of the required workloads across
+// the stage are healthy. It is always false if the WorkloadTracker does not set MinHealthyPodPercent, if no pods
+// were counted, or if some clusters are still updating, since their pods are not counted yet.
+func (e *workloadHealthEvaluation) healthyPodPercentMet() bool {
+	if e.MinHealthyPodPercent == nil || e.TotalPods == 0 || len(e.UpdatingClusters) > 0 {
+		return false
+	}
+	return int64(e.HealthyPods)*100 >= int64(*e.MinHealthyPodPercent)*int64(e.TotalPods)
+}
+
+// clusterWeight returns the weight of a cluster in the approval decision, which is 1 unless the WorkloadTracker overrides it.
+func clusterWeight(clusterWeights map[string]int32, clusterName string) int32 {
+	if weight, ok := clusterWeights[clusterName]; ok {
+		return weight
+	}
+	return 1
+}
+
+// workloadHealthDecision is the evaluation of a single tracked workload on a member cluster.
+type workloadHealthDecision struct {
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	Kind            string `json:"kind"`
+	Optional        bool   `json:"optional,omitempty"`
+	State           string `json:"state"`
+	HealthyPods     int32  `json:"healthyPods"`
+	TotalPods       int32  `json:"totalPods"`
+	ExpectedHealthy int32  `json:"expectedHealthy"`
+	// Value is the lowest health value of the workload, if it is judged by its WarnThreshold and CriticalThreshold.
+	Value  string `json:"value,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+const (
+	// workloadStateHealthy means the workload has enough healthy pods.
+	workloadStateHealthy = "Healthy"
+	// workloadStateDegraded means the workload has enough healthy pods, but not all of its pods are healthy.
+	workloadStateDegraded = "Degraded"
+	// workloadStateUnhealthy means the workload has fewer healthy pods than expected.
+	workloadStateUnhealthy = "Unhealthy"
+	// workloadStateMissing means the report has no metrics for the workload.
+	workloadStateMissing = "Missing"
+	// workloadStateMissingAllowed means the report has no metrics for the workload, but its AllowMissingAfter has elapsed.
+	workloadStateMissingAllowed = "MissingAllowed"
+	// workloadStateWarning means the lowest health value of the workload is below its WarnThreshold.
+	workloadStateWarning = "Warning"
+	// workloadStateCritical means the lowest health value of the workload is below its CriticalThreshold, or NaN.
+	workloadStateCritical = "Critical"
+	// workloadStateParseError means a health value of the workload, judged by its thresholds, is not a number.
+	workloadStateParseError = "ParseError"
+)
+
+// excludeClustersInMaintenance splits the clusters of a stage into those whose fleet-member namespace does not carry
+// the maintenance annotation set to "true", and those that do. Clusters whose namespace does not exist yet are not
+// in maintenance.
+func (r *Reconciler) excludeClustersInMaintenance(ctx context.Context, clusterNames []string) ([]string, []string, error) {
+	effectiveClusters := make([]string, 0, len(clusterNames))
+	var excludedClusters []string
+	for _, clusterName := range clusterNames {
+		namespace := &corev1.Namespace{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: r.memberNamespace(clusterName)}, namespace); err != nil && !errors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("failed to get namespace of cluster %s: %w", clusterName, err)
+		}
+		if namespace.Annotations[maintenanceAnnotation] == "true" {
+			excludedClusters = append(excludedClusters, clusterName)
+			continue
+		}
+		effectiveClusters = append(effectiveClusters, clusterName)
+	}
+	return effectiveClusters, excludedClusters, nil
+}
+
+// workloadTrackerName returns the name of the WorkloadTracker that applies to the UpdateRun: the one named after the
+// UpdateRun if it exists, otherwise DefaultWorkloadTracker if that exists. If neither exists, the name of the UpdateRun
+// is returned, so that the missing tracker is reported under it. Trackers of StagedUpdateRuns are looked up in the
+// namespace of the ApprovalRequest.
+func (r *Reconciler) workloadTrackerName(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj, updateRunName string) (string, error) {
+	if r.DefaultWorkloadTracker == "" || r.DefaultWorkloadTracker == updateRunName {
+		return updateRunName, nil
+	}
+	for _, name := range []string{updateRunName, r.DefaultWorkloadTracker} {
+		var tracker client.Object = &autoapprovev1alpha1.ClusterStagedWorkloadTracker{}
+		if approvalReqObj.GetNamespace() != "" {
+			tracker = &autoapprovev1alpha1.StagedWorkloadTracker{}
+		}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: approvalReqObj.GetNamespace()}, tracker)
+		if err == nil {
+			if name != updateRunName {
+				klog.V(2).InfoS("Using the default WorkloadTracker", "approvalRequest", klog.KObj(approvalReqObj), "updateRun", updateRunName, "workloadTracker", name)
 			}
+			return name, nil
+		}
+		if !errors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get WorkloadTracker %s: %w", name, err)
 		}
 	}
+	return updateRunName, nil
+}
 
-	return int32(len(healthyPods)), int32(len(allPods))
+// defaultWorkloadTrackerNotFoundSuffix extends the message of a missing WorkloadTracker with the default
+// WorkloadTracker, if one is configured, since that was not found either.
+func (r *Reconciler) defaultWorkloadTrackerNotFoundSuffix() string {
+	if r.DefaultWorkloadTracker == "" {
+		return ""
+	}
+	return fmt.Sprintf(", nor the default WorkloadTracker %s", r.DefaultWorkloadTracker)
 }
 
-// checkWorkloadHealthAndApprove checks if all workloads specified in ClusterStagedWorkloadTracker or StagedWorkloadTracker are healthy
-// across all clusters in the stage, and approves the ApprovalRequest if they are.
-func (r *Reconciler) checkWorkloadHealthAndApprove(
+// evaluateWorkloadHealth evaluates whether all workloads specified in ClusterStagedWorkloadTracker or
+// StagedWorkloadTracker are healthy across all clusters in the stage, without modifying any object.
+// Within the tracker's initial grace period after stageStartTime, unhealthy workloads are only logged at a higher verbosity.
+func (r *Reconciler) evaluateWorkloadHealth(
 	ctx context.Context,
 	approvalReqObj placementv1beta1.ApprovalRequestObj,
 	clusterNames []string,
+	updatingClusters map[string]bool,
 	updateRunName, stageName string,
-) error {
+	stageStartTime *metav1.Time,
+) (*workloadHealthEvaluation, error) {
 	approvalReqRef := klog.KObj(approvalReqObj)
+	evaluation := &workloadHealthEvaluation{
+		UpdateRun:      updateRunName,
+		Stage:          stageName,
+		stageStartTime: stageStartTime,
+	}
 
 	klog.V(2).InfoS("Starting workload health check", "approvalRequest", approvalReqRef, "clusters", clusterNames)
 
+	// Clusters in maintenance neither block approval nor count towards it
+	clusterNames, excludedClusters, err := r.excludeClustersInMaintenance(ctx, clusterNames)
+	if err != nil {
+		klog.ErrorS(err, "Failed to check clusters for maintenance", "approvalRequest", approvalReqRef)
+		return nil, err
+	}
+	if len(excludedClusters) > 0 {
+		klog.InfoS("Excluding clusters in maintenance from the health check", "approvalRequest", approvalReqRef, "excludedClusters", excludedClusters, "annotation", maintenanceAnnotation)
+		evaluation.ExcludedClusters = excludedClusters
+	}
+
+	// Every workload is vacuously healthy on no clusters, which must not count as approval
+	if len(clusterNames) == 0 {
+		evaluation.BlockedReason = progressingReasonNoEffectiveClusters
+		evaluation.BlockedMessage = fmt.Sprintf("Stage %s of UpdateRun %s has no clusters whose workload health could be verified", stageName, updateRunName)
+		if len(excludedClusters) > 0 {
+			evaluation.BlockedMessage += fmt.Sprintf("; clusters in maintenance: %s", strings.Join(excludedClusters, ", "))
+		}
+		return evaluation, nil
+	}
+
 	// Get the appropriate WorkloadTracker based on scope
-	// The WorkloadTracker name matches the UpdateRun name
+	// The WorkloadTracker name matches the UpdateRun name, unless only the default WorkloadTracker exists
+	trackerName, err := r.workloadTrackerName(ctx, approvalReqObj, updateRunName)
+	if err != nil {
+		klog.ErrorS(err, "Failed to look up WorkloadTracker", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
+		return nil, err
+	}
 	var workloads []autoapprovev1alpha1.WorkloadReference
-	var workloadTrackerName string
+	var initialGracePeriod *metav1.Duration
+	var stageDependencies []autoapprovev1alpha1.StageDependency
+	var clusterWeights map[string]int32
 
 	if approvalReqObj.GetNamespace() == "" {
 		// Cluster-scoped: Get ClusterStagedWorkloadTracker with same name as ClusterStagedUpdateRun
 		clusterWorkloadTracker := &autoapprovev1alpha1.ClusterStagedWorkloadTracker{}
-		if err := r.Client.Get(ctx, types.NamespacedName{Name: updateRunName}, clusterWorkloadTracker); err != nil {
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: trackerName}, clusterWorkloadTracker); err != nil {
 			if errors.IsNotFound(err) {
 				klog.V(2).InfoS("ClusterStagedWorkloadTracker not found, skipping health check", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
-				return nil
+				evaluation.BlockedReason = progressingReasonWorkloadTrackerNotFound
+				evaluation.BlockedMessage = fmt.Sprintf("ClusterStagedWorkloadTracker %s not found", updateRunName) + r.defaultWorkloadTrackerNotFoundSuffix()
+				return evaluation, nil
 			}
 			klog.ErrorS(err, "Failed to get ClusterStagedWorkloadTracker", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
-			return fmt.Errorf("failed to get ClusterStagedWorkloadTracker: %w", err)
+			return nil, fmt.Errorf("failed to get ClusterStagedWorkloadTracker: %w", err)
 		}
-		workloads = clusterWorkloadTracker.Workloads
-		workloadTrackerName = clusterWorkloadTracker.Name
-		klog.V(2).InfoS("Found ClusterStagedWorkloadTracker", "approvalRequest", approvalReqRef, "workloadTracker", workloadTrackerName, "workloadCount", len(workloads))
+		workloads = clusterWorkloadTracker.WorkloadsForStage(stageName)
+		evaluation.WorkloadTracker = clusterWorkloadTracker.Name
+		initialGracePeriod = clusterWorkloadTracker.InitialGracePeriod
+		stageDependencies = clusterWorkloadTracker.StageDependencies
+		clusterWeights = clusterWorkloadTracker.ClusterWeights
+		evaluation.MinHealthyClusterWeightPercent = clusterWorkloadTracker.MinHealthyClusterWeightPercent
+		evaluation.MinHealthyPodPercent = clusterWorkloadTracker.MinHealthyPodPercent
+		klog.V(2).InfoS("Found ClusterStagedWorkloadTracker", "approvalRequest", approvalReqRef, "workloadTracker", evaluation.WorkloadTracker, "workloadCount", len(workloads))
 	} else {
 		// Namespace-scoped: Get StagedWorkloadTracker with same name and namespace as StagedUpdateRun
 		stagedWorkloadTracker := &autoapprovev1alpha1.StagedWorkloadTracker{}
-		if err := r.Client.Get(ctx, types.NamespacedName{Name: updateRunName, Namespace: approvalReqObj.GetNamespace()}, stagedWorkloadTracker); err != nil {
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: trackerName, Namespace: approvalReqObj.GetNamespace()}, stagedWorkloadTracker); err != nil {
 			if errors.IsNotFound(err) {
 				klog.V(2).InfoS("StagedWorkloadTracker not found, skipping health check", "approvalRequest", approvalReqRef, "updateRun", updateRunName, "namespace", approvalReqObj.GetNamespace())
-				return nil
+				evaluation.BlockedReason = progressingReasonWorkloadTrackerNotFound
+				evaluation.BlockedMessage = fmt.Sprintf("StagedWorkloadTracker %s/%s not found", approvalReqObj.GetNamespace(), updateRunName) + r.defaultWorkloadTrackerNotFoundSuffix()
+				return evaluation, nil
 			}
 			klog.ErrorS(err, "Failed to get StagedWorkloadTracker", "approvalRequest", approvalReqRef, "updateRun", updateRunName)
-			return fmt.Errorf("failed to get StagedWorkloadTracker: %w", err)
+			return nil, fmt.Errorf("failed to get StagedWorkloadTracker: %w", err)
 		}
-		workloads = stagedWorkloadTracker.Workloads
-		workloadTrackerName = stagedWorkloadTracker.Name
+		workloads = stagedWorkloadTracker.WorkloadsForStage(stageName)
+		evaluation.WorkloadTracker = fmt.Sprintf("%s/%s", stagedWorkloadTracker.Namespace, stagedWorkloadTracker.Name)
+		initialGracePeriod = stagedWorkloadTracker.InitialGracePeriod
+		stageDependencies = stagedWorkloadTracker.StageDependencies
+		clusterWeights = stagedWorkloadTracker.ClusterWeights
+		evaluation.MinHealthyClusterWeightPercent = stagedWorkloadTracker.MinHealthyClusterWeightPercent
+		evaluation.MinHealthyPodPercent = stagedWorkloadTracker.MinHealthyPodPercent
 		klog.V(2).InfoS("Found StagedWorkloadTracker", "approvalRequest", approvalReqRef, "workloadTracker", klog.KObj(stagedWorkloadTracker), "workloadCount", len(workloads))
 	}
+	evaluation.RequiredWorkloads = countRequiredWorkloads(workloads)
+
+	// Only evaluate the stage once the prior stage it depends on, if any, is approved and stable
+	blockedMessage, err := r.checkStageDependency(ctx, approvalReqObj, stageDependencies, updateRunName, stageName)
+	if err != nil {
+		klog.ErrorS(err, "Failed to check stage dependency", "approvalRequest", approvalReqRef, "stage", stageName)
+		return nil, fmt.Errorf("failed to check stage dependency: %w", err)
+	}
+	if blockedMessage != "" {
+		klog.V(2).InfoS("Stage is waiting for a prior stage", "approvalRequest", approvalReqRef, "stage", stageName, "reason", blockedMessage)
+		evaluation.BlockedReason = progressingReasonWaitingForPriorStage
+		evaluation.BlockedMessage = blockedMessage
+		return evaluation, nil
+	}
 
 	if len(workloads) == 0 {
-		klog.V(2).InfoS("WorkloadTracker has no workloads defined, skipping health check", "approvalRequest", approvalReqRef, "workloadTracker", workloadTrackerName)
-		return nil
+		klog.V(2).InfoS("WorkloadTracker has no workloads defined, skipping health check", "approvalRequest", approvalReqRef, "workloadTracker", evaluation.WorkloadTracker)
+		evaluation.NoWorkloads = true
+		return evaluation, nil
 	}
 
 	// MetricCollectorReport name is same as MetricCollector name
 	metricCollectorName := fmt.Sprintf("mc-%s-%s", updateRunName, stageName)
 
-	// Check each cluster for the required workloads
-	allHealthy := true
-	unhealthyDetails := []string{}
+	// List the MetricCollectorReports for this update run and stage through the field index,
+	// so the lookup only touches matching reports regardless of how many exist on the hub.
+	reportList := &autoapprovev1alpha1.MetricCollectorReportList{}
+	if err := r.Client.List(ctx, reportList,
+		client.MatchingFields{reportUpdateRunStageIndex: updateRunStageIndexValue(updateRunName, stageName)},
+		client.MatchingLabels{parentApprovalRequestLabel: parentApprovalRequestLabelValue(approvalReqObj.GetNamespace(), approvalReqObj.GetName())},
+	); err != nil {
+		klog.ErrorS(err, "Failed to list MetricCollectorReports", "approvalRequest", approvalReqRef, "updateRun", updateRunName, "stage", stageName)
+		return nil, fmt.Errorf("failed to list MetricCollectorReports: %w", err)
+	}
+	reportsByNamespace := make(map[string]*autoapprovev1alpha1.MetricCollectorReport, len(reportList.Items))
+	for i := range reportList.Items {
+		if reportList.Items[i].Name == metricCollectorName {
+			reportsByNamespace[reportList.Items[i].Namespace] = &reportList.Items[i]
+		}
+	}
 
+	// Only evaluate workload health once every cluster in the stage has reported at least once for the current spec
+	// of its report, so that a partial or outdated view of the stage never counts towards approval
+	var clustersWithoutReport, clustersWaitingForReport, clustersWithOutdatedReport []string
+	evaluation.Clusters = make([]clusterHealthEvaluation, 0, len(clusterNames))
 	for _, clusterName := range clusterNames {
-		reportNamespace := fmt.Sprintf(utils.NamespaceNameFormat, clusterName)
-
-		klog.V(2).InfoS("Checking MetricCollectorReport", "approvalRequest", approvalReqRef, "cluster", clusterName, "reportName", metricCollectorName, "reportNamespace", reportNamespace)
+		clusterEvaluation := clusterHealthEvaluation{Cluster: clusterName, Updating: updatingClusters[clusterName]}
+		reportNamespace := r.memberNamespace(clusterName)
+		report, ok := reportsByNamespace[reportNamespace]
+		if ok {
+			clusterEvaluation.Report = fmt.Sprintf("%s/%s", report.Namespace, report.Name)
+			clusterEvaluation.report = report
+			clusterEvaluation.LastCollectionTime = report.Status.LastCollectionTime
+			if report.Status.LastCollectionTime != nil {
+				age := time.Since(report.Status.LastCollectionTime.Time).Seconds()
+				clusterEvaluation.ReportAgeSeconds = &age
+			}
+		}
+		if clusterEvaluation.Updating {
+			// The report of a cluster that is still updating is only needed once the cluster finishes
+			klog.V(2).InfoS("Cluster is still updating, not checking its workload health yet", "approvalRequest", approvalReqRef, "cluster", clusterName)
+			evaluation.UpdatingClusters = append(evaluation.UpdatingClusters, clusterName)
+		} else if !ok {
+			klog.V(2).InfoS("MetricCollectorReport not found", "approvalRequest", approvalReqRef, "cluster", clusterName, "report", metricCollectorName, "namespace", reportNamespace)
+			clustersWithoutReport = append(clustersWithoutReport, clusterName)
+		} else if report.Status.LastCollectionTime == nil {
+			klog.V(2).InfoS("MetricCollectorReport not collected yet", "approvalRequest", approvalReqRef, "cluster", clusterName, "report", metricCollectorName, "namespace", reportNamespace)
+			clustersWaitingForReport = append(clustersWaitingForReport, clusterName)
+		} else if !reportCollectedForCurrentSpec(report) {
+			klog.V(2).InfoS("MetricCollectorReport has not been successfully collected for its current spec yet", "approvalRequest", approvalReqRef, "cluster", clusterName, "report", metricCollectorName, "namespace", reportNamespace, "generation", report.Generation)
+			clustersWithOutdatedReport = append(clustersWithOutdatedReport, clusterName)
+		}
+		evaluation.Clusters = append(evaluation.Clusters, clusterEvaluation)
+	}
+	if len(clustersWithoutReport) > 0 {
+		evaluation.BlockedReason = progressingReasonReportNotReady
+		evaluation.BlockedMessage = fmt.Sprintf("MetricCollectorReport not found for clusters %v", clustersWithoutReport)
+		return evaluation, nil
+	}
+	if len(clustersWaitingForReport) > 0 {
+		evaluation.BlockedReason = progressingReasonWaitingForReports
+		evaluation.BlockedMessage = fmt.Sprintf("Waiting for the first MetricCollectorReport from clusters %v", clustersWaitingForReport)
+		return evaluation, nil
+	}
+	if len(clustersWithOutdatedReport) > 0 {
+		evaluation.BlockedReason = progressingReasonWaitingForReports
+		evaluation.BlockedMessage = fmt.Sprintf("Waiting for the MetricCollectorReports of clusters %v to be successfully collected for their current spec", clustersWithOutdatedReport)
+		return evaluation, nil
+	}
 
-		// Get MetricCollectorReport for this cluster
-		report := &autoapprovev1alpha1.MetricCollectorReport{}
-		err := r.Client.Get(ctx, types.NamespacedName{
-			Name:      metricCollectorName,
-			Namespace: reportNamespace,
-		}, report)
+	// Unhealthy workloads are expected right after the stage starts updating, so only log them at a higher
+	// verbosity while within the initial grace period; they are still evaluated so approval is not delayed
+	if initialGracePeriod != nil {
+		evaluation.initialGracePeriod = initialGracePeriod.Duration
+		evaluation.InGracePeriod = stageStartTime != nil && time.Since(stageStartTime.Time) < initialGracePeriod.Duration
+	}
+	unhealthyLogLevel := klog.Level(2)
+	if evaluation.InGracePeriod {
+		unhealthyLogLevel = 4
+	}
 
-		if err != nil {
-			if errors.IsNotFound(err) {
-				klog.V(2).InfoS("MetricCollectorReport not found yet", "approvalRequest", approvalReqRef, "cluster", clusterName, "report", metricCollectorName, "namespace", reportNamespace)
-				allHealthy = false
-				unhealthyDetails = append(unhealthyDetails, fmt.Sprintf("cluster %s: report not found", clusterName))
-				continue
-			}
-			klog.ErrorS(err, "Failed to get MetricCollectorReport", "approvalRequest", approvalReqRef, "cluster", clusterName, "report", metricCollectorName, "namespace", reportNamespace)
-			return fmt.Errorf("failed to get MetricCollectorReport for cluster %s: %w", clusterName, err)
+	// Check each cluster for the tracked workloads; optional workloads are reported but never block approval,
+	// and clusters that are still updating block approval without being evaluated
+	evaluation.AllHealthy = len(evaluation.UpdatingClusters) == 0
+	for i := range evaluation.Clusters {
+		clusterEvaluation := &evaluation.Clusters[i]
+		clusterName := clusterEvaluation.Cluster
+		clusterEvaluation.Weight = clusterWeight(clusterWeights, clusterName)
+		evaluation.TotalClusterWeight += clusterEvaluation.Weight
+		if clusterEvaluation.Updating {
+			continue
 		}
+		reportNamespace := r.memberNamespace(clusterName)
+		_, clusterSpan := tracing.OrNoop(r.Tracer).Start(ctx, "ApprovalRequest.evaluateClusterHealth", trace.WithAttributes(
+			attribute.String("cluster", clusterName),
+			attribute.String("report", clusterEvaluation.Report),
+		))
+
+		klog.V(2).InfoS("Checking MetricCollectorReport", "approvalRequest", approvalReqRef, "cluster", clusterName, "reportName", metricCollectorName, "reportNamespace", reportNamespace)
+
+		// Every cluster has a report at this point
+		report := reportsByNamespace[reportNamespace]
 
 		klog.V(2).InfoS("Found MetricCollectorReport", "approvalRequest", approvalReqRef, "cluster", clusterName, "collectedMetrics", len(report.Status.CollectedMetrics), "workloadsMonitored", report.Status.WorkloadsMonitored)
 
 		// Check if all workloads from WorkloadTracker are present and healthy
 		for _, trackedWorkload := range workloads {
 			// Aggregate metrics for all pods of this workload
-			healthyPodCount, totalPodCount := countHealthyPodsForWorkload(report.Status.CollectedMetrics, trackedWorkload)
-			expectedHealthyReplicas := trackedWorkload.HealthyReplicas
-
-			if totalPodCount == 0 {
-				klog.V(2).InfoS("Workload not found in MetricCollectorReport", "approvalRequest", approvalReqRef, "cluster", clusterName, "workload", trackedWorkload.Name, "namespace", trackedWorkload.Namespace)
-				allHealthy = false
-				unhealthyDetails = append(unhealthyDetails,
-					fmt.Sprintf("cluster %s: workload %s/%s not found", clusterName, trackedWorkload.Namespace, trackedWorkload.Name))
-				continue
+			healthyPodCount, totalPodCount := utils.CountHealthyPodsInReport(report, trackedWorkload)
+			expectedHealthyReplicas := expectedHealthyReplicasForWorkload(report.Status.DesiredReplicas, trackedWorkload)
+			decision := workloadHealthDecision{
+				Namespace:       trackedWorkload.Namespace,
+				Name:            trackedWorkload.Name,
+				Kind:            trackedWorkload.Kind,
+				Optional:        trackedWorkload.Optional,
+				HealthyPods:     healthyPodCount,
+				TotalPods:       totalPodCount,
+				ExpectedHealthy: expectedHealthyReplicas,
 			}
 
-			// Check if we have enough healthy replicas
-			if healthyPodCount < expectedHealthyReplicas {
-				klog.V(2).InfoS("Workload does not have enough healthy replicas",
+			var detail string
+			switch {
+			case totalPodCount == 0 && trackedWorkload.AllowMissingAfter != nil && stageStartTime != nil &&
+				time.Since(stageStartTime.Time) >= trackedWorkload.AllowMissingAfter.Duration:
+				klog.InfoS("Workload has no metrics but AllowMissingAfter has elapsed since the stage started, considering it satisfied",
+					"approvalRequest", approvalReqRef,
+					"cluster", clusterName,
+					"workload", trackedWorkload.Name,
+					"namespace", trackedWorkload.Namespace,
+					"kind", trackedWorkload.Kind,
+					"allowMissingAfter", trackedWorkload.AllowMissingAfter.Duration,
+					"stageStartTime", stageStartTime.Time)
+				decision.State = workloadStateMissingAllowed
+			case totalPodCount == 0:
+				klog.V(unhealthyLogLevel).InfoS("Workload not found in MetricCollectorReport", "approvalRequest", approvalReqRef, "cluster", clusterName, "workload", trackedWorkload.Name, "namespace", trackedWorkload.Namespace, "optional", trackedWorkload.Optional)
+				decision.State = workloadStateMissing
+				detail = fmt.Sprintf("cluster %s: workload %s/%s not found", clusterName, trackedWorkload.Namespace, trackedWorkload.Name)
+			case utils.HasValueThresholds(trackedWorkload):
+				// Judged by the value of its health metric rather than by the number of its healthy pods
+				value, _, err := utils.LowestValueInReport(report, trackedWorkload)
+				if err != nil {
+					klog.V(unhealthyLogLevel).InfoS("Workload has a health value that is not a number",
+						"approvalRequest", approvalReqRef,
+						"cluster", clusterName,
+						"workload", trackedWorkload.Name,
+						"namespace", trackedWorkload.Namespace,
+						"kind", trackedWorkload.Kind,
+						"error", err,
+						"optional", trackedWorkload.Optional)
+					decision.State = workloadStateParseError
+					detail = fmt.Sprintf("cluster %s: workload %s/%s: %v", clusterName, trackedWorkload.Namespace, trackedWorkload.Name, err)
+					break
+				}
+				decision.Value = strconv.FormatFloat(value, 'g', -1, 64)
+				switch utils.ValueTier(value, trackedWorkload) {
+				case utils.ValueTierCritical:
+					klog.V(unhealthyLogLevel).InfoS("Workload health value is below its critical threshold",
+						"approvalRequest", approvalReqRef,
+						"cluster", clusterName,
+						"workload", trackedWorkload.Name,
+						"namespace", trackedWorkload.Namespace,
+						"kind", trackedWorkload.Kind,
+						"value", decision.Value,
+						"criticalThreshold", trackedWorkload.CriticalThreshold,
+						"optional", trackedWorkload.Optional)
+					decision.State = workloadStateCritical
+					if math.IsNaN(value) {
+						detail = fmt.Sprintf("cluster %s: workload %s/%s has a pod with a NaN health value", clusterName, trackedWorkload.Namespace, trackedWorkload.Name)
+					} else {
+						detail = fmt.Sprintf("cluster %s: workload %s/%s has a lowest health value of %s, below its critical threshold %s",
+							clusterName, trackedWorkload.Namespace, trackedWorkload.Name, decision.Value, trackedWorkload.CriticalThreshold.String())
+					}
+				case utils.ValueTierWarn:
+					klog.V(2).InfoS("Workload health value is below its warn threshold, not blocking approval",
+						"approvalRequest", approvalReqRef,
+						"cluster", clusterName,
+						"workload", trackedWorkload.Name,
+						"namespace", trackedWorkload.Namespace,
+						"kind", trackedWorkload.Kind,
+						"value", decision.Value,
+						"warnThreshold", trackedWorkload.WarnThreshold)
+					decision.State = workloadStateWarning
+					evaluation.WarningDetails = append(evaluation.WarningDetails, fmt.Sprintf("cluster %s: workload %s/%s has a lowest health value of %s, below its warn threshold %s",
+						clusterName, trackedWorkload.Namespace, trackedWorkload.Name, decision.Value, trackedWorkload.WarnThreshold.String()))
+				default:
+					klog.V(2).InfoS("Workload health value is within its thresholds",
+						"approvalRequest", approvalReqRef,
+						"cluster", clusterName,
+						"workload", trackedWorkload.Name,
+						"namespace", trackedWorkload.Namespace,
+						"kind", trackedWorkload.Kind,
+						"value", decision.Value)
+					decision.State = workloadStateHealthy
+				}
+			case healthyPodCount < expectedHealthyReplicas:
+				// Not enough healthy replicas
+				klog.V(unhealthyLogLevel).InfoS("Workload does not have enough healthy replicas",
 					"approvalRequest", approvalReqRef,
 					"cluster", clusterName,
 					"workload", trackedWorkload.Name,
@@ -400,13 +1488,43 @@ func (r *Reconciler) checkWorkloadHealthAndApprove(
 					"kind", trackedWorkload.Kind,
 					"healthyPods", healthyPodCount,
 					"totalPods", totalPodCount,
-					"expectedHealthy", expectedHealthyReplicas)
-				allHealthy = false
-				unhealthyDetails = append(unhealthyDetails,
-					fmt.Sprintf("cluster %s: workload %s/%s has %d/%d healthy pods, expected %d",
-						clusterName, trackedWorkload.Namespace, trackedWorkload.Name,
-						healthyPodCount, totalPodCount, expectedHealthyReplicas))
-			} else {
+					"expectedHealthy", expectedHealthyReplicas,
+					"optional", trackedWorkload.Optional)
+				decision.State = workloadStateUnhealthy
+				detail = fmt.Sprintf("cluster %s: workload %s/%s has %d/%d healthy pods, expected %d",
+					clusterName, trackedWorkload.Namespace, trackedWorkload.Name,
+					healthyPodCount, totalPodCount, expectedHealthyReplicas)
+				// Tell a health metric that is not a number apart from one that reports unhealthy
+				unhealthyReasons := unhealthyReasonsForWorkload(report.Status.CollectedMetrics, trackedWorkload)
+				for _, reason := range []string{autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNaN, autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInfinite} {
+					if count := unhealthyReasons[reason]; count > 0 {
+						detail += fmt.Sprintf(" (%d pods reported a %s health value)", count, reason)
+					}
+				}
+				if count := unhealthyReasons[autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInsufficientUptime]; count > 0 {
+					detail += fmt.Sprintf(" (%d healthy pods have not been up for the minimum pod uptime)", count)
+				}
+			case healthyPodCount < totalPodCount:
+				klog.V(2).InfoS("Workload has sufficient healthy replicas but some unhealthy pods, considering it degraded",
+					"approvalRequest", approvalReqRef,
+					"cluster", clusterName,
+					"workload", trackedWorkload.Name,
+					"namespace", trackedWorkload.Namespace,
+					"kind", trackedWorkload.Kind,
+					"healthyPods", healthyPodCount,
+					"totalPods", totalPodCount,
+					"expectedHealthy", expectedHealthyReplicas,
+					"blockDegraded", r.BlockDegradedWorkloads)
+				decision.State = workloadStateDegraded
+				degradedDetail := fmt.Sprintf("cluster %s: workload %s/%s is degraded with %d/%d healthy pods, expected %d",
+					clusterName, trackedWorkload.Namespace, trackedWorkload.Name,
+					healthyPodCount, totalPodCount, expectedHealthyReplicas)
+				if r.BlockDegradedWorkloads {
+					detail = degradedDetail
+				} else {
+					evaluation.DegradedDetails = append(evaluation.DegradedDetails, degradedDetail)
+				}
+			default:
 				klog.V(2).InfoS("Workload has sufficient healthy replicas",
 					"approvalRequest", approvalReqRef,
 					"cluster", clusterName,
@@ -416,46 +1534,473 @@ func (r *Reconciler) checkWorkloadHealthAndApprove(
 					"healthyPods", healthyPodCount,
 					"totalPods", totalPodCount,
 					"expectedHealthy", expectedHealthyReplicas)
+				decision.State = workloadStateHealthy
 			}
+			decision.Detail = detail
+			clusterEvaluation.Workloads = append(clusterEvaluation.Workloads, decision)
+			if !trackedWorkload.Optional {
+				evaluation.HealthyPods += healthyPodCount
+				evaluation.TotalPods += totalPodCount
+			}
+
+			if detail == "" {
+				continue
+			}
+			if trackedWorkload.Optional {
+				evaluation.OptionalUnhealthyDetails = append(evaluation.OptionalUnhealthyDetails, detail)
+				continue
+			}
+			evaluation.AllHealthy = false
+			evaluation.UnhealthyDetails = append(evaluation.UnhealthyDetails, detail)
+		}
+		blockingWorkloads := len(blockingWorkloadsForCluster(*clusterEvaluation, r.BlockDegradedWorkloads))
+		clusterEvaluation.Healthy = blockingWorkloads == 0
+		if clusterEvaluation.Healthy {
+			evaluation.HealthyClusterWeight += clusterEvaluation.Weight
+		}
+		clusterSpan.SetAttributes(attribute.Int("blocking_workloads", blockingWorkloads))
+		clusterSpan.End()
+	}
+
+	if !evaluation.AllHealthy {
+		klog.V(unhealthyLogLevel).InfoS("Not all workloads are healthy yet", "approvalRequest", approvalReqRef, "unhealthyDetails", evaluation.UnhealthyDetails, "optionalUnhealthyDetails", evaluation.OptionalUnhealthyDetails, "inGracePeriod", evaluation.InGracePeriod)
+	}
+	return evaluation, nil
+}
+
+// checkWorkloadHealthAndApprove checks if all workloads specified in ClusterStagedWorkloadTracker or StagedWorkloadTracker are healthy
+// across all clusters in the stage, and approves the ApprovalRequest if they are, if the healthy clusters carry the
+// WorkloadTracker's MinHealthyClusterWeightPercent of the cluster weight, or if its MinHealthyPodPercent of the pods
+// across the stage are healthy.
+// Otherwise, it records in the Progressing condition why the ApprovalRequest is not approved yet.
+func (r *Reconciler) checkWorkloadHealthAndApprove(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	clusterNames []string,
+	updatingClusters map[string]bool,
+	updateRunName, stageName string,
+	stageStartTime *metav1.Time,
+) (err error) {
+	approvalReqRef := klog.KObj(approvalReqObj)
+	ctx, span := tracing.OrNoop(r.Tracer).Start(ctx, "ApprovalRequest.checkWorkloadHealthAndApprove", trace.WithAttributes(
+		attribute.String("updaterun", updateRunName),
+		attribute.String("stage", stageName),
+		attribute.Int("clusters", len(clusterNames)),
+	))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	if r.HealthCheckCacheTTL > 0 {
+		cacheKey := types.NamespacedName{Namespace: approvalReqObj.GetNamespace(), Name: approvalReqObj.GetName()}
+		fingerprint, err := r.healthCheckFingerprint(ctx, approvalReqObj, clusterNames, updatingClusters, updateRunName, stageName, stageStartTime)
+		if err != nil {
+			klog.ErrorS(err, "Failed to fingerprint the workload health check", "approvalRequest", approvalReqRef)
+			return err
 		}
+		if r.healthChecks.cached(cacheKey, fingerprint, r.HealthCheckCacheTTL) {
+			klog.V(2).InfoS("Nothing changed since the last workload health check, keeping its decision", "approvalRequest", approvalReqRef)
+			span.SetAttributes(attribute.Bool("cached", true))
+			return nil
+		}
+		defer func() {
+			// Only a check that did not approve is worth caching; an approved request is not checked again
+			if err == nil && !meta.IsStatusConditionTrue(approvalReqObj.GetApprovalRequestStatus().Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) {
+				r.healthChecks.store(cacheKey, fingerprint)
+			} else {
+				r.healthChecks.forget(cacheKey)
+			}
+		}()
 	}
 
-	// If all workloads are healthy across all clusters, approve the ApprovalRequest
-	if allHealthy {
-		klog.InfoS("All workloads meet healthy replica requirements, approving ApprovalRequest", "approvalRequest", approvalReqRef, "clusters", clusterNames, "workloads", len(workloads))
+	evaluation, err := r.evaluateWorkloadHealth(ctx, approvalReqObj, clusterNames, updatingClusters, updateRunName, stageName, stageStartTime)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.Bool("all_healthy", evaluation.AllHealthy), attribute.String("blocked_reason", evaluation.BlockedReason),
+		attribute.Int("healthy_cluster_weight", int(evaluation.HealthyClusterWeight)), attribute.Int("total_cluster_weight", int(evaluation.TotalClusterWeight)),
+		attribute.Int("healthy_pods", int(evaluation.HealthyPods)), attribute.Int("total_pods", int(evaluation.TotalPods)))
+	if evaluation.BlockedReason != "" {
+		return r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionFalse, evaluation.BlockedReason, evaluation.BlockedMessage)
+	}
 
-		status := approvalReqObj.GetApprovalRequestStatus()
-		// we have already checked that the condition is not present or not true.
-		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
-			Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
-			Status:             metav1.ConditionTrue,
-			ObservedGeneration: approvalReqObj.GetGeneration(),
-			Reason:             "AllWorkloadsHealthy",
-			Message:            fmt.Sprintf("All %d workloads have sufficient healthy replicas across %d clusters", len(workloads), len(clusterNames)),
+	optionalStatus := ""
+	if len(evaluation.OptionalUnhealthyDetails) > 0 {
+		optionalStatus = fmt.Sprintf("; optional workloads not healthy: %s", strings.Join(evaluation.OptionalUnhealthyDetails, ", "))
+	}
+	excludedStatus := ""
+	if len(evaluation.ExcludedClusters) > 0 {
+		excludedStatus = fmt.Sprintf("; excluded clusters in maintenance: %s", strings.Join(evaluation.ExcludedClusters, ", "))
+	}
+	// The clusters of the stage not in maintenance; evaluation.Clusters is empty if the tracker lists no workloads
+	clusterCount := len(clusterNames) - len(evaluation.ExcludedClusters)
+
+	// Every approval path renders its reason and message through the configured templates; the path only
+	// decides the default reason and message
+	templateData := approvalTemplateData{
+		ApprovalRequest:   approvalReqObj.GetName(),
+		Namespace:         approvalReqObj.GetNamespace(),
+		UpdateRun:         updateRunName,
+		Stage:             stageName,
+		Clusters:          clusterCount,
+		RequiredWorkloads: evaluation.RequiredWorkloads,
+		OptionalStatus:    optionalStatus,
+		ExcludedStatus:    excludedStatus,
+		Timestamp:         time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if evaluation.NoWorkloads {
+		return r.handleNoWorkloads(ctx, approvalReqObj, evaluation, templateData)
+	}
+
+	// Persist the blocking workloads of each cluster onto its report so that they can be rendered by UIs
+	if err := r.updateBlockingWorkloads(ctx, evaluation); err != nil {
+		klog.ErrorS(err, "Failed to update blocking workloads", "approvalRequest", approvalReqRef)
+		return err
+	}
+
+	// If all required workloads are healthy across all clusters, approve the ApprovalRequest
+	if evaluation.AllHealthy {
+		klog.InfoS("All required workloads meet healthy replica requirements, approving ApprovalRequest", "approvalRequest", approvalReqRef, "clusters", clusterNames, "requiredWorkloads", evaluation.RequiredWorkloads, "optionalUnhealthyDetails", evaluation.OptionalUnhealthyDetails)
+		// we have already checked that the condition is not present.
+		return r.approve(ctx, approvalReqObj, evaluation, templateData, approvalReasonAllWorkloadsHealthy,
+			fmt.Sprintf("All %d required workloads have sufficient healthy replicas across %d clusters%s%s",
+				evaluation.RequiredWorkloads, clusterCount, optionalStatus, excludedStatus))
+	}
+
+	// Otherwise, approve if the healthy clusters carry enough of the total cluster weight
+	if evaluation.healthyClusterWeightMet() {
+		klog.InfoS("Healthy clusters carry enough of the cluster weight, approving ApprovalRequest",
+			"approvalRequest", approvalReqRef,
+			"healthyClusterWeight", evaluation.HealthyClusterWeight,
+			"totalClusterWeight", evaluation.TotalClusterWeight,
+			"minHealthyClusterWeightPercent", *evaluation.MinHealthyClusterWeightPercent,
+			"unhealthyDetails", evaluation.UnhealthyDetails)
+		return r.approve(ctx, approvalReqObj, evaluation, templateData, approvalReasonHealthyClusterWeightMet,
+			fmt.Sprintf("Healthy clusters carry %d of the total cluster weight %d, at least %d%% required; not healthy: %s%s%s",
+				evaluation.HealthyClusterWeight, evaluation.TotalClusterWeight, *evaluation.MinHealthyClusterWeightPercent,
+				strings.Join(evaluation.UnhealthyDetails, ", "), optionalStatus, excludedStatus))
+	}
+
+	// Otherwise, approve if enough of the pods across the stage are healthy
+	if evaluation.healthyPodPercentMet() {
+		klog.InfoS("Enough of the pods across the stage are healthy, approving ApprovalRequest",
+			"approvalRequest", approvalReqRef,
+			"healthyPods", evaluation.HealthyPods,
+			"totalPods", evaluation.TotalPods,
+			"minHealthyPodPercent", *evaluation.MinHealthyPodPercent,
+			"unhealthyDetails", evaluation.UnhealthyDetails)
+		return r.approve(ctx, approvalReqObj, evaluation, templateData, approvalReasonHealthyPodPercentMet,
+			fmt.Sprintf("%d/%d pods of the required workloads are healthy across %d clusters, at least %d%% required; not healthy: %s%s%s",
+				evaluation.HealthyPods, evaluation.TotalPods, clusterCount, *evaluation.MinHealthyPodPercent,
+				strings.Join(evaluation.UnhealthyDetails, ", "), optionalStatus, excludedStatus))
+	}
+
+	// Not all workloads are healthy yet, return nil (reconcile will requeue)
+	if evaluation.MinHealthyClusterWeightPercent != nil && evaluation.TotalClusterWeight == 0 {
+		return r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionTrue, progressingReasonNoEffectiveClusters,
+			fmt.Sprintf("All %d clusters have a weight of 0, so MinHealthyClusterWeightPercent cannot be met; waiting for %d required workloads to become healthy across all clusters%s",
+				clusterCount, evaluation.RequiredWorkloads, optionalStatus))
+	}
+	if len(evaluation.UpdatingClusters) > 0 && len(evaluation.UnhealthyDetails) == 0 {
+		return r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionTrue, progressingReasonClustersUpdating,
+			fmt.Sprintf("Waiting for clusters %v to finish updating before checking their workload health%s", evaluation.UpdatingClusters, optionalStatus))
+	}
+	if evaluation.InGracePeriod {
+		return r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionTrue, progressingReasonInitialGracePeriod,
+			fmt.Sprintf("Stage started updating at %s, within the initial grace period of %s; waiting for workloads to become healthy",
+				evaluation.stageStartTime.UTC().Format(time.RFC3339), evaluation.initialGracePeriod))
+	}
+
+	return r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionTrue, progressingReasonCheckingWorkloadHealth,
+		fmt.Sprintf("Waiting for %d required workloads to become healthy across %d clusters%s%s", evaluation.RequiredWorkloads, clusterCount, optionalStatus, excludedStatus))
+}
+
+// approve approves the ApprovalRequest with the default reason and message of its approval path, rendered through the
+// configured templates. It records the approval on the evaluated MetricCollectorReports first, then sets the Approved
+// condition, and emits the Approved event along with the StageApproved event on the UpdateRun and the warnings about
+// the workloads it was approved with.
+func (r *Reconciler) approve(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	evaluation *workloadHealthEvaluation,
+	templateData approvalTemplateData,
+	reason, message string,
+) error {
+	approvalReqRef := klog.KObj(approvalReqObj)
+	if err := r.annotateApprovingReports(ctx, approvalReqObj, evaluation, templateData.Timestamp); err != nil {
+		klog.ErrorS(err, "Failed to record the approval on the MetricCollectorReports", "approvalRequest", approvalReqRef)
+		return err
+	}
+
+	templateData.DefaultReason = reason
+	templateData.DefaultMessage = message
+	reason, message = r.renderApproval(templateData)
+	if err := r.setApprovedCondition(ctx, approvalReqObj, metav1.ConditionTrue, reason, message); err != nil {
+		klog.ErrorS(err, "Failed to approve ApprovalRequest", "approvalRequest", approvalReqRef)
+		return fmt.Errorf("failed to approve ApprovalRequest: %w", err)
+	}
+
+	klog.InfoS("Successfully approved ApprovalRequest", "approvalRequest", approvalReqRef)
+	r.recorder.Event(approvalReqObj, "Normal", "Approved", fmt.Sprintf("%s in stage %s", message, templateData.Stage))
+	r.recordUpdateRunApproved(ctx, approvalReqObj, templateData.UpdateRun, templateData.Stage, templateData.Clusters)
+	r.warnDegradedWorkloads(approvalReqObj, evaluation)
+	return nil
+}
+
+// handleNoWorkloads acts on the ApprovalRequest of a stage for which the WorkloadTracker lists no workloads: it
+// approves it if EmptyTrackerBehavior is approve, and otherwise records in the Progressing condition that it is
+// left for a manual decision. The approval is rendered from templateData like any other.
+func (r *Reconciler) handleNoWorkloads(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	evaluation *workloadHealthEvaluation,
+	templateData approvalTemplateData,
+) error {
+	approvalReqRef := klog.KObj(approvalReqObj)
+	if r.EmptyTrackerBehavior != EmptyTrackerBehaviorApprove {
+		return r.setProgressingCondition(ctx, approvalReqObj, metav1.ConditionTrue, progressingReasonNoWorkloadsTracked,
+			fmt.Sprintf("WorkloadTracker %s lists no workloads for stage %s; approve or reject the ApprovalRequest manually", evaluation.WorkloadTracker, evaluation.Stage))
+	}
+
+	klog.InfoS("WorkloadTracker lists no workloads for the stage, approving ApprovalRequest", "approvalRequest", approvalReqRef, "workloadTracker", evaluation.WorkloadTracker, "stage", evaluation.Stage)
+	templateData.DefaultReason = approvalReasonNoWorkloadsTracked
+	templateData.DefaultMessage = fmt.Sprintf("WorkloadTracker %s lists no workloads for stage %s", evaluation.WorkloadTracker, evaluation.Stage)
+	reason, message := r.renderApproval(templateData)
+	if err := r.setApprovedCondition(ctx, approvalReqObj, metav1.ConditionTrue, reason, message); err != nil {
+		klog.ErrorS(err, "Failed to approve ApprovalRequest", "approvalRequest", approvalReqRef)
+		return fmt.Errorf("failed to approve ApprovalRequest: %w", err)
+	}
+
+	klog.InfoS("Successfully approved ApprovalRequest", "approvalRequest", approvalReqRef)
+	r.recorder.Event(approvalReqObj, "Normal", "Approved", message)
+	r.recordUpdateRunApproved(ctx, approvalReqObj, evaluation.UpdateRun, evaluation.Stage, templateData.Clusters)
+	return nil
+}
+
+// recordUpdateRunApproved records a StageApproved event on the UpdateRun targeted by the ApprovalRequest if
+// RecordUpdateRunEvents is set. The event is best-effort: failing to get the UpdateRun, e.g. because it was
+// deleted in the meantime, is logged and does not fail the approval.
+func (r *Reconciler) recordUpdateRunApproved(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj, updateRunName, stageName string, clusters int) {
+	if !r.RecordUpdateRunEvents {
+		return
+	}
+	var updateRun client.Object = &placementv1beta1.ClusterStagedUpdateRun{}
+	if approvalReqObj.GetNamespace() != "" {
+		updateRun = &placementv1beta1.StagedUpdateRun{}
+	}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: updateRunName, Namespace: approvalReqObj.GetNamespace()}, updateRun); err != nil {
+		if errors.IsNotFound(err) {
+			klog.V(2).InfoS("UpdateRun not found, not recording the approval on it", "approvalRequest", klog.KObj(approvalReqObj), "updateRun", updateRunName)
+			return
+		}
+		klog.ErrorS(err, "Failed to get UpdateRun to record the approval on", "approvalRequest", klog.KObj(approvalReqObj), "updateRun", updateRunName)
+		return
+	}
+	r.recorder.Event(updateRun, "Normal", "StageApproved", fmt.Sprintf("Stage %s approved by %s after checking workload health across %d clusters",
+		stageName, klog.KObj(approvalReqObj), clusters))
+}
+
+// warnDegradedWorkloads emits warning events listing the degraded workloads, and the workloads below their
+// WarnThreshold, that an ApprovalRequest was approved with.
+func (r *Reconciler) warnDegradedWorkloads(approvalReqObj placementv1beta1.ApprovalRequestObj, evaluation *workloadHealthEvaluation) {
+	if len(evaluation.WarningDetails) > 0 {
+		r.recorder.Event(approvalReqObj, "Warning", "WorkloadsBelowWarnThreshold",
+			fmt.Sprintf("Approved with workloads below their warn threshold: %s", strings.Join(evaluation.WarningDetails, ", ")))
+	}
+	if len(evaluation.DegradedDetails) == 0 {
+		return
+	}
+	r.recorder.Event(approvalReqObj, "Warning", "DegradedWorkloads",
+		fmt.Sprintf("Approved with degraded workloads: %s", strings.Join(evaluation.DegradedDetails, ", ")))
+}
+
+// blockingWorkloadsForCluster returns the required workloads of a cluster evaluation that block approval.
+// Degraded workloads only block approval if blockDegraded is set.
+func blockingWorkloadsForCluster(clusterEvaluation clusterHealthEvaluation, blockDegraded bool) []autoapprovev1alpha1.BlockingWorkload {
+	var blocking []autoapprovev1alpha1.BlockingWorkload
+	for _, decision := range clusterEvaluation.Workloads {
+		blocks := decision.State == workloadStateMissing || decision.State == workloadStateUnhealthy || decision.State == workloadStateCritical ||
+			decision.State == workloadStateParseError || (blockDegraded && decision.State == workloadStateDegraded)
+		if decision.Optional || !blocks {
+			continue
+		}
+		blocking = append(blocking, autoapprovev1alpha1.BlockingWorkload{
+			Cluster:      clusterEvaluation.Cluster,
+			Namespace:    decision.Namespace,
+			WorkloadName: decision.Name,
+			WorkloadKind: decision.Kind,
+			Reason:       decision.State,
+			Message:      decision.Detail,
 		})
+	}
+	return blocking
+}
+
+// updateBlockingWorkloads records the blocking workloads of each cluster in the status of its MetricCollectorReport.
+// The status is merge-patched so that the fields written by the metric collector are left untouched, and only
+// when the blocking workloads changed. Within the initial grace period, unhealthy workloads are expected and none
+// are recorded.
+func (r *Reconciler) updateBlockingWorkloads(ctx context.Context, evaluation *workloadHealthEvaluation) error {
+	for _, clusterEvaluation := range evaluation.Clusters {
+		report := clusterEvaluation.report
+		if report == nil {
+			continue
+		}
+		var blocking []autoapprovev1alpha1.BlockingWorkload
+		if !evaluation.InGracePeriod {
+			blocking = blockingWorkloadsForCluster(clusterEvaluation, r.BlockDegradedWorkloads)
+		}
+		if equality.Semantic.DeepEqual(report.Status.BlockingWorkloads, blocking) {
+			continue
+		}
+		patched := report.DeepCopy()
+		patched.Status.BlockingWorkloads = blocking
+		if err := r.writeReport(ctx, func() error { return r.Client.Status().Patch(ctx, patched, client.MergeFrom(report)) }); err != nil {
+			return fmt.Errorf("failed to update blocking workloads of MetricCollectorReport %s/%s: %w", report.Namespace, report.Name, err)
+		}
+		klog.V(2).InfoS("Updated blocking workloads", "report", klog.KObj(report), "cluster", clusterEvaluation.Cluster, "blockingWorkloads", len(blocking))
+	}
+	return nil
+}
+
+// annotateApprovingReports records on the MetricCollectorReport of every evaluated cluster which ApprovalRequest
+// is approved based on it and when, as an RFC 3339 time, linking the decision back from the reports for audit. It runs
+// before the approval is written, since decided ApprovalRequests are not reconciled again to retry a failed write.
+func (r *Reconciler) annotateApprovingReports(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	evaluation *workloadHealthEvaluation,
+	approvedAt string,
+) error {
+	approvedBy := klog.KObj(approvalReqObj).String()
+	for _, clusterEvaluation := range evaluation.Clusters {
+		report := clusterEvaluation.report
+		if report == nil || clusterEvaluation.Updating {
+			continue
+		}
+		patched := report.DeepCopy()
+		if patched.Annotations == nil {
+			patched.Annotations = make(map[string]string)
+		}
+		patched.Annotations[approvedByAnnotation] = approvedBy
+		patched.Annotations[approvedAtAnnotation] = approvedAt
+		if err := r.writeReport(ctx, func() error { return r.Client.Patch(ctx, patched, client.MergeFrom(report)) }); err != nil {
+			return fmt.Errorf("failed to record the approval on MetricCollectorReport %s/%s: %w", report.Namespace, report.Name, err)
+		}
+	}
+	return nil
+}
+
+// reportCollectedForCurrentSpec reports whether the status of the report was successfully collected for its current
+// spec: its MetricsCollected condition is True, or has the KubeStatusFallback reason, for the current generation. Right
+// after its spec changes, e.g. because the ApprovalRequest was edited, the report still holds the metrics collected
+// for the previous spec until the metric collector catches up, and a failed or cleared collection holds no metrics
+// to evaluate.
+func reportCollectedForCurrentSpec(report *autoapprovev1alpha1.MetricCollectorReport) bool {
+	cond := meta.FindStatusCondition(report.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+	if cond == nil || cond.ObservedGeneration != report.Generation {
+		return false
+	}
+	return cond.Status == metav1.ConditionTrue || cond.Reason == autoapprovev1alpha1.MetricCollectorReportConditionReasonKubeStatusFallback
+}
 
-		approvalReqObj.SetApprovalRequestStatus(*status)
-		if err := r.Client.Status().Update(ctx, approvalReqObj); err != nil {
-			klog.ErrorS(err, "Failed to approve ApprovalRequest", "approvalRequest", approvalReqRef)
-			return fmt.Errorf("failed to approve ApprovalRequest: %w", err)
+// countRequiredWorkloads returns the number of workloads that are not optional and thus gate approval.
+func countRequiredWorkloads(workloads []autoapprovev1alpha1.WorkloadReference) int {
+	count := 0
+	for _, workload := range workloads {
+		if !workload.Optional {
+			count++
 		}
+	}
+	return count
+}
 
-		klog.InfoS("Successfully approved ApprovalRequest", "approvalRequest", approvalReqRef)
-		r.recorder.Event(approvalReqObj, "Normal", "Approved", fmt.Sprintf("All %d workloads have sufficient healthy replicas across %d clusters in stage %s", len(workloads), len(clusterNames), stageName))
+// setProgressingCondition sets the Progressing condition of the ApprovalRequest, which records with a
+// machine-readable reason whether the health check is blocked on a missing dependency.
+// The status is only written when the condition changes.
+func (r *Reconciler) setProgressingCondition(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	conditionStatus metav1.ConditionStatus,
+	reason, message string,
+) error {
+	status := approvalReqObj.GetApprovalRequestStatus()
+	if !meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               approvalRequestConditionProgressing,
+		Status:             conditionStatus,
+		ObservedGeneration: approvalReqObj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	}) {
+		return nil
+	}
+	approvalReqObj.SetApprovalRequestStatus(*status)
+	if err := r.Client.Status().Update(ctx, approvalReqObj); err != nil {
+		return fmt.Errorf("failed to update Progressing condition: %w", err)
+	}
+	return nil
+}
 
-		// Approval successful or already approved
+// setPausedCondition sets the Paused condition of the ApprovalRequest.
+// The status is only written when the condition changes.
+func (r *Reconciler) setPausedCondition(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	conditionStatus metav1.ConditionStatus,
+	reason, message string,
+) error {
+	status := approvalReqObj.GetApprovalRequestStatus()
+	if !meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               approvalRequestConditionPaused,
+		Status:             conditionStatus,
+		ObservedGeneration: approvalReqObj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	}) {
 		return nil
 	}
+	approvalReqObj.SetApprovalRequestStatus(*status)
+	if err := r.Client.Status().Update(ctx, approvalReqObj); err != nil {
+		return fmt.Errorf("failed to update Paused condition: %w", err)
+	}
+	return nil
+}
 
-	// Not all workloads are healthy yet, log details and return nil (reconcile will requeue)
-	klog.V(2).InfoS("Not all workloads are healthy yet", "approvalRequest", approvalReqRef, "unhealthyDetails", unhealthyDetails)
+// isReconcilePaused returns true if the ApprovalRequest carries the reconcile-paused annotation set to "true".
+func isReconcilePaused(obj client.Object) bool {
+	return obj.GetAnnotations()[reconcilePausedAnnotation] == "true"
+}
 
-	return nil
+// setApprovedCondition sets the Approved condition of the ApprovalRequest and updates its status.
+// The placement API has no separate rejection condition type, so a rejection is expressed as
+// Approved=False with a reason. Once the condition is set to either status, reconciliation stops.
+func (r *Reconciler) setApprovedCondition(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	conditionStatus metav1.ConditionStatus,
+	reason, message string,
+) error {
+	status := approvalReqObj.GetApprovalRequestStatus()
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
+		Status:             conditionStatus,
+		ObservedGeneration: approvalReqObj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	approvalReqObj.SetApprovalRequestStatus(*status)
+	return r.Client.Status().Update(ctx, approvalReqObj)
 }
 
-// handleDelete handles the deletion of an ApprovalRequest or ClusterApprovalRequest
+// handleDelete handles the deletion of an ApprovalRequest or ClusterApprovalRequest.
+// It tolerates ApprovalRequests both with and without the cleanup finalizer: reports are cleaned up if the
+// finalizer is present or finalizers are disabled, and the finalizer is only removed if present.
 func (r *Reconciler) handleDelete(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj) (ctrl.Result, error) {
-	if !controllerutil.ContainsFinalizer(approvalReqObj, metricCollectorFinalizer) {
+	r.healthChecks.forget(types.NamespacedName{Namespace: approvalReqObj.GetNamespace(), Name: approvalReqObj.GetName()})
+	hasFinalizer := controllerutil.ContainsFinalizer(approvalReqObj, metricCollectorFinalizer)
+	if !hasFinalizer && !r.DisableFinalizers {
 		return ctrl.Result{}, nil
 	}
 
@@ -463,17 +2008,67 @@ func (r *Reconciler) handleDelete(ctx context.Context, approvalReqObj placementv
 	klog.V(2).InfoS("Cleaning up MetricCollectorReports for ApprovalRequest", "approvalRequest", approvalReqRef)
 
 	// Build the parent-approval-request label value to match
-	// For cluster-scoped: just the name
-	// For namespace-scoped: namespace.name format (using dot as separator)
-	var parentApprovalRequestValue string
-	if approvalReqObj.GetNamespace() == "" {
-		// Cluster-scoped: ClusterApprovalRequest
-		parentApprovalRequestValue = approvalReqObj.GetName()
-	} else {
-		// Namespace-scoped: ApprovalRequest (use dot instead of slash for valid label)
-		parentApprovalRequestValue = fmt.Sprintf("%s.%s", approvalReqObj.GetNamespace(), approvalReqObj.GetName())
+	parentApprovalRequestValue := parentApprovalRequestLabelValue(approvalReqObj.GetNamespace(), approvalReqObj.GetName())
+	deletedCount, err := r.deleteMetricCollectorReports(ctx, parentApprovalRequestValue)
+	if err != nil {
+		klog.ErrorS(err, "Failed to clean up MetricCollectorReports", "approvalRequest", approvalReqRef, "parentApprovalRequest", parentApprovalRequestValue)
+		return ctrl.Result{}, err
+	}
+
+	if deletedCount > 0 {
+		r.recorder.Event(approvalReqObj, "Normal", "MetricCollectorReportsDeleted",
+			fmt.Sprintf("Deleted the MetricCollectorReports of %d clusters", deletedCount))
+	}
+
+	if hasFinalizer {
+		if err := r.removeFinalizer(ctx, approvalReqObj); err != nil {
+			klog.ErrorS(err, "Failed to remove finalizer", "approvalRequest", approvalReqRef)
+			return ctrl.Result{}, err
+		}
 	}
 
+	klog.V(2).InfoS("Successfully cleaned up MetricCollectorReports", "approvalRequest", approvalReqRef, "deletedCount", deletedCount)
+	return ctrl.Result{}, nil
+}
+
+// ensureFinalizer adds the cleanup finalizer to the ApprovalRequest if it is not present, unless finalizers
+// are disabled.
+func (r *Reconciler) ensureFinalizer(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj) error {
+	if r.DisableFinalizers || controllerutil.ContainsFinalizer(approvalReqObj, metricCollectorFinalizer) {
+		return nil
+	}
+	approvalReqRef := klog.KObj(approvalReqObj)
+	controllerutil.AddFinalizer(approvalReqObj, metricCollectorFinalizer)
+	if err := r.Client.Update(ctx, approvalReqObj); err != nil {
+		klog.ErrorS(err, "Failed to add finalizer", "approvalRequest", approvalReqRef)
+		return err
+	}
+	klog.V(2).InfoS("Added finalizer to ApprovalRequest", "approvalRequest", approvalReqRef)
+	return nil
+}
+
+// removeFinalizer removes the cleanup finalizer from the ApprovalRequest, tolerating reconciles that race on
+// its deletion: an object that is already gone needs no update, and on a conflict the finalizer is removed
+// once more from a freshly read copy, unless another reconcile already removed it.
+func (r *Reconciler) removeFinalizer(ctx context.Context, approvalReqObj placementv1beta1.ApprovalRequestObj) error {
+	controllerutil.RemoveFinalizer(approvalReqObj, metricCollectorFinalizer)
+	err := r.Client.Update(ctx, approvalReqObj)
+	if errors.IsConflict(err) {
+		klog.V(2).InfoS("Conflict removing finalizer, retrying on the latest ApprovalRequest", "approvalRequest", klog.KObj(approvalReqObj))
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(approvalReqObj), approvalReqObj); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !controllerutil.RemoveFinalizer(approvalReqObj, metricCollectorFinalizer) {
+			return nil
+		}
+		err = r.Client.Update(ctx, approvalReqObj)
+	}
+	return client.IgnoreNotFound(err)
+}
+
+// deleteMetricCollectorReports deletes all MetricCollectorReports with the given parent-approval-request
+// label value across all namespaces and returns how many were deleted.
+func (r *Reconciler) deleteMetricCollectorReports(ctx context.Context, parentApprovalRequestValue string) (int, error) {
 	// List all MetricCollectorReports with the parent-approval-request label across all namespaces
 	reportList := &autoapprovev1alpha1.MetricCollectorReportList{}
 	listOptions := []client.ListOption{
@@ -483,47 +2078,154 @@ func (r *Reconciler) handleDelete(ctx context.Context, approvalReqObj placementv
 	}
 
 	if err := r.Client.List(ctx, reportList, listOptions...); err != nil {
-		klog.ErrorS(err, "Failed to list MetricCollectorReports for cleanup", "approvalRequest", approvalReqRef, "parentApprovalRequest", parentApprovalRequestValue)
-		return ctrl.Result{}, fmt.Errorf("failed to list MetricCollectorReports: %w", err)
+		return 0, fmt.Errorf("failed to list MetricCollectorReports: %w", err)
 	}
 
-	klog.V(2).InfoS("Found MetricCollectorReports to delete", "approvalRequest", approvalReqRef, "count", len(reportList.Items))
+	klog.V(2).InfoS("Found MetricCollectorReports to delete", "parentApprovalRequest", parentApprovalRequestValue, "count", len(reportList.Items))
 
 	// Delete all found MetricCollectorReports
 	for i := range reportList.Items {
 		report := &reportList.Items[i]
-		if err := r.Client.Delete(ctx, report); err != nil && !errors.IsNotFound(err) {
+		if err := r.writeReport(ctx, func() error { return r.Client.Delete(ctx, report) }); err != nil && !errors.IsNotFound(err) {
 			klog.ErrorS(err, "Failed to delete MetricCollectorReport", "report", report.Name, "namespace", report.Namespace)
-			return ctrl.Result{}, fmt.Errorf("failed to delete MetricCollectorReport %s/%s: %w", report.Namespace, report.Name, err)
+			return 0, fmt.Errorf("failed to delete MetricCollectorReport %s/%s: %w", report.Namespace, report.Name, err)
 		}
 		klog.V(2).InfoS("Deleted MetricCollectorReport", "report", report.Name, "namespace", report.Namespace)
 	}
+	return len(reportList.Items), nil
+}
 
-	// Remove finalizer
-	controllerutil.RemoveFinalizer(approvalReqObj, metricCollectorFinalizer)
-	if err := r.Client.Update(ctx, approvalReqObj); err != nil {
-		klog.ErrorS(err, "Failed to remove finalizer", "approvalRequest", approvalReqRef)
-		return ctrl.Result{}, err
+// parentApprovalRequestLabelValue returns the parent-approval-request label value that uniquely identifies
+// the ApprovalRequest. For cluster-scoped ApprovalRequests it is just the name; for namespace-scoped ones
+// it is namespace.name (using dot instead of slash for a valid label value).
+func parentApprovalRequestLabelValue(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", namespace, name)
+}
+
+// updateRunStageIndexValue returns the reportUpdateRunStageIndex key for an update run and stage.
+func updateRunStageIndexValue(updateRunName, stageName string) string {
+	return fmt.Sprintf("%s/%s", updateRunName, stageName)
+}
+
+// SetupReportIndexer registers the update-run/stage field index on MetricCollectorReport that the ApprovalRequest
+// and ClusterApprovalRequest controllers list reports with. It must be called once per manager, before either
+// controller is set up, since the controllers share the index through the manager cache.
+func SetupReportIndexer(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), &autoapprovev1alpha1.MetricCollectorReport{}, reportUpdateRunStageIndex, reportUpdateRunStageIndexValues)
+}
+
+// reportUpdateRunStageIndexValues extracts the reportUpdateRunStageIndex key of a MetricCollectorReport from its labels.
+func reportUpdateRunStageIndexValues(obj client.Object) []string {
+	labels := obj.GetLabels()
+	updateRunName, stageName := labels[updateRunLabel], labels[stageLabel]
+	if updateRunName == "" || stageName == "" {
+		return nil
 	}
+	return []string{updateRunStageIndexValue(updateRunName, stageName)}
+}
 
-	klog.V(2).InfoS("Successfully cleaned up MetricCollectorReports", "approvalRequest", approvalReqRef, "deletedCount", len(reportList.Items))
-	return ctrl.Result{}, nil
+// decidedApprovalRequestPredicate filters out update events for ApprovalRequests that are already approved
+// or rejected, so metadata edits that bump the generation do not re-enter reconciliation. Deletions still
+// pass through so that the finalizer can clean up the MetricCollectorReports.
+var decidedApprovalRequestPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		approvalReqObj, ok := e.ObjectNew.(placementv1beta1.ApprovalRequestObj)
+		if !ok {
+			return true
+		}
+		if !approvalReqObj.GetDeletionTimestamp().IsZero() {
+			return true
+		}
+		approvedCond := meta.FindStatusCondition(approvalReqObj.GetApprovalRequestStatus().Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+		return approvedCond == nil || approvedCond.Status == metav1.ConditionUnknown
+	},
+}
+
+// deletionStartedPredicate passes update events that set the deletion timestamp, so that deletions reach
+// handleDelete without relying on the API server bumping the generation when an object is marked for deletion.
+var deletionStartedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld == nil || e.ObjectNew == nil {
+			return false
+		}
+		return e.ObjectOld.GetDeletionTimestamp().IsZero() && !e.ObjectNew.GetDeletionTimestamp().IsZero()
+	},
+}
+
+// reconcilePausedAnnotationChangedPredicate passes update events that change the reconcile-paused annotation.
+// Annotation edits do not bump the generation, so without it pausing and unpausing would only be noticed on
+// the next periodic requeue, or never for a paused ApprovalRequest.
+var reconcilePausedAnnotationChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld == nil || e.ObjectNew == nil {
+			return false
+		}
+		return isReconcilePaused(e.ObjectOld) != isReconcilePaused(e.ObjectNew)
+	},
+}
+
+// reportDeletedPredicate only passes deletions of MetricCollectorReports, so that a report deleted while its
+// ApprovalRequest is pending is recreated right away instead of on the next periodic requeue.
+var reportDeletedPredicate = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// clusterApprovalRequestForReport maps a MetricCollectorReport created for a ClusterApprovalRequest, which references
+// a ClusterStagedWorkloadTracker, to the ClusterApprovalRequest named by its parent-approval-request label.
+func clusterApprovalRequestForReport(_ context.Context, obj client.Object) []reconcile.Request {
+	report, ok := obj.(*autoapprovev1alpha1.MetricCollectorReport)
+	if !ok || report.Spec.WorkloadTrackerRef == nil || report.Spec.WorkloadTrackerRef.Kind != autoapprovev1alpha1.ClusterStagedWorkloadTrackerKind {
+		return nil
+	}
+	name := report.Labels[parentApprovalRequestLabel]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+}
+
+// approvalRequestForReport maps a MetricCollectorReport created for an ApprovalRequest, which references a
+// StagedWorkloadTracker in the namespace of the ApprovalRequest, to the ApprovalRequest named by its
+// parent-approval-request label.
+func approvalRequestForReport(_ context.Context, obj client.Object) []reconcile.Request {
+	report, ok := obj.(*autoapprovev1alpha1.MetricCollectorReport)
+	if !ok || report.Spec.WorkloadTrackerRef == nil || report.Spec.WorkloadTrackerRef.Kind != autoapprovev1alpha1.StagedWorkloadTrackerKind {
+		return nil
+	}
+	namespace := report.Spec.WorkloadTrackerRef.Namespace
+	name, ok := strings.CutPrefix(report.Labels[parentApprovalRequestLabel], namespace+".")
+	if !ok || namespace == "" || name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
 }
 
 // SetupWithManagerForClusterApprovalRequest sets up the controller with the Manager for ClusterApprovalRequest resources.
+// The MetricCollectorReport index must have been registered with SetupReportIndexer.
 func (r *Reconciler) SetupWithManagerForClusterApprovalRequest(mgr ctrl.Manager) error {
 	r.recorder = mgr.GetEventRecorderFor("clusterapprovalrequest-controller")
+	r.healthChecks = newHealthCheckCache()
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("clusterapprovalrequest-controller").
-		For(&placementv1beta1.ClusterApprovalRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&placementv1beta1.ClusterApprovalRequest{}, builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, reconcilePausedAnnotationChangedPredicate, deletionStartedPredicate), decidedApprovalRequestPredicate)).
+		Watches(&autoapprovev1alpha1.MetricCollectorReport{}, handler.EnqueueRequestsFromMapFunc(clusterApprovalRequestForReport), builder.WithPredicates(reportDeletedPredicate)).
 		Complete(r)
 }
 
 // SetupWithManagerForApprovalRequest sets up the controller with the Manager for ApprovalRequest resources.
+// The MetricCollectorReport index must have been registered with SetupReportIndexer.
 func (r *Reconciler) SetupWithManagerForApprovalRequest(mgr ctrl.Manager) error {
 	r.recorder = mgr.GetEventRecorderFor("approvalrequest-controller")
+	r.healthChecks = newHealthCheckCache()
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("approvalrequest-controller").
-		For(&placementv1beta1.ApprovalRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&placementv1beta1.ApprovalRequest{}, builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, reconcilePausedAnnotationChangedPredicate, deletionStartedPredicate), decidedApprovalRequestPredicate)).
+		Watches(&autoapprovev1alpha1.MetricCollectorReport{}, handler.EnqueueRequestsFromMapFunc(approvalRequestForReport), builder.WithPredicates(reportDeletedPredicate)).
 		Complete(r)
 }
@@ -0,0 +1,2150 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+const (
+	testNamespace   = "test-ns"
+	testRequestName = "test-approval"
+	testUpdateRun   = "test-run"
+	testStage       = "canary"
+)
+
+// ignoreConditionTime ignores the LastTransitionTime of conditions, which round-trips through the fake
+// client at second precision.
+var ignoreConditionTime = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
+
+// newTestScheme returns a scheme with the types the controller reads and writes.
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		placementv1beta1.AddToScheme,
+		autoapprovev1alpha1.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+	return scheme
+}
+
+// newTestClientBuilder returns a fake hub client builder holding objs, with the status subresources and
+// field index the controller relies on.
+func newTestClientBuilder(t *testing.T, objs ...client.Object) *fake.ClientBuilder {
+	t.Helper()
+	return fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&placementv1beta1.ApprovalRequest{}, &placementv1beta1.ClusterApprovalRequest{}, &autoapprovev1alpha1.MetricCollectorReport{}).
+		WithIndex(&autoapprovev1alpha1.MetricCollectorReport{}, reportUpdateRunStageIndex, reportUpdateRunStageIndexValues)
+}
+
+// newTestReconciler returns a Reconciler backed by a fake hub client holding objs.
+func newTestReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+	return newTestReconcilerWithClient(newTestClientBuilder(t, objs...).Build())
+}
+
+// newTestReconcilerWithClient returns a Reconciler backed by hubClient.
+func newTestReconcilerWithClient(hubClient client.Client) *Reconciler {
+	return &Reconciler{
+		Client:       hubClient,
+		recorder:     record.NewFakeRecorder(100),
+		healthChecks: newHealthCheckCache(),
+	}
+}
+
+// newTestApprovalRequest returns a namespaced ApprovalRequest for testStage of testUpdateRun with the given conditions.
+func newTestApprovalRequest(conditions ...metav1.Condition) *placementv1beta1.ApprovalRequest {
+	return &placementv1beta1.ApprovalRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testRequestName,
+			Namespace:  testNamespace,
+			Generation: 1,
+		},
+		Spec: placementv1beta1.ApprovalRequestSpec{
+			TargetUpdateRun: testUpdateRun,
+			TargetStage:     testStage,
+		},
+		Status: placementv1beta1.ApprovalRequestStatus{
+			Conditions: conditions,
+		},
+	}
+}
+
+// newTestUpdateRun returns an initialized StagedUpdateRun named testUpdateRun whose testStage holds clusters.
+func newTestUpdateRun(clusters ...string) *placementv1beta1.StagedUpdateRun {
+	stage := placementv1beta1.StageUpdatingStatus{StageName: testStage}
+	for _, cluster := range clusters {
+		stage.Clusters = append(stage.Clusters, placementv1beta1.ClusterUpdatingStatus{ClusterName: cluster})
+	}
+	return &placementv1beta1.StagedUpdateRun{
+		ObjectMeta: metav1.ObjectMeta{Name: testUpdateRun, Namespace: testNamespace},
+		Status: placementv1beta1.UpdateRunStatus{
+			Conditions: []metav1.Condition{{
+				Type:               string(placementv1beta1.StagedUpdateRunConditionInitialized),
+				Status:             metav1.ConditionTrue,
+				Reason:             "Initialized",
+				LastTransitionTime: metav1.Now(),
+			}},
+			StagesStatus: []placementv1beta1.StageUpdatingStatus{stage},
+		},
+	}
+}
+
+// newTestWorkloadTracker returns a StagedWorkloadTracker for testUpdateRun tracking workloads.
+func newTestWorkloadTracker(workloads ...autoapprovev1alpha1.WorkloadReference) *autoapprovev1alpha1.StagedWorkloadTracker {
+	return &autoapprovev1alpha1.StagedWorkloadTracker{
+		ObjectMeta: metav1.ObjectMeta{Name: testUpdateRun, Namespace: testNamespace},
+		Workloads:  workloads,
+	}
+}
+
+// testWorkload is a required workload that needs two healthy replicas.
+var testWorkload = autoapprovev1alpha1.WorkloadReference{Name: "app", Namespace: "app-ns", Kind: "Deployment", HealthyReplicas: 2}
+
+// podMetrics returns the metrics of healthy healthy pods and unhealthy unhealthy pods of workload.
+func podMetrics(workload autoapprovev1alpha1.WorkloadReference, healthy, unhealthy int) []autoapprovev1alpha1.WorkloadMetric {
+	metrics := make([]autoapprovev1alpha1.WorkloadMetric, 0, healthy+unhealthy)
+	for i := 0; i < healthy+unhealthy; i++ {
+		metrics = append(metrics, autoapprovev1alpha1.WorkloadMetric{
+			Namespace:    workload.Namespace,
+			WorkloadName: workload.Name,
+			WorkloadKind: workload.Kind,
+			PodName:      fmt.Sprintf("%s-%d", workload.Name, i),
+			Health:       i < healthy,
+		})
+	}
+	return metrics
+}
+
+// collectReports writes a successful collection with the given metrics to the status of the MetricCollectorReport
+// of every cluster in metricsByCluster, as the metric collector on that cluster would.
+func collectReports(t *testing.T, r *Reconciler, metricsByCluster map[string][]autoapprovev1alpha1.WorkloadMetric) {
+	t.Helper()
+	for cluster, metrics := range metricsByCluster {
+		collectReport(t, r, cluster, func(status *autoapprovev1alpha1.MetricCollectorReportStatus) {
+			status.CollectedMetrics = metrics
+			status.WorkloadsMonitored = int32(len(metrics))
+		})
+	}
+}
+
+// collectReport marks the MetricCollectorReport of the cluster as collected, after setting its collected status
+// with setStatus.
+func collectReport(t *testing.T, r *Reconciler, cluster string, setStatus func(status *autoapprovev1alpha1.MetricCollectorReportStatus)) {
+	t.Helper()
+	report := &autoapprovev1alpha1.MetricCollectorReport{}
+	key := types.NamespacedName{Namespace: r.memberNamespace(cluster), Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+	if err := r.Get(context.Background(), key, report); err != nil {
+		t.Fatalf("failed to get MetricCollectorReport %s: %v", key, err)
+	}
+	now := metav1.Now()
+	report.Status.LastCollectionTime = &now
+	setStatus(&report.Status)
+	meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
+		Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: report.Generation,
+		Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded,
+	})
+	if err := r.Status().Update(context.Background(), report); err != nil {
+		t.Fatalf("failed to update MetricCollectorReport %s: %v", key, err)
+	}
+}
+
+// reconcileCollected reconciles the ApprovalRequest once to create its reports, collects metricsByCluster into
+// them and reconciles it again to evaluate workload health. It returns the stored ApprovalRequest.
+func reconcileCollected(t *testing.T, r *Reconciler, metricsByCluster map[string][]autoapprovev1alpha1.WorkloadMetric) *placementv1beta1.ApprovalRequest {
+	t.Helper()
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	collectReports(t, r, metricsByCluster)
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	got := &placementv1beta1.ApprovalRequest{}
+	if err := r.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("failed to get ApprovalRequest: %v", err)
+	}
+	return got
+}
+
+// progressingReason returns the reason of the Progressing condition of the ApprovalRequest stored under key.
+func progressingReason(t *testing.T, c client.Client, key types.NamespacedName) string {
+	t.Helper()
+	got := &placementv1beta1.ApprovalRequest{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("failed to get ApprovalRequest: %v", err)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, approvalRequestConditionProgressing)
+	if cond == nil {
+		return ""
+	}
+	return cond.Reason
+}
+
+func TestReconcileProgressingReason(t *testing.T) {
+	// reportNotCreated drops the creation of MetricCollectorReports, as if their namespace did not exist yet.
+	reportNotCreated := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*autoapprovev1alpha1.MetricCollectorReport); ok {
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}
+	tests := []struct {
+		name         string
+		objs         []client.Object
+		interceptors *interceptor.Funcs
+		wantErr      bool
+		wantRequeue  bool
+		wantReason   string
+	}{
+		{
+			name:        "update run not found",
+			objs:        []client.Object{newTestApprovalRequest()},
+			wantRequeue: true,
+			wantReason:  progressingReasonUpdateRunNotFound,
+		},
+		{
+			name: "update run of the other scope",
+			objs: []client.Object{
+				newTestApprovalRequest(),
+				&placementv1beta1.ClusterStagedUpdateRun{ObjectMeta: metav1.ObjectMeta{Name: testUpdateRun}},
+			},
+			wantReason: progressingReasonScopeMismatch,
+		},
+		{
+			name: "stage not found",
+			objs: []client.Object{
+				newTestApprovalRequest(),
+				func() client.Object {
+					updateRun := newTestUpdateRun("member-1")
+					updateRun.Status.StagesStatus[0].StageName = "other"
+					return updateRun
+				}(),
+			},
+			wantReason: progressingReasonStageNotFound,
+		},
+		{
+			name:       "workload tracker not found",
+			objs:       []client.Object{newTestApprovalRequest(), newTestUpdateRun("member-1")},
+			wantReason: progressingReasonWorkloadTrackerNotFound,
+		},
+		{
+			name:         "report not created yet",
+			objs:         []client.Object{newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload)},
+			interceptors: &reportNotCreated,
+			wantReason:   progressingReasonReportNotReady,
+		},
+		{
+			name:       "report not collected yet",
+			objs:       []client.Object{newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload)},
+			wantReason: progressingReasonWaitingForReports,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newTestClientBuilder(t, tt.objs...)
+			if tt.interceptors != nil {
+				builder = builder.WithInterceptorFuncs(*tt.interceptors)
+			}
+			r := newTestReconcilerWithClient(builder.Build())
+			key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantRequeue && result.RequeueAfter == 0 {
+				t.Errorf("Reconcile() result = %+v, want a requeue", result)
+			}
+			if got := progressingReason(t, r.Client, key); got != tt.wantReason {
+				t.Errorf("Progressing reason = %q, want %q", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestReconcileConflictingApprovalRequest(t *testing.T) {
+	created := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	newer := newTestApprovalRequest()
+	newer.UID = "newer-uid"
+	newer.CreationTimestamp = created
+	older := newTestApprovalRequest()
+	older.Name = "older-approval"
+	older.UID = "older-uid"
+	older.CreationTimestamp = metav1.NewTime(created.Add(-time.Minute))
+	// The stage has no clusters, so a request without a conflict stops right after the guard with NoEffectiveClusters.
+	updateRun := &placementv1beta1.StagedUpdateRun{
+		ObjectMeta: metav1.ObjectMeta{Name: testUpdateRun, Namespace: testNamespace},
+		Status: placementv1beta1.UpdateRunStatus{
+			StagesStatus: []placementv1beta1.StageUpdatingStatus{{StageName: testStage}},
+		},
+	}
+	r := newTestReconciler(t, newer, older, updateRun)
+	ctx := context.Background()
+	newerKey := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: newerKey})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	if diff := cmp.Diff(ctrl.Result{RequeueAfter: 15 * time.Second}, result); diff != "" {
+		t.Errorf("Reconcile() result mismatch (-want +got):\n%s", diff)
+	}
+	got := &placementv1beta1.ApprovalRequest{}
+	if err := r.Get(ctx, newerKey, got); err != nil {
+		t.Fatalf("failed to get ApprovalRequest: %v", err)
+	}
+	if len(got.Finalizers) != 0 {
+		t.Errorf("Finalizers = %v, want none while the older request exists", got.Finalizers)
+	}
+	wantCond := []metav1.Condition{{
+		Type:               approvalRequestConditionConflicting,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: 1,
+		Reason:             "OlderApprovalRequestExists",
+		Message:            "ApprovalRequest older-approval was created first for update run test-run stage canary",
+	}}
+	if diff := cmp.Diff(wantCond, got.Status.Conditions, ignoreConditionTime); diff != "" {
+		t.Errorf("conditions mismatch (-want +got):\n%s", diff)
+	}
+
+	// The older request wins, so it does not see a conflict itself.
+	olderKey := types.NamespacedName{Namespace: testNamespace, Name: older.Name}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: olderKey}); err != nil {
+		t.Fatalf("Reconcile() of the older request error = %v, want nil", err)
+	}
+	gotOlder := &placementv1beta1.ApprovalRequest{}
+	if err := r.Get(ctx, olderKey, gotOlder); err != nil {
+		t.Fatalf("failed to get ApprovalRequest: %v", err)
+	}
+	if cond := meta.FindStatusCondition(gotOlder.Status.Conditions, approvalRequestConditionConflicting); cond != nil {
+		t.Errorf("older request has condition %+v, want none", cond)
+	}
+
+	// Once the older request is gone, the newer one takes over the stage and drops the condition.
+	if err := r.Delete(ctx, gotOlder); err != nil {
+		t.Fatalf("failed to delete the older ApprovalRequest: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: newerKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	if err := r.Get(ctx, newerKey, got); err != nil {
+		t.Fatalf("failed to get ApprovalRequest: %v", err)
+	}
+	if cond := meta.FindStatusCondition(got.Status.Conditions, approvalRequestConditionConflicting); cond != nil {
+		t.Errorf("newer request has condition %+v, want none once the older request is deleted", cond)
+	}
+}
+
+func TestDecidedApprovalRequestPredicate(t *testing.T) {
+	approved := metav1.Condition{
+		Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+		Status: metav1.ConditionTrue,
+		Reason: approvalReasonAllWorkloadsHealthy,
+	}
+	rejected := metav1.Condition{
+		Type:   string(placementv1beta1.ApprovalRequestConditionApproved),
+		Status: metav1.ConditionFalse,
+		Reason: "Rejected",
+	}
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		deleting   bool
+		want       bool
+	}{
+		{
+			name: "pending",
+			want: true,
+		},
+		{
+			name:       "approved",
+			conditions: []metav1.Condition{approved},
+			want:       false,
+		},
+		{
+			name:       "rejected",
+			conditions: []metav1.Condition{rejected},
+			want:       false,
+		},
+		{
+			name:       "approved and deleting",
+			conditions: []metav1.Condition{approved},
+			deleting:   true,
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldObj := newTestApprovalRequest(tt.conditions...)
+			// A label edit bumps the generation of the ApprovalRequest.
+			newObj := oldObj.DeepCopy()
+			newObj.Generation++
+			newObj.Labels = map[string]string{"team": "payments"}
+			if tt.deleting {
+				now := metav1.Now()
+				newObj.DeletionTimestamp = &now
+			}
+			if got := decidedApprovalRequestPredicate.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}); got != tt.want {
+				t.Errorf("Update() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileStopsOnceCompleted(t *testing.T) {
+	tests := []struct {
+		name   string
+		status metav1.ConditionStatus
+		reason string
+	}{
+		{
+			name:   "approved",
+			status: metav1.ConditionTrue,
+			reason: approvalReasonAllWorkloadsHealthy,
+		},
+		{
+			name:   "rejected",
+			status: metav1.ConditionFalse,
+			reason: "Rejected",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approvedCond := metav1.Condition{
+				Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status:             tt.status,
+				ObservedGeneration: 1,
+				Reason:             tt.reason,
+				LastTransitionTime: metav1.Now(),
+			}
+			r := newTestReconciler(t, newTestApprovalRequest(approvedCond))
+			key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			if diff := cmp.Diff(ctrl.Result{}, result); diff != "" {
+				t.Errorf("Reconcile() result mismatch (-want +got):\n%s", diff)
+			}
+
+			got := &placementv1beta1.ApprovalRequest{}
+			if err := r.Get(context.Background(), key, got); err != nil {
+				t.Fatalf("failed to get ApprovalRequest: %v", err)
+			}
+			if len(got.Finalizers) != 0 {
+				t.Errorf("Finalizers = %v, want none", got.Finalizers)
+			}
+			if diff := cmp.Diff([]metav1.Condition{approvedCond}, got.Status.Conditions, ignoreConditionTime); diff != "" {
+				t.Errorf("conditions mismatch (-want +got):\n%s", diff)
+			}
+			reports := &autoapprovev1alpha1.MetricCollectorReportList{}
+			if err := r.List(context.Background(), reports); err != nil {
+				t.Fatalf("failed to list MetricCollectorReports: %v", err)
+			}
+			if len(reports.Items) != 0 {
+				t.Errorf("created %d MetricCollectorReports, want none", len(reports.Items))
+			}
+		})
+	}
+}
+
+func TestSetApprovedConditionRejects(t *testing.T) {
+	approvalReq := newTestApprovalRequest()
+	r := newTestReconciler(t, approvalReq)
+
+	if err := r.setApprovedCondition(context.Background(), approvalReq, metav1.ConditionFalse, "Rejected", "Workloads are unhealthy"); err != nil {
+		t.Fatalf("setApprovedCondition() error = %v, want nil", err)
+	}
+
+	got := &placementv1beta1.ApprovalRequest{}
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(approvalReq), got); err != nil {
+		t.Fatalf("failed to get ApprovalRequest: %v", err)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+	if cond == nil {
+		t.Fatalf("Approved condition not set")
+	}
+	want := metav1.Condition{
+		Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: 1,
+		Reason:             "Rejected",
+		Message:            "Workloads are unhealthy",
+	}
+	if diff := cmp.Diff(want, *cond, ignoreConditionTime); diff != "" {
+		t.Errorf("Approved condition mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOptionalWorkloads(t *testing.T) {
+	sidecar := autoapprovev1alpha1.WorkloadReference{Name: "sidecar", Namespace: "monitoring", Kind: "DaemonSet", HealthyReplicas: 1, Optional: true}
+	tests := []struct {
+		name         string
+		metrics      []autoapprovev1alpha1.WorkloadMetric
+		wantApproved bool
+		wantMessage  string
+	}{
+		{
+			name:         "unhealthy optional workload does not block approval",
+			metrics:      append(podMetrics(testWorkload, 2, 0), podMetrics(sidecar, 0, 1)...),
+			wantApproved: true,
+			wantMessage:  "All 1 required workloads have sufficient healthy replicas across 1 clusters; optional workloads not healthy: cluster member-1: workload monitoring/sidecar has 0/1 healthy pods, expected 1",
+		},
+		{
+			name:         "missing optional workload does not block approval",
+			metrics:      podMetrics(testWorkload, 2, 0),
+			wantApproved: true,
+			wantMessage:  "All 1 required workloads have sufficient healthy replicas across 1 clusters; optional workloads not healthy: cluster member-1: workload monitoring/sidecar not found",
+		},
+		{
+			name:        "unhealthy required workload blocks approval",
+			metrics:     append(podMetrics(testWorkload, 1, 1), podMetrics(sidecar, 1, 0)...),
+			wantMessage: "Waiting for 1 required workloads to become healthy across 1 clusters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload, sidecar))
+
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": tt.metrics})
+			approvedCond := meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+			if gotApproved := approvedCond != nil && approvedCond.Status == metav1.ConditionTrue; gotApproved != tt.wantApproved {
+				t.Fatalf("approved = %t, want %t", gotApproved, tt.wantApproved)
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, approvalRequestConditionProgressing)
+			if tt.wantApproved {
+				cond = approvedCond
+			}
+			if cond == nil || cond.Message != tt.wantMessage {
+				t.Errorf("condition = %+v, want message %q", cond, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestInitialGracePeriod(t *testing.T) {
+	tests := []struct {
+		name         string
+		stageStarted time.Duration
+		healthy      int
+		wantApproved bool
+		wantReason   string
+		wantBlocking int
+	}{
+		{
+			name:         "unhealthy within the grace period",
+			stageStarted: time.Minute,
+			healthy:      1,
+			wantReason:   progressingReasonInitialGracePeriod,
+		},
+		{
+			name:         "unhealthy after the grace period",
+			stageStarted: time.Hour,
+			healthy:      1,
+			wantReason:   progressingReasonCheckingWorkloadHealth,
+			wantBlocking: 1,
+		},
+		{
+			name:         "healthy within the grace period",
+			stageStarted: time.Minute,
+			healthy:      2,
+			wantApproved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateRun := newTestUpdateRun("member-1")
+			updateRun.Status.StagesStatus[0].StartTime = &metav1.Time{Time: time.Now().Add(-tt.stageStarted)}
+			tracker := newTestWorkloadTracker(testWorkload)
+			tracker.InitialGracePeriod = &metav1.Duration{Duration: 10 * time.Minute}
+			r := newTestReconciler(t, newTestApprovalRequest(), updateRun, tracker)
+
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+				"member-1": podMetrics(testWorkload, tt.healthy, 2-tt.healthy),
+			})
+			approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+			if approved != tt.wantApproved {
+				t.Errorf("approved = %v, want %v", approved, tt.wantApproved)
+			}
+
+			report := &autoapprovev1alpha1.MetricCollectorReport{}
+			key := types.NamespacedName{Namespace: r.memberNamespace("member-1"), Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+			if err := r.Get(context.Background(), key, report); err != nil {
+				t.Fatalf("failed to get MetricCollectorReport %s: %v", key, err)
+			}
+			if len(report.Status.BlockingWorkloads) != tt.wantBlocking {
+				t.Errorf("BlockingWorkloads = %+v, want %d", report.Status.BlockingWorkloads, tt.wantBlocking)
+			}
+
+			if tt.wantReason == "" {
+				return
+			}
+			if reason := progressingReason(t, r.Client, client.ObjectKeyFromObject(got)); reason != tt.wantReason {
+				t.Errorf("Progressing reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestAllowMissingAfter(t *testing.T) {
+	tests := []struct {
+		name         string
+		stageStarted time.Duration
+		wantApproved bool
+	}{
+		{
+			name:         "missing workload before AllowMissingAfter elapsed",
+			stageStarted: time.Minute,
+		},
+		{
+			name:         "missing workload after AllowMissingAfter elapsed",
+			stageStarted: 10 * time.Minute,
+			wantApproved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateRun := newTestUpdateRun("member-1")
+			updateRun.Status.StagesStatus[0].StartTime = &metav1.Time{Time: time.Now().Add(-tt.stageStarted)}
+			workload := testWorkload
+			workload.AllowMissingAfter = &metav1.Duration{Duration: 5 * time.Minute}
+			r := newTestReconciler(t, newTestApprovalRequest(), updateRun, newTestWorkloadTracker(workload))
+
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": nil})
+			approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+			if approved != tt.wantApproved {
+				t.Errorf("approved = %v, want %v", approved, tt.wantApproved)
+			}
+		})
+	}
+}
+
+func TestApprovalTemplates(t *testing.T) {
+	r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker(testWorkload))
+	r.ApprovalReasonTemplate = "StageApproved"
+	r.ApprovalMessageTemplate = "Stage {{.Stage}} of {{.UpdateRun}} approved: {{.DefaultMessage}}"
+
+	got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+		"member-1": podMetrics(testWorkload, 2, 0),
+		"member-2": podMetrics(testWorkload, 2, 0),
+	})
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+	if cond == nil {
+		t.Fatalf("Approved condition not set")
+	}
+	want := metav1.Condition{
+		Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: 1,
+		Reason:             "StageApproved",
+		Message:            "Stage canary of test-run approved: All 1 required workloads have sufficient healthy replicas across 2 clusters",
+	}
+	if diff := cmp.Diff(want, *cond, ignoreConditionTime); diff != "" {
+		t.Errorf("Approved condition mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNonFiniteHealthValuesInDetails(t *testing.T) {
+	metrics := podMetrics(testWorkload, 1, 2)
+	metrics[1].UnhealthyReason = autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNaN
+	metrics[2].UnhealthyReason = autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInfinite
+	r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload))
+	approvalReq := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": metrics})
+
+	evaluation, err := r.evaluateWorkloadHealth(context.Background(), approvalReq, []string{"member-1"}, nil, testUpdateRun, testStage, nil)
+	if err != nil {
+		t.Fatalf("evaluateWorkloadHealth() error = %v", err)
+	}
+	want := []string{"cluster member-1: workload app-ns/app has 1/3 healthy pods, expected 2 (1 pods reported a NaN health value) (1 pods reported a Infinite health value)"}
+	if diff := cmp.Diff(want, evaluation.UnhealthyDetails); diff != "" {
+		t.Errorf("UnhealthyDetails mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnsureMetricCollectorReportsOnlyWritesChanges(t *testing.T) {
+	approvalReq := newTestApprovalRequest()
+	var writes int
+	countWrites := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			writes++
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			writes++
+			return c.Update(ctx, obj, opts...)
+		},
+	}
+	r := newTestReconcilerWithClient(newTestClientBuilder(t, approvalReq).WithInterceptorFuncs(countWrites).Build())
+	clusters := []string{"member-1", "member-2", "member-3"}
+	ensure := func() int {
+		t.Helper()
+		pending, updated, err := r.ensureMetricCollectorReports(context.Background(), approvalReq, clusters, testUpdateRun, testStage, testUpdateRun)
+		if err != nil {
+			t.Fatalf("ensureMetricCollectorReports() error = %v", err)
+		}
+		if len(pending) != 0 {
+			t.Fatalf("ensureMetricCollectorReports() pending namespaces = %v, want none", pending)
+		}
+		return updated
+	}
+
+	ensure()
+	if writes != len(clusters) {
+		t.Errorf("first ensure wrote %d times, want %d creates", writes, len(clusters))
+	}
+
+	writes = 0
+	if updated := ensure(); updated != 0 || writes != 0 {
+		t.Errorf("unchanged ensure updated %d reports with %d writes, want none", updated, writes)
+	}
+
+	writes = 0
+	r.QueryTemplate = `workload_health{namespace="{{.Namespace}}"}`
+	if updated := ensure(); updated != len(clusters) || writes != len(clusters) {
+		t.Errorf("ensure after a spec change updated %d reports with %d writes, want %d", updated, writes, len(clusters))
+	}
+}
+
+func TestBlockingWorkloadsRecordedOnReports(t *testing.T) {
+	r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker(testWorkload))
+	reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+		"member-1": podMetrics(testWorkload, 1, 1),
+		"member-2": nil,
+	})
+
+	want := map[string][]autoapprovev1alpha1.BlockingWorkload{
+		"member-1": {{
+			Cluster:      "member-1",
+			Namespace:    testWorkload.Namespace,
+			WorkloadName: testWorkload.Name,
+			WorkloadKind: testWorkload.Kind,
+			Reason:       workloadStateUnhealthy,
+			Message:      "cluster member-1: workload app-ns/app has 1/2 healthy pods, expected 2",
+		}},
+		"member-2": {{
+			Cluster:      "member-2",
+			Namespace:    testWorkload.Namespace,
+			WorkloadName: testWorkload.Name,
+			WorkloadKind: testWorkload.Kind,
+			Reason:       workloadStateMissing,
+			Message:      "cluster member-2: workload app-ns/app not found",
+		}},
+	}
+	for cluster, wantBlocking := range want {
+		report := &autoapprovev1alpha1.MetricCollectorReport{}
+		key := types.NamespacedName{Namespace: r.memberNamespace(cluster), Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+		if err := r.Get(context.Background(), key, report); err != nil {
+			t.Fatalf("failed to get MetricCollectorReport %s: %v", key, err)
+		}
+		if diff := cmp.Diff(wantBlocking, report.Status.BlockingWorkloads); diff != "" {
+			t.Errorf("BlockingWorkloads of %s mismatch (-want +got):\n%s", cluster, diff)
+		}
+	}
+}
+
+func TestReportUnhealthyOnly(t *testing.T) {
+	healthyCount := func(total int32) []autoapprovev1alpha1.WorkloadHealthyCount {
+		return []autoapprovev1alpha1.WorkloadHealthyCount{{
+			Namespace:    testWorkload.Namespace,
+			WorkloadName: testWorkload.Name,
+			WorkloadKind: testWorkload.Kind,
+			TotalHealthy: total,
+		}}
+	}
+	tests := []struct {
+		name                string
+		reportUnhealthyOnly bool
+		status              autoapprovev1alpha1.MetricCollectorReportStatus
+		wantApproved        bool
+	}{
+		{
+			name:         "all pods listed and healthy",
+			status:       autoapprovev1alpha1.MetricCollectorReportStatus{CollectedMetrics: podMetrics(testWorkload, 2, 0)},
+			wantApproved: true,
+		},
+		{
+			name:   "all pods listed, one unhealthy",
+			status: autoapprovev1alpha1.MetricCollectorReportStatus{CollectedMetrics: podMetrics(testWorkload, 1, 1)},
+		},
+		{
+			name:                "healthy pods counted",
+			reportUnhealthyOnly: true,
+			status:              autoapprovev1alpha1.MetricCollectorReportStatus{HealthyWorkloads: healthyCount(2)},
+			wantApproved:        true,
+		},
+		{
+			name:                "unhealthy pod listed next to enough healthy pods",
+			reportUnhealthyOnly: true,
+			status: autoapprovev1alpha1.MetricCollectorReportStatus{
+				CollectedMetrics: podMetrics(testWorkload, 0, 1),
+				HealthyWorkloads: healthyCount(2),
+			},
+			wantApproved: true,
+		},
+		{
+			name:                "unhealthy pod listed next to too few healthy pods",
+			reportUnhealthyOnly: true,
+			status: autoapprovev1alpha1.MetricCollectorReportStatus{
+				CollectedMetrics: podMetrics(testWorkload, 0, 1),
+				HealthyWorkloads: healthyCount(1),
+			},
+		},
+		{
+			name:                "workload neither listed nor counted",
+			reportUnhealthyOnly: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload))
+			r.ReportUnhealthyOnly = tt.reportUnhealthyOnly
+			key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+
+			report := &autoapprovev1alpha1.MetricCollectorReport{}
+			reportKey := types.NamespacedName{Namespace: r.memberNamespace("member-1"), Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+			if err := r.Get(context.Background(), reportKey, report); err != nil {
+				t.Fatalf("failed to get MetricCollectorReport %s: %v", reportKey, err)
+			}
+			if report.Spec.ReportUnhealthyOnly != tt.reportUnhealthyOnly {
+				t.Errorf("report spec ReportUnhealthyOnly = %t, want %t", report.Spec.ReportUnhealthyOnly, tt.reportUnhealthyOnly)
+			}
+
+			collectReport(t, r, "member-1", func(status *autoapprovev1alpha1.MetricCollectorReportStatus) {
+				status.CollectedMetrics = tt.status.CollectedMetrics
+				status.HealthyWorkloads = tt.status.HealthyWorkloads
+			})
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			got := &placementv1beta1.ApprovalRequest{}
+			if err := r.Get(context.Background(), key, got); err != nil {
+				t.Fatalf("failed to get ApprovalRequest: %v", err)
+			}
+			if approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)); approved != tt.wantApproved {
+				t.Errorf("Approved = %t, want %t", approved, tt.wantApproved)
+			}
+		})
+	}
+}
+
+func TestHandleDeleteToleratesConcurrentReconciles(t *testing.T) {
+	const otherFinalizer = "example.com/other"
+	// concurrentUpdate stands in for another reconcile that changes the ApprovalRequest right before the
+	// finalizer update of this one
+	tests := []struct {
+		name             string
+		finalizers       []string
+		concurrentUpdate func(approvalReq *placementv1beta1.ApprovalRequest)
+		wantGone         bool
+		wantFinalizers   []string
+	}{
+		{
+			name:       "deleted by another reconcile",
+			finalizers: []string{metricCollectorFinalizer},
+			concurrentUpdate: func(approvalReq *placementv1beta1.ApprovalRequest) {
+				approvalReq.Finalizers = nil
+			},
+			wantGone: true,
+		},
+		{
+			name:       "finalizer already removed by another reconcile",
+			finalizers: []string{metricCollectorFinalizer, otherFinalizer},
+			concurrentUpdate: func(approvalReq *placementv1beta1.ApprovalRequest) {
+				approvalReq.Finalizers = []string{otherFinalizer}
+			},
+			wantFinalizers: []string{otherFinalizer},
+		},
+		{
+			name:       "updated by another controller",
+			finalizers: []string{metricCollectorFinalizer, otherFinalizer},
+			concurrentUpdate: func(approvalReq *placementv1beta1.ApprovalRequest) {
+				approvalReq.Labels = map[string]string{"touched": "true"}
+			},
+			wantFinalizers: []string{otherFinalizer},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approvalReq := newTestApprovalRequest()
+			approvalReq.Finalizers = tt.finalizers
+			approvalReq.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			report := &autoapprovev1alpha1.MetricCollectorReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fleet-member-member-1",
+					Name:      fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage),
+					Labels:    map[string]string{parentApprovalRequestLabel: parentApprovalRequestLabelValue(testNamespace, testRequestName)},
+				},
+			}
+			raced := false
+			c := newTestClientBuilder(t, approvalReq, report).WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*placementv1beta1.ApprovalRequest); ok && !raced {
+						raced = true
+						current := &placementv1beta1.ApprovalRequest{}
+						if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+							return err
+						}
+						tt.concurrentUpdate(current)
+						if err := c.Update(ctx, current); err != nil {
+							return err
+						}
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+			}).Build()
+			r := newTestReconcilerWithClient(c)
+			key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(report), &autoapprovev1alpha1.MetricCollectorReport{}); !apierrors.IsNotFound(err) {
+				t.Errorf("get MetricCollectorReport error = %v, want NotFound", err)
+			}
+			got := &placementv1beta1.ApprovalRequest{}
+			err := c.Get(context.Background(), key, got)
+			if tt.wantGone {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("get ApprovalRequest error = %v, want NotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get ApprovalRequest: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantFinalizers, got.Finalizers); diff != "" {
+				t.Errorf("finalizers mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnsureMetricCollectorReportsContinuesAfterClusterError(t *testing.T) {
+	approvalReq := newTestApprovalRequest()
+	failingNamespace := "fleet-member-member-1"
+	forbidden := apierrors.NewForbidden(autoapprovev1alpha1.GroupVersion.WithResource("metriccollectorreports").GroupResource(), "", fmt.Errorf("exceeded quota"))
+	c := newTestClientBuilder(t, approvalReq).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if obj.GetNamespace() == failingNamespace {
+				return forbidden
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	r := newTestReconcilerWithClient(c)
+
+	pending, _, err := r.ensureMetricCollectorReports(context.Background(), approvalReq, []string{"member-1", "member-2", "member-3"}, testUpdateRun, testStage, testUpdateRun)
+	if !errors.Is(err, forbidden) {
+		t.Errorf("ensureMetricCollectorReports() error = %v, want the Forbidden error of %s", err, failingNamespace)
+	}
+	if len(pending) != 0 {
+		t.Errorf("ensureMetricCollectorReports() pending namespaces = %v, want none", pending)
+	}
+	for _, cluster := range []string{"member-2", "member-3"} {
+		key := types.NamespacedName{Namespace: r.memberNamespace(cluster), Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+		if err := c.Get(context.Background(), key, &autoapprovev1alpha1.MetricCollectorReport{}); err != nil {
+			t.Errorf("failed to get MetricCollectorReport %s: %v", key, err)
+		}
+	}
+}
+
+func TestReconcileRequeuesWhileNamespacePending(t *testing.T) {
+	pendingNamespace := "fleet-member-member-1"
+	namespaceExists := false
+	c := newTestClientBuilder(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker(testWorkload)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if obj.GetNamespace() == pendingNamespace && !namespaceExists {
+					return apierrors.NewNotFound(corev1.Resource("namespaces"), pendingNamespace)
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	r := newTestReconcilerWithClient(c)
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+	reportKey := func(cluster string) types.NamespacedName {
+		return types.NamespacedName{Namespace: r.memberNamespace(cluster), Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+	}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil while the namespace is pending", err)
+	}
+	if result.RequeueAfter != namespacePendingRequeueInterval {
+		t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, namespacePendingRequeueInterval)
+	}
+	if err := c.Get(context.Background(), reportKey("member-2"), &autoapprovev1alpha1.MetricCollectorReport{}); err != nil {
+		t.Errorf("failed to get the MetricCollectorReport of member-2: %v", err)
+	}
+	if got := progressingReason(t, c, key); got != progressingReasonReportNotReady {
+		t.Errorf("Progressing reason = %q, want %q", got, progressingReasonReportNotReady)
+	}
+
+	namespaceExists = true
+	result, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	if result.RequeueAfter != defaultRequeueInterval {
+		t.Errorf("Reconcile() RequeueAfter = %v, want %v once the namespace exists", result.RequeueAfter, defaultRequeueInterval)
+	}
+	if err := c.Get(context.Background(), reportKey("member-1"), &autoapprovev1alpha1.MetricCollectorReport{}); err != nil {
+		t.Errorf("failed to get the MetricCollectorReport of member-1: %v", err)
+	}
+}
+
+func TestClusterWeights(t *testing.T) {
+	tests := []struct {
+		name              string
+		minPercent        *int32
+		unhealthyClusters []string
+		wantReason        string
+	}{
+		{
+			name:              "light cluster unhealthy without a weight percentage",
+			unhealthyClusters: []string{"edge"},
+		},
+		{
+			name:              "light cluster unhealthy within the weight percentage",
+			minPercent:        ptr.To[int32](80),
+			unhealthyClusters: []string{"edge"},
+			wantReason:        approvalReasonHealthyClusterWeightMet,
+		},
+		{
+			name:              "heavy cluster unhealthy below the weight percentage",
+			minPercent:        ptr.To[int32](80),
+			unhealthyClusters: []string{"canary"},
+		},
+		{
+			name:       "all clusters healthy with a weight percentage",
+			minPercent: ptr.To[int32](80),
+			wantReason: approvalReasonAllWorkloadsHealthy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// canary weighs 3 and the clusters without a weight 1 each, so edge holds 1/5 of the total weight.
+			tracker := newTestWorkloadTracker(testWorkload)
+			tracker.ClusterWeights = map[string]int32{"canary": 3}
+			tracker.MinHealthyClusterWeightPercent = tt.minPercent
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("canary", "edge", "member-1"), tracker)
+			metricsByCluster := map[string][]autoapprovev1alpha1.WorkloadMetric{}
+			for _, cluster := range []string{"canary", "edge", "member-1"} {
+				metricsByCluster[cluster] = podMetrics(testWorkload, 2, 0)
+			}
+			for _, cluster := range tt.unhealthyClusters {
+				metricsByCluster[cluster] = podMetrics(testWorkload, 1, 1)
+			}
+
+			got := reconcileCollected(t, r, metricsByCluster)
+			var gotReason string
+			if cond := meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)); cond != nil && cond.Status == metav1.ConditionTrue {
+				gotReason = cond.Reason
+			}
+			if gotReason != tt.wantReason {
+				t.Errorf("Approved reason = %q, want %q", gotReason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestClusterWeightsWaitForUpdatingClusters(t *testing.T) {
+	clusterCondition := func(conditionType placementv1beta1.ClusterUpdatingStatusConditionType) metav1.Condition {
+		return metav1.Condition{Type: string(conditionType), Status: metav1.ConditionTrue, Reason: "Test", LastTransitionTime: metav1.Now()}
+	}
+	tracker := newTestWorkloadTracker(testWorkload)
+	tracker.MinHealthyClusterWeightPercent = ptr.To[int32](50)
+	updateRun := newTestUpdateRun("member-1", "member-2")
+	clusters := updateRun.Status.StagesStatus[0].Clusters
+	clusters[0].Conditions = []metav1.Condition{clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted), clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded)}
+	clusters[1].Conditions = []metav1.Condition{clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted)}
+	r := newTestReconciler(t, newTestApprovalRequest(), updateRun, tracker)
+
+	// member-1 alone carries all of the weight that is checked, but member-2 is still updating
+	got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": podMetrics(testWorkload, 2, 0)})
+	if meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) {
+		t.Fatalf("approved by cluster weight while member-2 is still updating")
+	}
+	if reason := progressingReason(t, r.Client, types.NamespacedName{Namespace: testNamespace, Name: testRequestName}); reason != progressingReasonClustersUpdating {
+		t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonClustersUpdating)
+	}
+}
+
+func TestMinHealthyPodPercent(t *testing.T) {
+	tests := []struct {
+		name        string
+		metrics     map[string][]autoapprovev1alpha1.WorkloadMetric
+		wantApprove bool
+		wantReason  string
+	}{
+		{
+			name: "all workloads healthy",
+			metrics: map[string][]autoapprovev1alpha1.WorkloadMetric{
+				"member-1": podMetrics(testWorkload, 4, 0),
+				"member-2": podMetrics(testWorkload, 2, 0),
+			},
+			wantApprove: true,
+			wantReason:  approvalReasonAllWorkloadsHealthy,
+		},
+		{
+			name: "unhealthy cluster with the stage above the percentage",
+			metrics: map[string][]autoapprovev1alpha1.WorkloadMetric{
+				"member-1": podMetrics(testWorkload, 4, 0),
+				"member-2": podMetrics(testWorkload, 1, 1),
+			},
+			wantApprove: true,
+			wantReason:  approvalReasonHealthyPodPercentMet,
+		},
+		{
+			name: "unhealthy cluster with the stage at the percentage",
+			metrics: map[string][]autoapprovev1alpha1.WorkloadMetric{
+				"member-1": podMetrics(testWorkload, 2, 0),
+				"member-2": podMetrics(testWorkload, 1, 1),
+			},
+			wantApprove: true,
+			wantReason:  approvalReasonHealthyPodPercentMet,
+		},
+		{
+			name: "unhealthy cluster with the stage below the percentage",
+			metrics: map[string][]autoapprovev1alpha1.WorkloadMetric{
+				"member-1": podMetrics(testWorkload, 4, 0),
+				"member-2": podMetrics(testWorkload, 0, 2),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newTestWorkloadTracker(testWorkload)
+			tracker.MinHealthyPodPercent = ptr.To[int32](75)
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), tracker)
+
+			got := reconcileCollected(t, r, tt.metrics)
+			cond := meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+			if !tt.wantApprove {
+				if cond != nil {
+					t.Errorf("Approved condition = %+v, want none", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != metav1.ConditionTrue {
+				t.Fatalf("Approved condition = %+v, want True", cond)
+			}
+			if cond.Reason != tt.wantReason {
+				t.Errorf("Approved reason = %q, want %q", cond.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestNoEffectiveClusters(t *testing.T) {
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+	t.Run("stage without clusters", func(t *testing.T) {
+		r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun(), newTestWorkloadTracker(testWorkload))
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() error = %v, want nil", err)
+		}
+		got := &placementv1beta1.ApprovalRequest{}
+		if err := r.Get(context.Background(), key, got); err != nil {
+			t.Fatalf("failed to get ApprovalRequest: %v", err)
+		}
+		if meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) != nil {
+			t.Errorf("Approved condition set on a stage without clusters: %+v", got.Status.Conditions)
+		}
+		if reason := progressingReason(t, r.Client, key); reason != progressingReasonNoEffectiveClusters {
+			t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonNoEffectiveClusters)
+		}
+		reports := &autoapprovev1alpha1.MetricCollectorReportList{}
+		if err := r.List(context.Background(), reports); err != nil {
+			t.Fatalf("failed to list MetricCollectorReports: %v", err)
+		}
+		if len(reports.Items) != 0 {
+			t.Errorf("created %d MetricCollectorReports, want none", len(reports.Items))
+		}
+	})
+
+	t.Run("all clusters weighted 0", func(t *testing.T) {
+		tracker := newTestWorkloadTracker(testWorkload)
+		tracker.ClusterWeights = map[string]int32{"member-1": 0, "member-2": 0}
+		tracker.MinHealthyClusterWeightPercent = ptr.To[int32](50)
+		r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), tracker)
+
+		got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+			"member-1": podMetrics(testWorkload, 2, 0),
+			"member-2": podMetrics(testWorkload, 1, 1),
+		})
+		if meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) {
+			t.Errorf("approved with every cluster weighted 0 and an unhealthy cluster")
+		}
+		if reason := progressingReason(t, r.Client, key); reason != progressingReasonNoEffectiveClusters {
+			t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonNoEffectiveClusters)
+		}
+	})
+}
+
+func TestClustersInMaintenance(t *testing.T) {
+	inMaintenance := func(cluster string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "fleet-member-" + cluster,
+			Annotations: map[string]string{maintenanceAnnotation: "true"},
+		}}
+	}
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+	t.Run("unhealthy cluster in maintenance", func(t *testing.T) {
+		r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker(testWorkload), inMaintenance("member-2"))
+		got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+			"member-1": podMetrics(testWorkload, 2, 0),
+			"member-2": podMetrics(testWorkload, 0, 2),
+		})
+		cond := meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			t.Fatalf("Approved condition = %+v, want True with member-2 in maintenance", cond)
+		}
+		if !strings.Contains(cond.Message, "excluded clusters in maintenance: member-2") {
+			t.Errorf("Approved message = %q, want it to list member-2 as excluded", cond.Message)
+		}
+		if !strings.Contains(cond.Message, "across 1 clusters") {
+			t.Errorf("Approved message = %q, want only member-1 counted", cond.Message)
+		}
+	})
+
+	t.Run("all clusters in maintenance", func(t *testing.T) {
+		r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker(testWorkload), inMaintenance("member-1"), inMaintenance("member-2"))
+		got := reconcileCollected(t, r, nil)
+		if meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) != nil {
+			t.Errorf("Approved condition set with every cluster in maintenance: %+v", got.Status.Conditions)
+		}
+		if reason := progressingReason(t, r.Client, key); reason != progressingReasonNoEffectiveClusters {
+			t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonNoEffectiveClusters)
+		}
+	})
+}
+
+func TestReconcileRequiresCurrentSuccessfulCollection(t *testing.T) {
+	tests := []struct {
+		name        string
+		condition   func(generation int64) metav1.Condition
+		wantApprove bool
+	}{
+		{
+			name: "collected for the current generation",
+			condition: func(generation int64) metav1.Condition {
+				return metav1.Condition{Status: metav1.ConditionTrue, ObservedGeneration: generation, Reason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded}
+			},
+			wantApprove: true,
+		},
+		{
+			name: "kube status fallback for the current generation",
+			condition: func(generation int64) metav1.Condition {
+				return metav1.Condition{Status: metav1.ConditionTrue, ObservedGeneration: generation, Reason: autoapprovev1alpha1.MetricCollectorReportConditionReasonKubeStatusFallback}
+			},
+			wantApprove: true,
+		},
+		{
+			name: "collected for a stale generation",
+			condition: func(generation int64) metav1.Condition {
+				return metav1.Condition{Status: metav1.ConditionTrue, ObservedGeneration: generation - 1, Reason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded}
+			},
+		},
+		{
+			name: "failed collection",
+			condition: func(generation int64) metav1.Condition {
+				return metav1.Condition{Status: metav1.ConditionFalse, ObservedGeneration: generation, Reason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionFailed}
+			},
+		},
+		{
+			name: "cleared collection",
+			condition: func(generation int64) metav1.Condition {
+				return metav1.Condition{Status: metav1.ConditionUnknown, ObservedGeneration: generation, Reason: autoapprovev1alpha1.MetricCollectorReportConditionReasonSpecChanged}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload))
+			key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			reportKey := types.NamespacedName{Namespace: "fleet-member-member-1", Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+			report := &autoapprovev1alpha1.MetricCollectorReport{}
+			if err := r.Get(context.Background(), reportKey, report); err != nil {
+				t.Fatalf("failed to get MetricCollectorReport %s: %v", reportKey, err)
+			}
+			// The healthy metrics are left over from an earlier collection unless the condition is current
+			now := metav1.Now()
+			report.Status.LastCollectionTime = &now
+			report.Status.CollectedMetrics = podMetrics(testWorkload, 2, 0)
+			cond := tt.condition(report.Generation)
+			cond.Type = autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected
+			meta.SetStatusCondition(&report.Status.Conditions, cond)
+			if err := r.Status().Update(context.Background(), report); err != nil {
+				t.Fatalf("failed to update MetricCollectorReport %s: %v", reportKey, err)
+			}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			got := &placementv1beta1.ApprovalRequest{}
+			if err := r.Get(context.Background(), key, got); err != nil {
+				t.Fatalf("failed to get ApprovalRequest: %v", err)
+			}
+			if approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)); approved != tt.wantApprove {
+				t.Fatalf("approved = %v, want %v", approved, tt.wantApprove)
+			}
+			if !tt.wantApprove {
+				if reason := progressingReason(t, r.Client, key); reason != progressingReasonWaitingForReports {
+					t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonWaitingForReports)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileRequeuesOnApprovalRequestConflict(t *testing.T) {
+	approvalReq := newTestApprovalRequest()
+	conflict := interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			if _, ok := obj.(*placementv1beta1.ApprovalRequest); ok {
+				return apierrors.NewConflict(placementv1beta1.GroupVersion.WithResource("approvalrequests").GroupResource(), obj.GetName(), errors.New("the object has been modified"))
+			}
+			return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+		},
+	}
+	r := newTestReconcilerWithClient(newTestClientBuilder(t, approvalReq, newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload)).WithInterceptorFuncs(conflict).Build())
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	collectReports(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": podMetrics(testWorkload, 2, 0)})
+
+	got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	if got.RequeueAfter <= 0 {
+		t.Errorf("Reconcile() = %+v, want a RequeueAfter after a conflict", got)
+	}
+}
+
+func TestReconcileWaitsForUpdateRunInitialization(t *testing.T) {
+	// The UpdateRun has only listed member-1 of the stage so far
+	updateRun := newTestUpdateRun("member-1")
+	updateRun.Status.Conditions = nil
+	r := newTestReconciler(t, newTestApprovalRequest(), updateRun, newTestWorkloadTracker(testWorkload))
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	if result.RequeueAfter <= 0 {
+		t.Errorf("Reconcile() = %+v, want a RequeueAfter while the UpdateRun is not initialized", result)
+	}
+	if reason := progressingReason(t, r.Client, key); reason != progressingReasonUpdateRunNotInitialized {
+		t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonUpdateRunNotInitialized)
+	}
+	reports := &autoapprovev1alpha1.MetricCollectorReportList{}
+	if err := r.List(context.Background(), reports); err != nil {
+		t.Fatalf("failed to list MetricCollectorReports: %v", err)
+	}
+	if len(reports.Items) != 0 {
+		t.Errorf("created %d MetricCollectorReports before the UpdateRun was initialized, want none", len(reports.Items))
+	}
+
+	// Once initialized, the stage lists all its clusters, and reports are created for each of them
+	initialized := newTestUpdateRun("member-1", "member-2")
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(updateRun), updateRun); err != nil {
+		t.Fatalf("failed to get StagedUpdateRun: %v", err)
+	}
+	updateRun.Status = initialized.Status
+	if err := r.Update(context.Background(), updateRun); err != nil {
+		t.Fatalf("failed to update StagedUpdateRun: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	if err := r.List(context.Background(), reports); err != nil {
+		t.Fatalf("failed to list MetricCollectorReports: %v", err)
+	}
+	var namespaces []string
+	for _, report := range reports.Items {
+		namespaces = append(namespaces, report.Namespace)
+	}
+	if diff := cmp.Diff([]string{"fleet-member-member-1", "fleet-member-member-2"}, namespaces, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("MetricCollectorReport namespaces mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileSkipsClustersStillUpdating(t *testing.T) {
+	clusterCondition := func(conditionType placementv1beta1.ClusterUpdatingStatusConditionType) metav1.Condition {
+		return metav1.Condition{Type: string(conditionType), Status: metav1.ConditionTrue, Reason: "Test", LastTransitionTime: metav1.Now()}
+	}
+	updateRun := newTestUpdateRun("member-1", "member-2", "member-3")
+	clusters := updateRun.Status.StagesStatus[0].Clusters
+	clusters[0].Conditions = []metav1.Condition{clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted), clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded)}
+	clusters[1].Conditions = []metav1.Condition{clusterCondition(placementv1beta1.ClusterUpdatingConditionStarted)}
+	r := newTestReconciler(t, newTestApprovalRequest(), updateRun, newTestWorkloadTracker(testWorkload))
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+	// member-2 is still updating and has not collected yet, member-3 has not started and is evaluated as usual
+	got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+		"member-1": podMetrics(testWorkload, 2, 0),
+		"member-3": podMetrics(testWorkload, 2, 0),
+	})
+	if meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) {
+		t.Fatalf("approved while member-2 is still updating")
+	}
+	if reason := progressingReason(t, r.Client, key); reason != progressingReasonClustersUpdating {
+		t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonClustersUpdating)
+	}
+
+	// Once member-2 finishes updating, its health is checked
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(updateRun), updateRun); err != nil {
+		t.Fatalf("failed to get StagedUpdateRun: %v", err)
+	}
+	meta.SetStatusCondition(&updateRun.Status.StagesStatus[0].Clusters[1].Conditions, clusterCondition(placementv1beta1.ClusterUpdatingConditionSucceeded))
+	if err := r.Update(context.Background(), updateRun); err != nil {
+		t.Fatalf("failed to update StagedUpdateRun: %v", err)
+	}
+	got = reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-2": podMetrics(testWorkload, 2, 0)})
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) {
+		t.Errorf("not approved after member-2 finished updating healthy: %+v", got.Status.Conditions)
+	}
+}
+
+func TestReconcileCustomMemberNamespaceFormat(t *testing.T) {
+	r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload))
+	r.MemberNamespaceFormat = "acme-fleet-%s"
+
+	got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": podMetrics(testWorkload, 2, 0)})
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) {
+		t.Errorf("not approved with healthy workloads: %+v", got.Status.Conditions)
+	}
+	reports := &autoapprovev1alpha1.MetricCollectorReportList{}
+	if err := r.List(context.Background(), reports); err != nil {
+		t.Fatalf("failed to list MetricCollectorReports: %v", err)
+	}
+	var namespaces []string
+	for _, report := range reports.Items {
+		namespaces = append(namespaces, report.Namespace)
+	}
+	if diff := cmp.Diff([]string{"acme-fleet-member-1"}, namespaces); diff != "" {
+		t.Errorf("MetricCollectorReport namespaces mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileAnnotatesReportsOnApproval(t *testing.T) {
+	tests := []struct {
+		name         string
+		healthy      int
+		wantApproved bool
+	}{
+		{name: "approved", healthy: 2, wantApproved: true},
+		{name: "not approved", healthy: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker(testWorkload))
+			before := time.Now().Add(-time.Second)
+
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+				"member-1": podMetrics(testWorkload, 2, 0),
+				"member-2": podMetrics(testWorkload, tt.healthy, 2-tt.healthy),
+			})
+			if approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)); approved != tt.wantApproved {
+				t.Fatalf("approved = %v, want %v", approved, tt.wantApproved)
+			}
+			reports := &autoapprovev1alpha1.MetricCollectorReportList{}
+			if err := r.List(context.Background(), reports); err != nil {
+				t.Fatalf("failed to list MetricCollectorReports: %v", err)
+			}
+			if len(reports.Items) != 2 {
+				t.Fatalf("MetricCollectorReports = %d, want 2", len(reports.Items))
+			}
+			for _, report := range reports.Items {
+				approvedBy, hasApprovedBy := report.Annotations[approvedByAnnotation]
+				approvedAt, hasApprovedAt := report.Annotations[approvedAtAnnotation]
+				if !tt.wantApproved {
+					if hasApprovedBy || hasApprovedAt {
+						t.Errorf("report %s annotations = %v, want no approval annotations", report.Namespace, report.Annotations)
+					}
+					continue
+				}
+				if want := testNamespace + "/" + testRequestName; approvedBy != want {
+					t.Errorf("report %s %s = %q, want %q", report.Namespace, approvedByAnnotation, approvedBy, want)
+				}
+				at, err := time.Parse(time.RFC3339, approvedAt)
+				if err != nil || at.Before(before.Truncate(time.Second)) {
+					t.Errorf("report %s %s = %q, want an RFC 3339 time of the approval", report.Namespace, approvedAtAnnotation, approvedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileDeletesDecidedApprovalRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		status metav1.ConditionStatus
+		reason string
+	}{
+		{name: "approved", status: metav1.ConditionTrue, reason: approvalReasonAllWorkloadsHealthy},
+		{name: "rejected", status: metav1.ConditionFalse, reason: "Rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approvalReq := newTestApprovalRequest(metav1.Condition{
+				Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
+				Status:             tt.status,
+				ObservedGeneration: 1,
+				Reason:             tt.reason,
+				LastTransitionTime: metav1.Now(),
+			})
+			approvalReq.Finalizers = []string{metricCollectorFinalizer}
+			approvalReq.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			report := &autoapprovev1alpha1.MetricCollectorReport{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fleet-member-member-1",
+					Name:      fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage),
+					Labels:    map[string]string{parentApprovalRequestLabel: parentApprovalRequestLabelValue(testNamespace, testRequestName)},
+				},
+			}
+			r := newTestReconciler(t, approvalReq, report)
+			key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			if err := r.Get(context.Background(), client.ObjectKeyFromObject(report), &autoapprovev1alpha1.MetricCollectorReport{}); !apierrors.IsNotFound(err) {
+				t.Errorf("get MetricCollectorReport error = %v, want NotFound", err)
+			}
+			// The fake client deletes the ApprovalRequest once its last finalizer is removed
+			if err := r.Get(context.Background(), key, &placementv1beta1.ApprovalRequest{}); !apierrors.IsNotFound(err) {
+				t.Errorf("get ApprovalRequest error = %v, want NotFound", err)
+			}
+		})
+	}
+}
+
+func TestDecidedApprovalRequestDeletionPassesPredicates(t *testing.T) {
+	decided := newTestApprovalRequest(metav1.Condition{
+		Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: 1,
+		Reason:             approvalReasonAllWorkloadsHealthy,
+	})
+	deleting := decided.DeepCopy()
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	relabeled := decided.DeepCopy()
+	relabeled.Labels = map[string]string{"touched": "true"}
+	forPredicates := predicate.And[client.Object](
+		predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, reconcilePausedAnnotationChangedPredicate, deletionStartedPredicate),
+		decidedApprovalRequestPredicate,
+	)
+	tests := []struct {
+		name   string
+		oldObj client.Object
+		newObj client.Object
+		want   bool
+	}{
+		{name: "deletion started without a generation bump", oldObj: decided, newObj: deleting, want: true},
+		{name: "metadata edit", oldObj: decided, newObj: relabeled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := forPredicates.Update(event.UpdateEvent{ObjectOld: tt.oldObj, ObjectNew: tt.newObj}); got != tt.want {
+				t.Errorf("Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApprovalRequestForReport(t *testing.T) {
+	report := func(kind, namespace, parent string) *autoapprovev1alpha1.MetricCollectorReport {
+		return &autoapprovev1alpha1.MetricCollectorReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mc-run-stage",
+				Namespace: "fleet-member-member-1",
+				Labels:    map[string]string{parentApprovalRequestLabel: parent},
+			},
+			Spec: autoapprovev1alpha1.MetricCollectorReportSpec{
+				WorkloadTrackerRef: &autoapprovev1alpha1.WorkloadTrackerReference{Kind: kind, Name: "run", Namespace: namespace},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		report      *autoapprovev1alpha1.MetricCollectorReport
+		wantCluster []reconcile.Request
+		wantNamed   []reconcile.Request
+	}{
+		{
+			name:        "report of a ClusterApprovalRequest",
+			report:      report(autoapprovev1alpha1.ClusterStagedWorkloadTrackerKind, "", "run-stage"),
+			wantCluster: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "run-stage"}}},
+		},
+		{
+			name:      "report of an ApprovalRequest",
+			report:    report(autoapprovev1alpha1.StagedWorkloadTrackerKind, testNamespace, testNamespace+".run-stage"),
+			wantNamed: []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "run-stage"}}},
+		},
+		{
+			name:   "parent label of another namespace",
+			report: report(autoapprovev1alpha1.StagedWorkloadTrackerKind, testNamespace, "other-ns.run-stage"),
+		},
+		{
+			name:   "without parent label",
+			report: report(autoapprovev1alpha1.ClusterStagedWorkloadTrackerKind, "", ""),
+		},
+		{
+			name: "without workload tracker reference",
+			report: &autoapprovev1alpha1.MetricCollectorReport{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{parentApprovalRequestLabel: "run-stage"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.wantCluster, clusterApprovalRequestForReport(context.Background(), tt.report)); diff != "" {
+				t.Errorf("clusterApprovalRequestForReport() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantNamed, approvalRequestForReport(context.Background(), tt.report)); diff != "" {
+				t.Errorf("approvalRequestForReport() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportDeletedPredicate(t *testing.T) {
+	report := &autoapprovev1alpha1.MetricCollectorReport{}
+	if reportDeletedPredicate.Create(event.CreateEvent{Object: report}) {
+		t.Errorf("Create() = true, want false")
+	}
+	if reportDeletedPredicate.Update(event.UpdateEvent{ObjectOld: report, ObjectNew: report}) {
+		t.Errorf("Update() = true, want false")
+	}
+	if !reportDeletedPredicate.Delete(event.DeleteEvent{Object: report}) {
+		t.Errorf("Delete() = false, want true")
+	}
+	if reportDeletedPredicate.Generic(event.GenericEvent{Object: report}) {
+		t.Errorf("Generic() = true, want false")
+	}
+}
+
+func TestReconcileRecreatesDeletedReport(t *testing.T) {
+	r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload))
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	reportKey := types.NamespacedName{Namespace: "fleet-member-member-1", Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+	report := &autoapprovev1alpha1.MetricCollectorReport{}
+	if err := r.Get(context.Background(), reportKey, report); err != nil {
+		t.Fatalf("failed to get MetricCollectorReport %s: %v", reportKey, err)
+	}
+	if got := approvalRequestForReport(context.Background(), report); len(got) != 1 || got[0].NamespacedName != key {
+		t.Fatalf("approvalRequestForReport() = %v, want the request of %s", got, key)
+	}
+	if err := r.Delete(context.Background(), report); err != nil {
+		t.Fatalf("failed to delete MetricCollectorReport %s: %v", reportKey, err)
+	}
+
+	// The deletion enqueues the ApprovalRequest, whose reconcile recreates the report
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	if err := r.Get(context.Background(), reportKey, &autoapprovev1alpha1.MetricCollectorReport{}); err != nil {
+		t.Errorf("MetricCollectorReport %s not recreated: %v", reportKey, err)
+	}
+}
+
+// recordedEvent is an event recorded by eventRecorder.
+type recordedEvent struct {
+	object  client.Object
+	reason  string
+	message string
+}
+
+// eventRecorder is a record.EventRecorder that keeps the object of every event, which record.FakeRecorder drops.
+type eventRecorder struct {
+	events []recordedEvent
+}
+
+func (e *eventRecorder) Event(object runtime.Object, _, reason, message string) {
+	e.events = append(e.events, recordedEvent{object: object.(client.Object), reason: reason, message: message})
+}
+
+func (e *eventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	e.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (e *eventRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	e.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func TestReconcileRecordsUpdateRunEvents(t *testing.T) {
+	tests := []struct {
+		name                  string
+		recordUpdateRunEvents bool
+		wantUpdateRunEvent    bool
+	}{
+		{name: "disabled"},
+		{name: "enabled", recordUpdateRunEvents: true, wantUpdateRunEvent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker(testWorkload))
+			r.RecordUpdateRunEvents = tt.recordUpdateRunEvents
+			recorder := &eventRecorder{}
+			r.recorder = recorder
+
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+				"member-1": podMetrics(testWorkload, 2, 0),
+				"member-2": podMetrics(testWorkload, 2, 0),
+			})
+			if !meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) {
+				t.Fatalf("not approved with all workloads healthy: %+v", got.Status.Conditions)
+			}
+			var gotUpdateRunEvent bool
+			for _, e := range recorder.events {
+				if _, ok := e.object.(*placementv1beta1.StagedUpdateRun); !ok {
+					continue
+				}
+				if e.reason != "StageApproved" || e.object.GetName() != testUpdateRun {
+					t.Errorf("event %s on UpdateRun %s, want StageApproved on %s", e.reason, e.object.GetName(), testUpdateRun)
+				}
+				gotUpdateRunEvent = true
+			}
+			if gotUpdateRunEvent != tt.wantUpdateRunEvent {
+				t.Errorf("recorded an event on the UpdateRun = %v, want %v", gotUpdateRunEvent, tt.wantUpdateRunEvent)
+			}
+		})
+	}
+
+	t.Run("UpdateRun deleted", func(t *testing.T) {
+		approvalReq := newTestApprovalRequest()
+		r := newTestReconciler(t, approvalReq)
+		r.RecordUpdateRunEvents = true
+		recorder := &eventRecorder{}
+		r.recorder = recorder
+
+		r.recordUpdateRunApproved(context.Background(), approvalReq, testUpdateRun, testStage, 2)
+		if len(recorder.events) != 0 {
+			t.Errorf("recorded %d events for a deleted UpdateRun, want none", len(recorder.events))
+		}
+	})
+}
+
+func TestReconcileInvalidApprovalRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		updateRun   string
+		stage       string
+		wantMessage string
+	}{
+		{name: "no update run", stage: testStage, wantMessage: "spec.parentStageRollout"},
+		{name: "no stage", updateRun: testUpdateRun, wantMessage: "spec.targetStage"},
+		{name: "neither", wantMessage: "spec.parentStageRollout and spec.targetStage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approvalReq := newTestApprovalRequest()
+			approvalReq.Spec.TargetUpdateRun = tt.updateRun
+			approvalReq.Spec.TargetStage = tt.stage
+			r := newTestReconciler(t, approvalReq, newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload))
+			key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			if diff := cmp.Diff(ctrl.Result{}, result); diff != "" {
+				t.Errorf("Reconcile() result mismatch (-want +got):\n%s", diff)
+			}
+			got := &placementv1beta1.ApprovalRequest{}
+			if err := r.Get(context.Background(), key, got); err != nil {
+				t.Fatalf("failed to get ApprovalRequest: %v", err)
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, approvalRequestConditionProgressing)
+			if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != progressingReasonInvalidApprovalRequest || !strings.Contains(cond.Message, tt.wantMessage) {
+				t.Errorf("Progressing condition = %+v, want False with reason %s naming %s", cond, progressingReasonInvalidApprovalRequest, tt.wantMessage)
+			}
+			if len(got.Finalizers) != 0 {
+				t.Errorf("Finalizers = %v, want none", got.Finalizers)
+			}
+			reports := &autoapprovev1alpha1.MetricCollectorReportList{}
+			if err := r.List(context.Background(), reports); err != nil {
+				t.Fatalf("failed to list MetricCollectorReports: %v", err)
+			}
+			if len(reports.Items) != 0 {
+				t.Errorf("created %d MetricCollectorReports, want none", len(reports.Items))
+			}
+		})
+	}
+}
+
+func TestWorkloadHealthStates(t *testing.T) {
+	tests := []struct {
+		name              string
+		healthy           int
+		unhealthy         int
+		blockDegraded     bool
+		wantState         string
+		wantApproved      bool
+		wantDegradedEvent bool
+	}{
+		{name: "healthy", healthy: 2, wantState: workloadStateHealthy, wantApproved: true},
+		{name: "degraded", healthy: 2, unhealthy: 1, wantState: workloadStateDegraded, wantApproved: true, wantDegradedEvent: true},
+		{name: "degraded blocking", healthy: 2, unhealthy: 1, blockDegraded: true, wantState: workloadStateDegraded},
+		{name: "unhealthy", healthy: 1, unhealthy: 2, wantState: workloadStateUnhealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload))
+			r.BlockDegradedWorkloads = tt.blockDegraded
+			recorder := record.NewFakeRecorder(10)
+			r.recorder = recorder
+			metrics := map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": podMetrics(testWorkload, tt.healthy, tt.unhealthy)}
+
+			got := reconcileCollected(t, r, metrics)
+			if approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)); approved != tt.wantApproved {
+				t.Errorf("approved = %v, want %v", approved, tt.wantApproved)
+			}
+			var gotDegradedEvent bool
+			for len(recorder.Events) > 0 {
+				if strings.Contains(<-recorder.Events, "DegradedWorkloads") {
+					gotDegradedEvent = true
+				}
+			}
+			if gotDegradedEvent != tt.wantDegradedEvent {
+				t.Errorf("DegradedWorkloads event emitted = %v, want %v", gotDegradedEvent, tt.wantDegradedEvent)
+			}
+
+			evaluation, err := r.evaluateWorkloadHealth(context.Background(), got, []string{"member-1"}, nil, testUpdateRun, testStage, nil)
+			if err != nil {
+				t.Fatalf("evaluateWorkloadHealth() error = %v", err)
+			}
+			if len(evaluation.Clusters) != 1 || len(evaluation.Clusters[0].Workloads) != 1 {
+				t.Fatalf("evaluation clusters = %+v, want 1 cluster with 1 workload", evaluation.Clusters)
+			}
+			if state := evaluation.Clusters[0].Workloads[0].State; state != tt.wantState {
+				t.Errorf("workload state = %q, want %q", state, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestWorkloadValueThresholds(t *testing.T) {
+	workload := testWorkload
+	workload.WarnThreshold = ptr.To(resource.MustParse("0.95"))
+	workload.CriticalThreshold = ptr.To(resource.MustParse("0.9"))
+	tests := []struct {
+		name             string
+		values           []string
+		wantState        string
+		wantApproved     bool
+		wantWarningEvent bool
+	}{
+		{name: "ok", values: []string{"1", "0.95"}, wantState: workloadStateHealthy, wantApproved: true},
+		{name: "warn", values: []string{"1", "0.92"}, wantState: workloadStateWarning, wantApproved: true, wantWarningEvent: true},
+		{name: "critical", values: []string{"1", "0.5"}, wantState: workloadStateCritical},
+		{name: "NaN", values: []string{"1", "NaN"}, wantState: workloadStateCritical},
+		{name: "not a number", values: []string{"1", "high"}, wantState: workloadStateParseError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"), newTestWorkloadTracker(workload))
+			recorder := record.NewFakeRecorder(10)
+			r.recorder = recorder
+			// Every pod counts as healthy, so only the values decide
+			metrics := podMetrics(workload, len(tt.values), 0)
+			for i, value := range tt.values {
+				metrics[i].Value = value
+			}
+
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": metrics})
+			if approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)); approved != tt.wantApproved {
+				t.Errorf("approved = %v, want %v", approved, tt.wantApproved)
+			}
+			var gotWarningEvent bool
+			for len(recorder.Events) > 0 {
+				if strings.Contains(<-recorder.Events, "WorkloadsBelowWarnThreshold") {
+					gotWarningEvent = true
+				}
+			}
+			if gotWarningEvent != tt.wantWarningEvent {
+				t.Errorf("WorkloadsBelowWarnThreshold event emitted = %v, want %v", gotWarningEvent, tt.wantWarningEvent)
+			}
+
+			evaluation, err := r.evaluateApprovalRequest(context.Background(), client.ObjectKeyFromObject(got))
+			if err != nil {
+				t.Fatalf("evaluateApprovalRequest() error = %v", err)
+			}
+			if len(evaluation.Clusters) != 1 || len(evaluation.Clusters[0].Workloads) != 1 {
+				t.Fatalf("evaluation clusters = %+v, want 1 cluster with 1 workload", evaluation.Clusters)
+			}
+			if state := evaluation.Clusters[0].Workloads[0].State; state != tt.wantState {
+				t.Errorf("workload state = %q, want %q", state, tt.wantState)
+			}
+
+			// A blocking workload is recorded on the report with its state as the reason
+			report := &autoapprovev1alpha1.MetricCollectorReport{}
+			key := types.NamespacedName{Namespace: r.memberNamespace("member-1"), Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+			if err := r.Get(context.Background(), key, report); err != nil {
+				t.Fatalf("failed to get MetricCollectorReport %s: %v", key, err)
+			}
+			var gotReasons []string
+			for _, blocking := range report.Status.BlockingWorkloads {
+				gotReasons = append(gotReasons, blocking.Reason)
+			}
+			var wantReasons []string
+			if !tt.wantApproved {
+				wantReasons = []string{tt.wantState}
+			}
+			if diff := cmp.Diff(wantReasons, gotReasons); diff != "" {
+				t.Errorf("BlockingWorkloads reasons mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEmptyTrackerBehavior(t *testing.T) {
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+	tests := []struct {
+		name         string
+		behavior     EmptyTrackerBehavior
+		wantApproved bool
+	}{
+		{name: "default"},
+		{name: "block", behavior: EmptyTrackerBehaviorBlock},
+		{name: "approve", behavior: EmptyTrackerBehaviorApprove, wantApproved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker())
+			r.EmptyTrackerBehavior = tt.behavior
+			r.RecordUpdateRunEvents = true
+			recorder := &eventRecorder{}
+			r.recorder = recorder
+
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": nil, "member-2": nil})
+			cond := meta.FindStatusCondition(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved))
+			if !tt.wantApproved {
+				if cond != nil {
+					t.Errorf("Approved condition = %+v, want none with an empty tracker", cond)
+				}
+				if reason := progressingReason(t, r.Client, key); reason != progressingReasonNoWorkloadsTracked {
+					t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonNoWorkloadsTracked)
+				}
+				return
+			}
+			if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != approvalReasonNoWorkloadsTracked {
+				t.Errorf("Approved condition = %+v, want True with reason %s", cond, approvalReasonNoWorkloadsTracked)
+			}
+			// The StageApproved event counts the clusters of the stage even though no workloads were checked on them
+			var gotMessages []string
+			for _, e := range recorder.events {
+				if e.reason == "StageApproved" {
+					gotMessages = append(gotMessages, e.message)
+				}
+			}
+			wantMessages := []string{fmt.Sprintf("Stage %s approved by %s/%s after checking workload health across 2 clusters", testStage, testNamespace, testRequestName)}
+			if diff := cmp.Diff(wantMessages, gotMessages); diff != "" {
+				t.Errorf("StageApproved events mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileFinalizer(t *testing.T) {
+	approved := metav1.Condition{
+		Type:               string(placementv1beta1.ApprovalRequestConditionApproved),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: 1,
+		Reason:             approvalReasonAllWorkloadsHealthy,
+		LastTransitionTime: metav1.Now(),
+	}
+	tests := []struct {
+		name                      string
+		approvalReq               *placementv1beta1.ApprovalRequest
+		disableFinalizers         bool
+		finalizeCompletedRequests bool
+		wantFinalizer             bool
+	}{
+		{
+			name:          "pending on first reconcile",
+			approvalReq:   newTestApprovalRequest(),
+			wantFinalizer: true,
+		},
+		{
+			name:              "pending with finalizers disabled",
+			approvalReq:       newTestApprovalRequest(),
+			disableFinalizers: true,
+		},
+		{
+			name:        "approved on first reconcile",
+			approvalReq: newTestApprovalRequest(approved),
+		},
+		{
+			name:                      "approved on first reconcile finalizing completed requests",
+			approvalReq:               newTestApprovalRequest(approved),
+			finalizeCompletedRequests: true,
+			wantFinalizer:             true,
+		},
+		{
+			name:                      "approved finalizing completed requests with finalizers disabled",
+			approvalReq:               newTestApprovalRequest(approved),
+			disableFinalizers:         true,
+			finalizeCompletedRequests: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, tt.approvalReq, newTestUpdateRun("member-1"), newTestWorkloadTracker(testWorkload))
+			r.DisableFinalizers = tt.disableFinalizers
+			r.FinalizeCompletedRequests = tt.finalizeCompletedRequests
+			key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			got := &placementv1beta1.ApprovalRequest{}
+			if err := r.Get(context.Background(), key, got); err != nil {
+				t.Fatalf("failed to get ApprovalRequest: %v", err)
+			}
+			if hasFinalizer := controllerutil.ContainsFinalizer(got, metricCollectorFinalizer); hasFinalizer != tt.wantFinalizer {
+				t.Errorf("has finalizer %s = %v, want %v", metricCollectorFinalizer, hasFinalizer, tt.wantFinalizer)
+			}
+		})
+	}
+}
+
+func TestReconcileDefaultWorkloadTracker(t *testing.T) {
+	const defaultTracker = "default"
+	// other is a workload that is never reported, so that approval tells which tracker was used
+	other := autoapprovev1alpha1.WorkloadReference{Name: "other", Namespace: "app-ns", Kind: "Deployment", HealthyReplicas: 1}
+	newDefaultTracker := func(workloads ...autoapprovev1alpha1.WorkloadReference) *autoapprovev1alpha1.StagedWorkloadTracker {
+		tracker := newTestWorkloadTracker(workloads...)
+		tracker.Name = defaultTracker
+		return tracker
+	}
+	tests := []struct {
+		name         string
+		trackers     []client.Object
+		wantApproved bool
+		wantTracker  string
+	}{
+		{
+			name:         "only the default tracker",
+			trackers:     []client.Object{newDefaultTracker(testWorkload)},
+			wantApproved: true,
+			wantTracker:  defaultTracker,
+		},
+		{
+			name:         "run-specific tracker takes precedence",
+			trackers:     []client.Object{newTestWorkloadTracker(testWorkload), newDefaultTracker(other)},
+			wantApproved: true,
+			wantTracker:  testUpdateRun,
+		},
+		{
+			name:        "default tracker with a missing workload",
+			trackers:    []client.Object{newDefaultTracker(testWorkload, other)},
+			wantTracker: defaultTracker,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := append([]client.Object{newTestApprovalRequest(), newTestUpdateRun("member-1")}, tt.trackers...)
+			r := newTestReconciler(t, objs...)
+			r.DefaultWorkloadTracker = defaultTracker
+
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{"member-1": podMetrics(testWorkload, 2, 0)})
+			if approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)); approved != tt.wantApproved {
+				t.Errorf("approved = %v, want %v", approved, tt.wantApproved)
+			}
+			report := &autoapprovev1alpha1.MetricCollectorReport{}
+			key := types.NamespacedName{Namespace: r.memberNamespace("member-1"), Name: fmt.Sprintf("mc-%s-%s", testUpdateRun, testStage)}
+			if err := r.Get(context.Background(), key, report); err != nil {
+				t.Fatalf("failed to get MetricCollectorReport %s: %v", key, err)
+			}
+			want := &autoapprovev1alpha1.WorkloadTrackerReference{Kind: autoapprovev1alpha1.StagedWorkloadTrackerKind, Name: tt.wantTracker, Namespace: testNamespace}
+			if diff := cmp.Diff(want, report.Spec.WorkloadTrackerRef); diff != "" {
+				t.Errorf("WorkloadTrackerRef mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("no tracker", func(t *testing.T) {
+		r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1"))
+		r.DefaultWorkloadTracker = defaultTracker
+		key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() error = %v, want nil", err)
+		}
+		if reason := progressingReason(t, r.Client, key); reason != progressingReasonWorkloadTrackerNotFound {
+			t.Errorf("Progressing reason = %q, want %q", reason, progressingReasonWorkloadTrackerNotFound)
+		}
+	})
+}
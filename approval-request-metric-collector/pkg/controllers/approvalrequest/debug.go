@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DebugHandler returns an HTTP handler that dumps, as JSON, how the controller currently evaluates an
+// ApprovalRequest: which MetricCollectorReports exist per cluster, how old they are, and which tracked workloads
+// are healthy, unhealthy or missing. It is read-only and evaluates through the same code path as reconciliation.
+//
+// The ApprovalRequest is selected with the "name" query parameter, plus "namespace" for a namespaced
+// ApprovalRequest; without a namespace the ClusterApprovalRequest of that name is evaluated.
+func (r *Reconciler) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		name := req.URL.Query().Get("name")
+		if name == "" {
+			http.Error(w, "the name query parameter is required", http.StatusBadRequest)
+			return
+		}
+		key := types.NamespacedName{Namespace: req.URL.Query().Get("namespace"), Name: name}
+
+		evaluation, err := r.evaluateApprovalRequest(req.Context(), key)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.IsNotFound(err) {
+				status = http.StatusNotFound
+			}
+			klog.V(2).InfoS("Failed to evaluate ApprovalRequest for debug endpoint", "approvalRequest", key, "err", err)
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(evaluation); err != nil {
+			klog.ErrorS(err, "Failed to write debug response", "approvalRequest", key)
+		}
+	})
+}
+
+// evaluateApprovalRequest evaluates the workload health of the given ApprovalRequest without modifying it.
+func (r *Reconciler) evaluateApprovalRequest(ctx context.Context, key types.NamespacedName) (*workloadHealthEvaluation, error) {
+	approvalReqObj, err := r.getApprovalRequestObj(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		return nil, err
+	}
+
+	spec := approvalReqObj.GetApprovalRequestSpec()
+	stageStatus, initialized, err := r.getStageStatus(ctx, approvalReqObj, spec.TargetUpdateRun, spec.TargetStage)
+	if err != nil {
+		return nil, err
+	}
+	if !initialized {
+		return nil, fmt.Errorf("UpdateRun %s is not initialized yet", spec.TargetUpdateRun)
+	}
+	if stageStatus == nil {
+		return nil, fmt.Errorf("stage %s not found in UpdateRun %s", spec.TargetStage, spec.TargetUpdateRun)
+	}
+
+	clusterNames, updatingClusters := stageClusters(stageStatus)
+	return r.evaluateWorkloadHealth(ctx, approvalReqObj, clusterNames, updatingClusters, spec.TargetUpdateRun, spec.TargetStage, stageStatus.StartTime)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+// healthCheckCache holds the last workload health check of each ApprovalRequest, keyed by its namespace and name.
+// A nil cache caches nothing.
+type healthCheckCache struct {
+	mu      sync.Mutex
+	entries map[types.NamespacedName]healthCheckCacheEntry
+}
+
+// healthCheckCacheEntry records the inputs of the last workload health evaluation of an ApprovalRequest that
+// did not approve it.
+type healthCheckCacheEntry struct {
+	fingerprint string
+	evaluatedAt time.Time
+}
+
+// healthCheckFingerprint summarizes the inputs of the workload health evaluation of an ApprovalRequest: its
+// generation, the clusters of the stage, whether they are still updating and which of them are in maintenance, the
+// start time of the stage, and the resourceVersion of its WorkloadTracker and of every MetricCollectorReport of the
+// stage. Any report or WorkloadTracker event changes its resourceVersion, so that a changed fingerprint is how the
+// cache learns of their updates.
+func (r *Reconciler) healthCheckFingerprint(
+	ctx context.Context,
+	approvalReqObj placementv1beta1.ApprovalRequestObj,
+	clusterNames []string,
+	updatingClusters map[string]bool,
+	updateRunName, stageName string,
+	stageStartTime *metav1.Time,
+) (string, error) {
+	reportList := &autoapprovev1alpha1.MetricCollectorReportList{}
+	if err := r.Client.List(ctx, reportList,
+		client.MatchingFields{reportUpdateRunStageIndex: updateRunStageIndexValue(updateRunName, stageName)},
+		client.MatchingLabels{parentApprovalRequestLabel: parentApprovalRequestLabelValue(approvalReqObj.GetNamespace(), approvalReqObj.GetName())},
+	); err != nil {
+		return "", fmt.Errorf("failed to list MetricCollectorReports: %w", err)
+	}
+
+	trackerName, err := r.workloadTrackerName(ctx, approvalReqObj, updateRunName)
+	if err != nil {
+		return "", err
+	}
+	var tracker client.Object = &autoapprovev1alpha1.ClusterStagedWorkloadTracker{}
+	if approvalReqObj.GetNamespace() != "" {
+		tracker = &autoapprovev1alpha1.StagedWorkloadTracker{}
+	}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: trackerName, Namespace: approvalReqObj.GetNamespace()}, tracker); err != nil && !errors.IsNotFound(err) {
+		return "", fmt.Errorf("failed to get WorkloadTracker %s: %w", trackerName, err)
+	}
+	_, excludedClusters, err := r.excludeClustersInMaintenance(ctx, clusterNames)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "generation=%d;stage=%s/%s;", approvalReqObj.GetGeneration(), updateRunName, stageName)
+	// A tracker that is not found has an empty resourceVersion
+	fmt.Fprintf(&b, "tracker=%s,%s;", trackerName, tracker.GetResourceVersion())
+	if stageStartTime != nil {
+		fmt.Fprintf(&b, "start=%d;", stageStartTime.Unix())
+	}
+	clusters := append([]string(nil), clusterNames...)
+	sort.Strings(clusters)
+	for _, cluster := range clusters {
+		fmt.Fprintf(&b, "cluster=%s,%t;", cluster, updatingClusters[cluster])
+	}
+	excluded := append([]string(nil), excludedClusters...)
+	sort.Strings(excluded)
+	fmt.Fprintf(&b, "maintenance=%s;", strings.Join(excluded, ","))
+	reports := make([]string, 0, len(reportList.Items))
+	for i := range reportList.Items {
+		report := &reportList.Items[i]
+		reports = append(reports, fmt.Sprintf("report=%s/%s,%s;", report.Namespace, report.Name, report.ResourceVersion))
+	}
+	sort.Strings(reports)
+	for _, report := range reports {
+		b.WriteString(report)
+	}
+	return b.String(), nil
+}
+
+// newHealthCheckCache returns an empty healthCheckCache.
+func newHealthCheckCache() *healthCheckCache {
+	return &healthCheckCache{entries: make(map[types.NamespacedName]healthCheckCacheEntry)}
+}
+
+// cached reports whether the last workload health evaluation of the ApprovalRequest had the same fingerprint and
+// is younger than ttl, so that its decision still holds.
+func (c *healthCheckCache) cached(key types.NamespacedName, fingerprint string, ttl time.Duration) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return ok && entry.fingerprint == fingerprint && time.Since(entry.evaluatedAt) < ttl
+}
+
+// store records the fingerprint of a workload health evaluation of the ApprovalRequest.
+func (c *healthCheckCache) store(key types.NamespacedName, fingerprint string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = healthCheckCacheEntry{fingerprint: fingerprint, evaluatedAt: time.Now()}
+}
+
+// forget drops the cached workload health evaluation of the ApprovalRequest, if any.
+func (c *healthCheckCache) forget(key types.NamespacedName) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+	placementv1beta1 "github.com/kubefleet-dev/kubefleet/apis/placement/v1beta1"
+)
+
+func TestHealthCheckCache(t *testing.T) {
+	key := types.NamespacedName{Namespace: testNamespace, Name: testRequestName}
+	tests := []struct {
+		name string
+		// change changes an input of the health check after the first, unapproved, evaluation
+		change       func(t *testing.T, r *Reconciler)
+		wantApproved bool
+	}{
+		{
+			name: "nothing changed",
+			// Not an input of the fingerprint, so the cached decision is kept
+			change: func(_ *testing.T, r *Reconciler) { r.BlockDegradedWorkloads = false },
+		},
+		{
+			name: "WorkloadTracker changed",
+			change: func(t *testing.T, r *Reconciler) {
+				tracker := &autoapprovev1alpha1.StagedWorkloadTracker{}
+				if err := r.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testUpdateRun}, tracker); err != nil {
+					t.Fatalf("failed to get StagedWorkloadTracker: %v", err)
+				}
+				tracker.Workloads[0].Optional = true
+				if err := r.Update(context.Background(), tracker); err != nil {
+					t.Fatalf("failed to update StagedWorkloadTracker: %v", err)
+				}
+			},
+			wantApproved: true,
+		},
+		{
+			name: "cluster put in maintenance",
+			change: func(t *testing.T, r *Reconciler) {
+				namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:        "fleet-member-member-2",
+					Annotations: map[string]string{maintenanceAnnotation: "true"},
+				}}
+				if err := r.Create(context.Background(), namespace); err != nil {
+					t.Fatalf("failed to create Namespace: %v", err)
+				}
+			},
+			wantApproved: true,
+		},
+		{
+			name: "stage clusters changed",
+			change: func(t *testing.T, r *Reconciler) {
+				updateRun := &placementv1beta1.StagedUpdateRun{}
+				if err := r.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testUpdateRun}, updateRun); err != nil {
+					t.Fatalf("failed to get StagedUpdateRun: %v", err)
+				}
+				// Drop the degraded member-2 from the stage
+				updateRun.Status.StagesStatus[0].Clusters = updateRun.Status.StagesStatus[0].Clusters[:1]
+				if err := r.Update(context.Background(), updateRun); err != nil {
+					t.Fatalf("failed to update StagedUpdateRun: %v", err)
+				}
+			},
+			wantApproved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestApprovalRequest(), newTestUpdateRun("member-1", "member-2"), newTestWorkloadTracker(testWorkload))
+			r.HealthCheckCacheTTL = time.Hour
+			// member-2 has enough healthy pods, but is degraded, which blocks approval
+			r.BlockDegradedWorkloads = true
+			got := reconcileCollected(t, r, map[string][]autoapprovev1alpha1.WorkloadMetric{
+				"member-1": podMetrics(testWorkload, 2, 0),
+				"member-2": podMetrics(testWorkload, 2, 1),
+			})
+			if meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)) {
+				t.Fatalf("approved with a degraded workload blocking")
+			}
+			// The first evaluation records the blocking workload on the report of member-2, so only the next one is
+			// cached with the report as it stays
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+
+			tt.change(t, r)
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			if err := r.Get(context.Background(), key, got); err != nil {
+				t.Fatalf("failed to get ApprovalRequest: %v", err)
+			}
+			if approved := meta.IsStatusConditionTrue(got.Status.Conditions, string(placementv1beta1.ApprovalRequestConditionApproved)); approved != tt.wantApproved {
+				t.Errorf("approved = %v, want %v", approved, tt.wantApproved)
+			}
+		})
+	}
+}
+
+func TestHealthCheckFingerprint(t *testing.T) {
+	approvalReq := newTestApprovalRequest()
+	r := newTestReconciler(t, approvalReq, newTestWorkloadTracker(testWorkload))
+	fingerprint := func() string {
+		t.Helper()
+		got, err := r.healthCheckFingerprint(context.Background(), approvalReq, []string{"member-2", "member-1"}, map[string]bool{"member-1": true}, testUpdateRun, testStage, nil)
+		if err != nil {
+			t.Fatalf("healthCheckFingerprint() error = %v, want nil", err)
+		}
+		return got
+	}
+
+	first := fingerprint()
+	if again := fingerprint(); again != first {
+		t.Errorf("healthCheckFingerprint() = %q, then %q without changes", first, again)
+	}
+
+	tracker := &autoapprovev1alpha1.StagedWorkloadTracker{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testUpdateRun}, tracker); err != nil {
+		t.Fatalf("failed to get StagedWorkloadTracker: %v", err)
+	}
+	tracker.Workloads[0].HealthyReplicas = 1
+	if err := r.Update(context.Background(), tracker); err != nil {
+		t.Fatalf("failed to update StagedWorkloadTracker: %v", err)
+	}
+	trackerChanged := fingerprint()
+	if trackerChanged == first {
+		t.Errorf("healthCheckFingerprint() = %q unchanged after the WorkloadTracker changed", trackerChanged)
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "fleet-member-member-1",
+		Annotations: map[string]string{maintenanceAnnotation: "true"},
+	}}
+	if err := r.Create(context.Background(), namespace); err != nil {
+		t.Fatalf("failed to create Namespace: %v", err)
+	}
+	maintenance := fingerprint()
+	if maintenance == trackerChanged {
+		t.Errorf("healthCheckFingerprint() = %q unchanged after member-1 was put in maintenance", maintenance)
+	}
+
+	got, err := r.healthCheckFingerprint(context.Background(), approvalReq, []string{"member-1"}, map[string]bool{"member-1": true}, testUpdateRun, testStage, nil)
+	if err != nil {
+		t.Fatalf("healthCheckFingerprint() error = %v, want nil", err)
+	}
+	if got == maintenance {
+		t.Errorf("healthCheckFingerprint() = %q unchanged after member-2 left the stage", got)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+const (
+	// replayDecisionApproved means the ApprovalRequest would be approved.
+	replayDecisionApproved = "Approved"
+	// replayDecisionNotApproved means workload health was evaluated, but does not allow approval yet.
+	replayDecisionNotApproved = "NotApproved"
+	// replayDecisionNoWorkloads means the WorkloadTracker lists no workloads for the stage and EmptyTrackerBehavior
+	// is block, so nothing is done.
+	replayDecisionNoWorkloads = "NoWorkloads"
+)
+
+// replayResult is the outcome of a replay: the decision the controller would make, and the evaluation behind it.
+// The decision is the blocked reason, e.g. WaitingForReports, if workload health could not be evaluated.
+type replayResult struct {
+	Decision   string                    `json:"decision"`
+	Evaluation *workloadHealthEvaluation `json:"evaluation"`
+}
+
+// LoadSnapshot decodes the objects of a snapshot of the hub from YAML or JSON, as captured with kubectl get -o yaml.
+// Documents may be single objects or Lists of objects, separated by ---. Every kind must be registered in scheme.
+func LoadSnapshot(scheme *runtime.Scheme, r io.Reader) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var objs []client.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot object: %w", err)
+		}
+		list, ok := obj.(*corev1.List)
+		if !ok {
+			clientObj, ok := obj.(client.Object)
+			if !ok {
+				return nil, fmt.Errorf("snapshot object of type %T is not a Kubernetes object", obj)
+			}
+			objs = append(objs, clientObj)
+			continue
+		}
+		for _, item := range list.Items {
+			itemObj, _, err := decoder.Decode(item.Raw, nil, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode snapshot list item: %w", err)
+			}
+			clientObj, ok := itemObj.(client.Object)
+			if !ok {
+				return nil, fmt.Errorf("snapshot list item of type %T is not a Kubernetes object", itemObj)
+			}
+			objs = append(objs, clientObj)
+		}
+	}
+}
+
+// Replay evaluates the workload health of an ApprovalRequest against a snapshot of hub objects instead of the live
+// hub, to explain an approval decision after the fact, and writes the decision and the evaluation behind it as JSON
+// to w. It reports whether the ApprovalRequest would be approved. The snapshot must hold the ApprovalRequest, its
+// UpdateRun, its WorkloadTracker and the MetricCollectorReports of the stage. The evaluation runs through the same code path as reconciliation and the debug endpoint, with the
+// settings of r, and modifies nothing; grace periods and AllowMissingAfter are judged against the current time.
+func (r *Reconciler) Replay(ctx context.Context, scheme *runtime.Scheme, snapshot []client.Object, key types.NamespacedName, w io.Writer) (bool, error) {
+	snapshotClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(snapshot...).
+		WithIndex(&autoapprovev1alpha1.MetricCollectorReport{}, reportUpdateRunStageIndex, reportUpdateRunStageIndexValues).
+		Build()
+	replayer := *r
+	replayer.Client = snapshotClient
+	evaluation, err := replayer.evaluateApprovalRequest(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	result := replayResult{Decision: replayDecisionNotApproved, Evaluation: evaluation}
+	switch {
+	case evaluation.BlockedReason != "":
+		result.Decision = evaluation.BlockedReason
+	case evaluation.NoWorkloads && r.EmptyTrackerBehavior == EmptyTrackerBehaviorApprove:
+		result.Decision = replayDecisionApproved
+	case evaluation.NoWorkloads:
+		result.Decision = replayDecisionNoWorkloads
+	case evaluation.AllHealthy || evaluation.healthyClusterWeightMet() || evaluation.healthyPodPercentMet():
+		result.Decision = replayDecisionApproved
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return false, fmt.Errorf("failed to write replay result: %w", err)
+	}
+	return result.Decision == replayDecisionApproved, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// sampleSnapshot is the sample snapshot of the README, with one degraded cluster.
+const sampleSnapshot = "../../../examples/replay/snapshot.yaml"
+
+func TestLoadSnapshot(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "objects and lists",
+			snapshot: `apiVersion: autoapprove.kubernetes-fleet.io/v1alpha1
+kind: StagedWorkloadTracker
+metadata:
+  name: test-run
+  namespace: test-ns
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: autoapprove.kubernetes-fleet.io/v1alpha1
+  kind: MetricCollectorReport
+  metadata:
+    name: mc-test-run-canary
+    namespace: fleet-member-member-1
+- apiVersion: autoapprove.kubernetes-fleet.io/v1alpha1
+  kind: MetricCollectorReport
+  metadata:
+    name: mc-test-run-canary
+    namespace: fleet-member-member-2
+---
+`,
+			want: []string{
+				"StagedWorkloadTracker test-ns/test-run",
+				"MetricCollectorReport fleet-member-member-1/mc-test-run-canary",
+				"MetricCollectorReport fleet-member-member-2/mc-test-run-canary",
+			},
+		},
+		{
+			name: "unregistered kind",
+			snapshot: `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := LoadSnapshot(newTestScheme(t), strings.NewReader(tt.snapshot))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, obj := range objs {
+				got = append(got, obj.GetObjectKind().GroupVersionKind().Kind+" "+client.ObjectKeyFromObject(obj).String())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadSnapshot() objects mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplaySampleSnapshot(t *testing.T) {
+	tests := []struct {
+		name          string
+		blockDegraded bool
+		wantApproved  bool
+		wantDecision  string
+	}{
+		{name: "degraded workloads allowed", wantApproved: true, wantDecision: replayDecisionApproved},
+		{name: "degraded workloads blocking", blockDegraded: true, wantDecision: replayDecisionNotApproved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := os.Open(sampleSnapshot)
+			if err != nil {
+				t.Fatalf("failed to open the sample snapshot: %v", err)
+			}
+			defer file.Close()
+			scheme := newTestScheme(t)
+			snapshot, err := LoadSnapshot(scheme, file)
+			if err != nil {
+				t.Fatalf("LoadSnapshot() error = %v", err)
+			}
+			r := &Reconciler{BlockDegradedWorkloads: tt.blockDegraded}
+
+			var out bytes.Buffer
+			approved, err := r.Replay(context.Background(), scheme, snapshot, types.NamespacedName{Name: "example-cluster-staged-run-staging"}, &out)
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			if approved != tt.wantApproved {
+				t.Errorf("Replay() approved = %v, want %v", approved, tt.wantApproved)
+			}
+			var result replayResult
+			if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+				t.Fatalf("failed to decode replay result %q: %v", out.String(), err)
+			}
+			if result.Decision != tt.wantDecision {
+				t.Errorf("replay decision = %q, want %q", result.Decision, tt.wantDecision)
+			}
+			if result.Evaluation == nil || len(result.Evaluation.Clusters) != 2 {
+				t.Errorf("replay evaluation = %+v, want the evaluation of 2 clusters", result.Evaluation)
+			}
+		})
+	}
+}
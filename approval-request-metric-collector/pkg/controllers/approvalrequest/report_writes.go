@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// reportWriteBackoff is the backoff between retries of a MetricCollectorReport write that failed with a
+// transient error, see isTransientWriteError.
+var reportWriteBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 500 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// writeReport performs a MetricCollectorReport write (create, update, status patch or delete).
+// Every attempt first waits for ReportWriteLimiter, if set, so that write bursts, e.g. when many ApprovalRequests
+// reconcile after a restart, are shaped before they reach the hub. Writes that fail with a transient error are
+// retried with exponential backoff until ctx is done; the last write error is returned once the retries run out.
+func (r *Reconciler) writeReport(ctx context.Context, write func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, reportWriteBackoff, func(ctx context.Context) (bool, error) {
+		if r.ReportWriteLimiter != nil {
+			if err := r.ReportWriteLimiter.Wait(ctx); err != nil {
+				return false, err
+			}
+		}
+		lastErr = write()
+		if lastErr == nil {
+			return true, nil
+		}
+		if !isTransientWriteError(lastErr) {
+			return false, lastErr
+		}
+		klog.V(2).InfoS("MetricCollectorReport write failed with a transient error, backing off", "err", lastErr)
+		return false, nil
+	})
+	if ctx.Err() == nil && wait.Interrupted(err) && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+// isTransientWriteError returns whether a failed MetricCollectorReport write may succeed if repeated as is: the hub
+// throttling, timing out or being unavailable. Conflicts are not retried here since the write carries the
+// resourceVersion it was built from; they are returned so that the next reconcile re-reads the report.
+func isTransientWriteError(err error) bool {
+	return errors.IsTooManyRequests(err) || errors.IsServerTimeout(err) || errors.IsTimeout(err) ||
+		errors.IsServiceUnavailable(err)
+}
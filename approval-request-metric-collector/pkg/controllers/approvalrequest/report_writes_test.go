@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvalrequest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestWriteReport(t *testing.T) {
+	original := reportWriteBackoff
+	reportWriteBackoff = wait.Backoff{Steps: 3, Duration: time.Millisecond, Factor: 1.0}
+	t.Cleanup(func() { reportWriteBackoff = original })
+
+	resource := schema.GroupResource{Group: "autoapprove.kubernetes-fleet.io", Resource: "metriccollectorreports"}
+	throttled := apierrors.NewTooManyRequests("slow down", 1)
+	unavailable := apierrors.NewServiceUnavailable("unavailable")
+	conflict := apierrors.NewConflict(resource, "mc-test-run-canary", errors.New("object has been modified"))
+	invalid := apierrors.NewBadRequest("invalid report")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{
+			name:         "succeeds first time",
+			errs:         []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "retries transient errors",
+			errs:         []error{throttled, unavailable, nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "returns the last transient error once retries run out",
+			errs:         []error{throttled, throttled, unavailable},
+			wantErr:      unavailable,
+			wantAttempts: 3,
+		},
+		{
+			name:         "does not retry conflicts",
+			errs:         []error{conflict},
+			wantErr:      conflict,
+			wantAttempts: 1,
+		},
+		{
+			name:         "does not retry other errors",
+			errs:         []error{invalid},
+			wantErr:      invalid,
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{}
+			attempts := 0
+			err := r.writeReport(context.Background(), func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("writeReport() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("writeReport() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestWriteReportStopsWhenContextDone(t *testing.T) {
+	original := reportWriteBackoff
+	reportWriteBackoff = wait.Backoff{Steps: 5, Duration: time.Hour, Factor: 1.0}
+	t.Cleanup(func() { reportWriteBackoff = original })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Reconciler{}
+	attempts := 0
+	err := r.writeReport(ctx, func() error {
+		attempts++
+		cancel()
+		return apierrors.NewTooManyRequests("slow down", 1)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("writeReport() error = %v, want %v", err, context.Canceled)
+	}
+	if attempts != 1 {
+		t.Errorf("writeReport() attempts = %d, want 1", attempts)
+	}
+}
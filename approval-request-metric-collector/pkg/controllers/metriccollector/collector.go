@@ -17,19 +17,43 @@ limitations under the License.
 package metriccollector
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/tracing"
 )
 
+// defaultPostQueryThreshold is the encoded query length in bytes above which queries are sent with POST.
+// It keeps typical queries on GET while staying well below common proxy and server URL length limits.
+const defaultPostQueryThreshold = 2048
+
+// unixSocketBaseURL is the base URL of requests sent over a Unix domain socket. Its host is only used for the
+// Host header, since the socket is dialed whatever the address of the request.
+const unixSocketBaseURL = "http://localhost"
+
+// Version is the metric collector version reported in the default Prometheus User-Agent.
+// It is set at build time with -ldflags "-X <package path>.Version=<version>".
+var Version = "dev"
+
+// defaultUserAgent identifies the metric collector in Prometheus access logs.
+func defaultUserAgent() string {
+	return fmt.Sprintf("kubefleet-metric-collector/%s", Version)
+}
+
 // PrometheusClient is the interface for querying Prometheus
 type PrometheusClient interface {
 	Query(ctx context.Context, query string) (PrometheusData, error)
@@ -41,39 +65,175 @@ type prometheusClient struct {
 	authType   string
 	authSecret *corev1.Secret
 	httpClient *http.Client
+	// requestBaseURL is the base URL requests are sent to: baseURL, or unixSocketBaseURL for a unix:// baseURL.
+	requestBaseURL string
+	// postQueryThreshold is the encoded query length above which POST is used instead of GET.
+	// Zero means every query is sent with POST.
+	postQueryThreshold int
+	// userAgent is the User-Agent header sent with every query.
+	userAgent string
+	// tracer records a span around every query.
+	tracer trace.Tracer
+	// queryTimeout, if positive, is sent as the timeout parameter of every query.
+	queryTimeout time.Duration
+}
+
+// PrometheusClientOption configures optional settings of the Prometheus client.
+type PrometheusClientOption func(*prometheusClient, *http.Transport)
+
+// WithProxyURL routes Prometheus requests through the given proxy instead of the one
+// configured by the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables.
+func WithProxyURL(proxyURL *url.URL) PrometheusClientOption {
+	return func(_ *prometheusClient, transport *http.Transport) {
+		if proxyURL != nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+}
+
+// WithPostQueryThreshold sends queries whose form-encoded length exceeds threshold bytes as a POST
+// with a form body instead of a GET with query parameters, avoiding 414 URI Too Long responses.
+// A threshold of 0 sends every query with POST; a negative threshold keeps the default.
+func WithPostQueryThreshold(threshold int) PrometheusClientOption {
+	return func(c *prometheusClient, _ *http.Transport) {
+		if threshold >= 0 {
+			c.postQueryThreshold = threshold
+		}
+	}
+}
+
+// WithUserAgent sends userAgent as the User-Agent header of every query instead of
+// kubefleet-metric-collector/<version>. An empty userAgent keeps the default.
+func WithUserAgent(userAgent string) PrometheusClientOption {
+	return func(c *prometheusClient, _ *http.Transport) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
 }
 
-// NewPrometheusClient creates a new Prometheus client
-func NewPrometheusClient(baseURL, authType string, authSecret *corev1.Secret) PrometheusClient {
-	return &prometheusClient{
-		baseURL:    baseURL,
-		authType:   authType,
-		authSecret: authSecret,
+// WithTracer records a span around every query with tracer. A nil tracer keeps queries untraced.
+func WithTracer(tracer trace.Tracer) PrometheusClientOption {
+	return func(c *prometheusClient, _ *http.Transport) {
+		if tracer != nil {
+			c.tracer = tracer
+		}
+	}
+}
+
+// WithQueryTimeout sends timeout as the timeout parameter of every query, so that Prometheus aborts
+// evaluation server-side once it is exceeded. This is separate from the timeout of the HTTP client;
+// a zero or negative timeout leaves the parameter out and Prometheus applies its --query.timeout.
+func WithQueryTimeout(timeout time.Duration) PrometheusClientOption {
+	return func(c *prometheusClient, _ *http.Transport) {
+		if timeout > 0 {
+			c.queryTimeout = timeout
+		}
+	}
+}
+
+// NewPrometheusClient creates a new Prometheus client.
+// By default requests honor the proxy environment variables; options may override the transport settings.
+// A unix:// baseURL, e.g. unix:///var/run/prometheus/prometheus.sock, sends the requests over HTTP on that Unix
+// domain socket, for Prometheus sidecars that do not listen on TCP; proxies do not apply to it.
+func NewPrometheusClient(baseURL, authType string, authSecret *corev1.Secret, opts ...PrometheusClientOption) PrometheusClient {
+	// Start from a clone of the default transport so that customizations keep http.ProxyFromEnvironment
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	c := &prometheusClient{
+		baseURL:        baseURL,
+		requestBaseURL: baseURL,
+		authType:       authType,
+		authSecret:     authSecret,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: transport,
 		},
+		postQueryThreshold: defaultPostQueryThreshold,
+		userAgent:          defaultUserAgent(),
+		tracer:             tracing.OrNoop(nil),
 	}
+	for _, opt := range opts {
+		opt(c, transport)
+	}
+	if socketPath, ok := unixSocketPath(baseURL); ok {
+		c.requestBaseURL = unixSocketBaseURL
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+	}
+	return c
+}
+
+// unixSocketPath returns the socket path of a unix:// Prometheus URL, or false if the URL does not use the unix
+// scheme.
+func unixSocketPath(prometheusURL string) (string, bool) {
+	u, err := url.Parse(prometheusURL)
+	if err != nil || u.Scheme != "unix" {
+		return "", false
+	}
+	return u.Path, true
 }
 
 // Query executes a PromQL query against Prometheus API
 func (c *prometheusClient) Query(ctx context.Context, query string) (PrometheusData, error) {
+	ctx, span := c.tracer.Start(ctx, "Prometheus.Query", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("prometheus.url", c.baseURL),
+		attribute.String("prometheus.query", query),
+	))
+	defer span.End()
+
+	data, err := c.query(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return PrometheusData{}, err
+	}
+	span.SetAttributes(attribute.Int("prometheus.series", len(data.Result)))
+	return data, nil
+}
+
+// query sends a PromQL query to the Prometheus API and decodes the response.
+func (c *prometheusClient) query(ctx context.Context, query string) (PrometheusData, error) {
 	// Build query URL
-	queryURL := fmt.Sprintf("%s/api/v1/query", strings.TrimSuffix(c.baseURL, "/"))
+	queryURL := fmt.Sprintf("%s/api/v1/query", strings.TrimSuffix(c.requestBaseURL, "/"))
 	params := url.Values{}
 	params.Add("query", query)
-	fullURL := fmt.Sprintf("%s?%s", queryURL, params.Encode())
+	if c.queryTimeout > 0 {
+		params.Add("timeout", strconv.FormatFloat(c.queryTimeout.Seconds(), 'f', -1, 64))
+	}
+	encodedParams := params.Encode()
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	// Create request; long queries go in a form-encoded POST body, which Prometheus also accepts,
+	// while short ones stay on GET so that they remain cache-friendly
+	var req *http.Request
+	var err error
+	if len(encodedParams) > c.postQueryThreshold {
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, queryURL, strings.NewReader(encodedParams))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", queryURL, encodedParams), nil)
+	}
 	if err != nil {
 		return PrometheusData{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Identify the collector in Prometheus access logs
+	req.Header.Set("User-Agent", c.userAgent)
+
 	// Add authentication
 	if err := c.addAuth(req); err != nil {
 		return PrometheusData{}, fmt.Errorf("failed to add authentication: %w", err)
 	}
 
+	// Ask for a compressed response explicitly; since the header is set by us, the transport
+	// no longer decompresses transparently and the body is decompressed below
+	req.Header.Set("Accept-Encoding", "gzip")
+
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -81,14 +241,20 @@ func (c *prometheusClient) Query(ctx context.Context, query string) (PrometheusD
 	}
 	defer resp.Body.Close()
 
+	body, err := responseBody(resp)
+	if err != nil {
+		return PrometheusData{}, err
+	}
+	defer body.Close()
+
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return PrometheusData{}, fmt.Errorf("Prometheus query failed with status %d: %s", resp.StatusCode, string(body))
+		errBody, _ := io.ReadAll(body)
+		return PrometheusData{}, fmt.Errorf("Prometheus query failed with status %d: %s", resp.StatusCode, string(errBody))
 	}
 
 	// Parse response
 	var result PrometheusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
 		return PrometheusData{}, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -99,33 +265,58 @@ func (c *prometheusClient) Query(ctx context.Context, query string) (PrometheusD
 	return result.Data, nil
 }
 
+// responseBody returns a reader for the response body, decompressing it if the server gzip-encoded it.
+func responseBody(resp *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return io.NopCloser(resp.Body), nil
+	}
+	gzipReader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	return gzipReader, nil
+}
+
 // addAuth adds authentication to the request
 func (c *prometheusClient) addAuth(req *http.Request) error {
-	if c.authType == "" || c.authSecret == nil {
-		return nil
+	authorization, err := authorizationHeader(c.authType, c.authSecret)
+	if err != nil {
+		return err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return nil
+}
+
+// authorizationHeader returns the Authorization header value for the auth type and secret,
+// or an empty string if no authentication is configured.
+func authorizationHeader(authType string, authSecret *corev1.Secret) (string, error) {
+	if authType == "" || authSecret == nil {
+		return "", nil
 	}
 
-	switch c.authType {
-	case "bearer":
-		token, ok := c.authSecret.Data["token"]
+	switch authType {
+	case prometheusAuthTypeBearer:
+		token, ok := authSecret.Data["token"]
 		if !ok {
-			return fmt.Errorf("token not found in secret")
+			return "", fmt.Errorf("token not found in secret")
 		}
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", string(token)))
-	case "basic":
-		username, ok := c.authSecret.Data["username"]
+		return fmt.Sprintf("Bearer %s", string(token)), nil
+	case prometheusAuthTypeBasic:
+		username, ok := authSecret.Data["username"]
 		if !ok {
-			return fmt.Errorf("username not found in secret")
+			return "", fmt.Errorf("username not found in secret")
 		}
-		password, ok := c.authSecret.Data["password"]
+		password, ok := authSecret.Data["password"]
 		if !ok {
-			return fmt.Errorf("password not found in secret")
+			return "", fmt.Errorf("password not found in secret")
 		}
 		auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password)))
-		req.Header.Set("Authorization", fmt.Sprintf("Basic %s", auth))
+		return fmt.Sprintf("Basic %s", auth), nil
 	}
 
-	return nil
+	return "", nil
 }
 
 // PrometheusResponse represents the Prometheus API response
@@ -144,5 +335,48 @@ type PrometheusData struct {
 // PrometheusResult represents a single result from Prometheus
 type PrometheusResult struct {
 	Metric map[string]string `json:"metric"`
-	Value  []interface{}     `json:"value"` // [timestamp, value]
+	Value  []interface{}     `json:"value,omitempty"`  // [timestamp, value] for instant vectors
+	Values [][]interface{}   `json:"values,omitempty"` // [[timestamp, value], ...] for range matrices
+}
+
+// latestSample returns the value string of the most recent sample in the result.
+// Instant vectors carry a single [timestamp, value] pair in Value, while range matrices
+// carry a list of pairs in Values; for the latter the sample with the newest timestamp is used.
+func (r PrometheusResult) latestSample() (string, error) {
+	if len(r.Values) == 0 {
+		return sampleValue(r.Value)
+	}
+
+	latest := -1
+	var latestTimestamp float64
+	for i, sample := range r.Values {
+		if len(sample) < 2 {
+			continue
+		}
+		timestamp, ok := sample[0].(float64)
+		if !ok {
+			continue
+		}
+		if latest == -1 || timestamp >= latestTimestamp {
+			latest = i
+			latestTimestamp = timestamp
+		}
+	}
+	if latest == -1 {
+		return "", fmt.Errorf("no valid samples in range result with %d entries", len(r.Values))
+	}
+	return sampleValue(r.Values[latest])
+}
+
+// sampleValue returns the value string of a single [timestamp, value] sample.
+func sampleValue(sample []interface{}) (string, error) {
+	// We need at least 2 elements: index 0 is timestamp, index 1 is the metric value
+	if len(sample) < 2 {
+		return "", fmt.Errorf("sample has insufficient elements: %d", len(sample))
+	}
+	valueStr, ok := sample[1].(string)
+	if !ok {
+		return "", fmt.Errorf("sample value is not a string: %v", sample[1])
+	}
+	return valueStr, nil
 }
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTimeoutRecordingPrometheus returns a Prometheus HTTP API answering every query with an empty vector, and a
+// func returning the timeout parameters of the queries it received.
+func newTimeoutRecordingPrometheus(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var timeouts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		if req.Form.Has("timeout") {
+			timeouts = append(timeouts, req.Form.Get("timeout"))
+		}
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(PrometheusResponse{Status: "success", Data: PrometheusData{ResultType: prometheusResultTypeVector}})
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), timeouts...)
+	}
+}
+
+func TestWithQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    []string
+	}{
+		{name: "seconds", timeout: 5 * time.Second, want: []string{"5"}},
+		{name: "fractional seconds", timeout: 1500 * time.Millisecond, want: []string{"1.5"}},
+		{name: "not set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, timeouts := newTimeoutRecordingPrometheus(t)
+			promClient := NewPrometheusClient(server.URL, "", nil, WithQueryTimeout(tt.timeout))
+			if _, err := promClient.Query(context.Background(), "workload_health"); err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if got := timeouts(); !slices.Equal(got, tt.want) {
+				t.Errorf("timeout parameters = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileSendsQueryTimeout(t *testing.T) {
+	server, timeouts := newTimeoutRecordingPrometheus(t)
+	report := newTestReport(server.URL)
+	report.Spec.QueryTimeout = &metav1.Duration{Duration: 10 * time.Second}
+	r := newTestReconciler(t, report)
+
+	reconcileReport(t, r)
+	got := timeouts()
+	if len(got) == 0 {
+		t.Fatalf("no query carried a timeout parameter")
+	}
+	for _, timeout := range got {
+		if timeout != "10" {
+			t.Errorf("timeout parameter = %q, want %q", timeout, "10")
+		}
+	}
+}
+
+func TestPrometheusClientUnixSocket(t *testing.T) {
+	// Unix socket paths are limited to about 100 bytes, which the test's temp dir may exceed
+	dir, err := os.MkdirTemp("", "prom")
+	if err != nil {
+		t.Fatalf("failed to create a socket dir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "prometheus.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", socketPath, err)
+	}
+	var mu sync.Mutex
+	var methods []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/query" {
+			http.NotFound(w, req)
+			return
+		}
+		mu.Lock()
+		methods = append(methods, req.Method)
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(PrometheusResponse{Status: "success", Data: PrometheusData{ResultType: prometheusResultTypeVector}})
+	}))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name       string
+		opts       []PrometheusClientOption
+		wantMethod string
+	}{
+		{name: "GET", wantMethod: http.MethodGet},
+		{name: "POST", opts: []PrometheusClientOption{WithPostQueryThreshold(0)}, wantMethod: http.MethodPost},
+		// A proxy cannot reach the socket, so it is not used
+		{name: "proxy", opts: []PrometheusClientOption{WithProxyURL(&url.URL{Scheme: "http", Host: "127.0.0.1:1"})}, wantMethod: http.MethodGet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			methods = nil
+			mu.Unlock()
+			promClient := NewPrometheusClient("unix://"+socketPath, "", nil, tt.opts...)
+			if _, err := promClient.Query(context.Background(), "workload_health"); err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if !slices.Equal(methods, []string{tt.wantMethod}) {
+				t.Errorf("request methods = %q, want %q", methods, []string{tt.wantMethod})
+			}
+		})
+	}
+}
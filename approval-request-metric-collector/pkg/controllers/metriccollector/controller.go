@@ -19,29 +19,169 @@ package metriccollector
 import (
 	"context"
 	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/tracing"
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/utils"
 )
 
 const (
 	// defaultCollectionInterval is the interval for collecting metrics (30 seconds)
 	defaultCollectionInterval = 30 * time.Second
+
+	// defaultReadyFailureThreshold is the number of consecutive failed collections after which the Ready
+	// condition of a report turns False, unless ReadyFailureThreshold is set
+	defaultReadyFailureThreshold = 3
+
+	// workloadHealthMetric is the name of the metric emitted by workloads to report their health
+	workloadHealthMetric = "workload_health"
+
+	// defaultPodStartTimeMetric is the metric holding the Unix start time of each pod's process, exported by the
+	// Prometheus client libraries, that MinPodUptime is checked against unless PodStartTimeMetric is set
+	defaultPodStartTimeMetric = "process_start_time_seconds"
+
+	// metricNameLabel is the label holding the metric name of a series returned by Prometheus
+	metricNameLabel = "__name__"
+
+	// prometheusResultTypeVector is the Prometheus result type of an instant vector
+	prometheusResultTypeVector = "vector"
+
+	// statusUpdateRetryInterval is how soon a reconcile is retried after a transient status update failure
+	statusUpdateRetryInterval = 100 * time.Millisecond
+
+	// fieldManager is the server-side apply field manager the metric collector writes report status with
+	fieldManager = "metric-collector"
 )
 
+// desiredReplicasMetric identifies the kube-state-metrics metric reporting the desired replica count
+// of a workload kind, and the label holding the workload name.
+type desiredReplicasMetric struct {
+	metric    string
+	nameLabel string
+}
+
+// desiredReplicasMetrics maps workload kinds to their kube-state-metrics desired replica metric.
+var desiredReplicasMetrics = map[string]desiredReplicasMetric{
+	"Deployment":  {metric: "kube_deployment_spec_replicas", nameLabel: "deployment"},
+	"StatefulSet": {metric: "kube_statefulset_replicas", nameLabel: "statefulset"},
+	"DaemonSet":   {metric: "kube_daemonset_status_desired_number_scheduled", nameLabel: "daemonset"},
+}
+
 // Reconciler reconciles a MetricCollectorReport object on the hub cluster
 type Reconciler struct {
 	// HubClient is the client to access the hub cluster (for MetricCollectorReport and WorkloadTracker)
 	HubClient client.Client
+
+	// RequeueJitterFraction spreads requeues by a random ±fraction of the collection interval
+	// so that reports do not reconcile in lockstep after a restart. Zero disables jitter.
+	RequeueJitterFraction float64
+
+	// PrometheusProxyURL is the proxy used to reach Prometheus. If nil, the proxy environment variables apply.
+	PrometheusProxyURL *url.URL
+
+	// PrometheusPostQueryThreshold is the encoded query length in bytes above which Prometheus queries are sent
+	// with POST instead of GET. Zero sends every query with POST; a negative value keeps the client default.
+	PrometheusPostQueryThreshold int
+
+	// PrometheusUserAgent is the User-Agent header sent with Prometheus queries.
+	// If empty, kubefleet-metric-collector/<version> is used.
+	PrometheusUserAgent string
+
+	// MemberClient reads workloads on the member cluster from an informer cache scoped to the configured
+	// namespaces and workload kinds, so that FallbackToKubeStatus looks up the tracked workloads without hitting
+	// the member API server. Workloads outside the cache are read with KubeStatusReader. It is nil if the member
+	// cache is disabled.
+	MemberClient client.Reader
+
+	// Tracer, if set, records spans around reconciliation and Prometheus queries.
+	Tracer trace.Tracer
+
+	// FallbackToKubeStatus derives the health of the pods of the tracked workloads from their Ready condition on the
+	// member cluster, read with KubeStatusReader, when Prometheus cannot be queried. The metrics collected this way
+	// have the KubeStatus source, and the MetricsCollected condition the KubeStatusFallback reason. Reports
+	// without a WorkloadTracker still fail collection, since there are no workloads to look up.
+	FallbackToKubeStatus bool
+
+	// KubeStatusReader reads pods, and workloads not held by MemberClient, on the member cluster for
+	// FallbackToKubeStatus. It reads from the API server directly, since it is only used while Prometheus is down.
+	KubeStatusReader client.Reader
+
+	// ScrapeEndpointSelector, if set, replaces Prometheus as the source of the health metrics: the pods behind the
+	// EndpointSlices matching it on the member cluster are scraped directly. The PrometheusURL of reports is then
+	// not queried, and the settings that need PromQL, namely QueryTemplate, HealthExpression, the HealthQuery and
+	// SLOQuery of tracked workloads, RequireMetricExists and the desired replica counts, have no effect.
+	ScrapeEndpointSelector labels.Selector
+
+	// EndpointReader reads EndpointSlices and pods on the member cluster for ScrapeEndpointSelector.
+	EndpointReader client.Reader
+
+	// grpcConns caches the gRPC connections to Prometheus URLs queried with the grpc protocol, keyed by URL,
+	// so that connections are reused across reconciles instead of being dialed for every query.
+	// grpcURLsByReport records the URLs each report queries with the grpc protocol, so that a connection is
+	// closed once no report queries its URL anymore.
+	grpcConnsMu      sync.Mutex
+	grpcConns        map[string]*grpc.ClientConn
+	grpcURLsByReport map[types.NamespacedName][]string
+
+	// ReadyFailureThreshold is the number of consecutive failed collections after which the Ready condition of a
+	// report turns False. Zero or less uses defaultReadyFailureThreshold.
+	ReadyFailureThreshold int
+
+	// RecentCollectionsLimit is the number of collection summaries kept in the RecentCollections of a report.
+	// Zero disables the history.
+	RecentCollectionsLimit int
+
+	// MaxCollectedMetrics caps the number of CollectedMetrics written to a report, to keep it well below the
+	// etcd object size limit. Metrics of unhealthy pods are kept first. Zero disables the cap.
+	MaxCollectedMetrics int
+
+	// PrometheusAuthConfigMap, if set, is the hub ConfigMap that maps each report namespace (fleet-member-<cluster>)
+	// to the name of the Secret in that namespace holding the Prometheus credentials of the cluster.
+	PrometheusAuthConfigMap types.NamespacedName
+
+	// AllowCrossNamespaceAuthSecrets allows the PrometheusAuthConfigMap to map a report namespace to a Secret in
+	// another namespace, given as <namespace>/<name>. Such references are rejected by default, so that the
+	// credentials of a cluster stay scoped to its own fleet-member-<cluster> namespace.
+	AllowCrossNamespaceAuthSecrets bool
+
+	// MaxConcurrentReconciles is the number of MetricCollectorReports collected concurrently.
+	// If zero, reports are collected one at a time.
+	MaxConcurrentReconciles int
+
+	// MaxConcurrentQueries bounds the Prometheus queries in flight across all reconciles, independently of
+	// MaxConcurrentReconciles, so that a burst of collections such as after a restart does not overwhelm the
+	// member Prometheus. Queries beyond the limit wait for a free slot. Zero disables the limit.
+	MaxConcurrentQueries int
+
+	// querySlots is the semaphore shared by all Prometheus clients when MaxConcurrentQueries is set.
+	querySlotsOnce sync.Once
+	querySlots     chan struct{}
 }
 
 // Reconcile watches MetricCollectorReport on hub and updates it with metrics from member Prometheus
@@ -52,82 +192,845 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		latency := time.Since(startTime).Milliseconds()
 		klog.V(2).InfoS("MetricCollectorReport reconciliation ends", "report", req.NamespacedName, "latency", latency)
 	}()
+	ctx, span := tracing.OrNoop(r.Tracer).Start(ctx, "MetricCollectorReport.Reconcile", trace.WithAttributes(
+		attribute.String("report.namespace", req.Namespace),
+		attribute.String("report.name", req.Name),
+	))
+	defer span.End()
 
 	// 1. Get MetricCollectorReport from hub cluster
 	report := &autoapprovev1alpha1.MetricCollectorReport{}
 	if err := r.HubClient.Get(ctx, req.NamespacedName, report); err != nil {
 		if errors.IsNotFound(err) {
 			klog.V(2).InfoS("MetricCollectorReport not found, ignoring", "report", req.NamespacedName)
+			reportLastCollectionAgeSeconds.forget(req.NamespacedName)
+			r.setGRPCURLs(req.NamespacedName, nil)
 			return ctrl.Result{}, nil
 		}
 		klog.ErrorS(err, "Failed to get MetricCollectorReport", "report", req.NamespacedName)
 		return ctrl.Result{}, err
 	}
+	if report.Status.LastCollectionTime != nil {
+		reportLastCollectionAgeSeconds.observe(req.NamespacedName, report.Status.LastCollectionTime.Time)
+	}
+
+	// Spec changes are reconciled right away rather than after the pending requeue. Drop the metrics collected
+	// for the previous spec first, since they may come from a Prometheus URL or query that no longer applies
+	// and must not be used for approval if the collection below fails or is retried.
+	if clearStaleCollection(report) {
+		klog.V(2).InfoS("Spec changed since the last collection, clearing collected metrics", "report", req.NamespacedName, "generation", report.Generation)
+		if err := r.applyStatus(ctx, report); err != nil {
+			return statusUpdateFailed(err, req.NamespacedName, "Failed to clear stale MetricCollectorReport status")
+		}
+	}
 
 	klog.InfoS("Reconciling MetricCollectorReport", "name", report.Name, "namespace", report.Namespace)
 
-	// 2. Get PrometheusURL from report spec (or use default)
-	prometheusURL := report.Spec.PrometheusURL
+	// 2. Get PrometheusURL and any replica URLs from report spec
+	prometheusURLs := append([]string{report.Spec.PrometheusURL}, report.Spec.ReplicaPrometheusURLs...)
+	if err := validatePrometheusURLs(prometheusURLs, report.Spec.Protocol); err != nil {
+		klog.ErrorS(err, "Invalid PrometheusURL in MetricCollectorReport spec", "report", req.NamespacedName, "prometheusUrls", prometheusURLs)
+		r.setGRPCURLs(req.NamespacedName, nil)
+		// Drop previously collected metrics so that stale data is not used for approval
+		resetCollectedStatus(report)
+		meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
+			Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: report.Generation,
+			Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonInvalidPrometheusURL,
+			Message:            fmt.Sprintf("Invalid PrometheusURL: %v", err),
+		})
+		if err := r.applyStatus(ctx, report); err != nil {
+			return statusUpdateFailed(err, req.NamespacedName, "Failed to update MetricCollectorReport status")
+		}
+		// Fixing the URL changes the spec, which triggers a new reconciliation
+		return ctrl.Result{}, nil
+	}
+	report.Status.LastQueriedURL = report.Spec.PrometheusURL
+	if r.ScrapeEndpointSelector != nil {
+		report.Status.LastQueriedURL = ""
+	}
+	// Close the connections to the URLs the report queried before its spec changed
+	if report.Spec.Protocol == autoapprovev1alpha1.PrometheusProtocolGRPC && r.ScrapeEndpointSelector == nil {
+		r.setGRPCURLs(req.NamespacedName, prometheusURLs)
+	} else {
+		r.setGRPCURLs(req.NamespacedName, nil)
+	}
 
 	// 3. Query Prometheus on member cluster for all workload_health metrics
-	promClient := NewPrometheusClient(prometheusURL, "", nil)
-	collectedMetrics, collectErr := r.collectAllWorkloadMetrics(ctx, promClient)
+	// Scope the query to the workloads listed in the referenced WorkloadTracker, if any
+	workloads, err := r.getTrackedWorkloads(ctx, report.Spec.WorkloadTrackerRef, report.Labels[stageLabel])
+	if err != nil {
+		klog.ErrorS(err, "Failed to get tracked workloads", "report", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	// Workloads with their own HealthQuery are queried separately from the fleet-wide query
+	defaultWorkloads, healthQueryWorkloads := splitHealthQueryWorkloads(workloads)
+	query := buildPromQLQuery(defaultWorkloads, report.Spec.HealthMetricNames)
+	if len(defaultWorkloads) == 0 && len(healthQueryWorkloads) > 0 {
+		query = ""
+	}
+	if report.Spec.QueryTemplate != "" {
+		query, err = renderQueryTemplate(report.Spec.QueryTemplate, report.Labels)
+		if err != nil {
+			klog.ErrorS(err, "Invalid QueryTemplate in MetricCollectorReport spec", "report", req.NamespacedName)
+			// Drop previously collected metrics so that stale data is not used for approval
+			resetCollectedStatus(report)
+			meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
+				Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: report.Generation,
+				Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonInvalidQueryTemplate,
+				Message:            fmt.Sprintf("Invalid QueryTemplate: %v", err),
+			})
+			if err := r.applyStatus(ctx, report); err != nil {
+				return statusUpdateFailed(err, req.NamespacedName, "Failed to update MetricCollectorReport status")
+			}
+			// Fixing the template changes the spec, which triggers a new reconciliation
+			return ctrl.Result{}, nil
+		}
+	}
+	if report.Spec.HealthExpression != "" {
+		if report.Spec.QueryTemplate != "" {
+			klog.ErrorS(nil, "HealthExpression and QueryTemplate are both set in MetricCollectorReport spec", "report", req.NamespacedName)
+			// Drop previously collected metrics so that stale data is not used for approval
+			resetCollectedStatus(report)
+			meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
+				Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: report.Generation,
+				Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonInvalidHealthExpression,
+				Message:            "HealthExpression cannot be combined with QueryTemplate",
+			})
+			if err := r.applyStatus(ctx, report); err != nil {
+				return statusUpdateFailed(err, req.NamespacedName, "Failed to update MetricCollectorReport status")
+			}
+			// Unsetting either field changes the spec, which triggers a new reconciliation
+			return ctrl.Result{}, nil
+		}
+		query = report.Spec.HealthExpression
+	}
+	if report.Spec.QueryTemplate != "" || report.Spec.HealthExpression != "" {
+		// The query of the report covers every workload, so it is not overridden by their own queries
+		healthQueryWorkloads = nil
+	}
+	auth, err := r.resolvePrometheusAuth(ctx, report)
+	if err != nil {
+		klog.ErrorS(err, "Failed to resolve Prometheus auth", "report", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	var queryTimeout time.Duration
+	if report.Spec.QueryTimeout != nil {
+		queryTimeout = report.Spec.QueryTimeout.Duration
+	}
+
+	collectionStart := time.Now()
+	var collectedMetrics []autoapprovev1alpha1.WorkloadMetric
+	var parseErrors int
+	var collectErr error
+	if r.ScrapeEndpointSelector != nil {
+		collectedMetrics, parseErrors, collectErr = r.collectScrapedMetrics(ctx, report.Spec.HealthMetricNames, report.Spec.WorkloadKinds, report.Spec.ExtraLabelKeys, report.Spec.HealthStateMapping, report.Spec.AllowAggregatedSeries, report.Spec.MinPodUptime)
+		if workloads != nil {
+			// Every matching endpoint is scraped, not only those of the tracked workloads
+			collectedMetrics = filterTrackedWorkloadMetrics(collectedMetrics, workloads)
+		}
+	} else {
+		collectedMetrics, parseErrors, collectErr = r.collectFromPrometheusReplicas(ctx, prometheusURLs, report.Spec.Protocol, auth, queryTimeout, query, report.Spec.WorkloadKinds, report.Spec.ExtraLabelKeys, report.Spec.StrictResultType, report.Spec.HealthStateMapping, report.Spec.AllowAggregatedSeries, healthQueryWorkloads, report.Spec.ReplicaMergePolicy)
+	}
+	collectionDuration := time.Since(collectionStart)
+	if report.Spec.HealthExpression != "" && workloads != nil {
+		// Unlike the built query, the expression is not scoped to the tracked workloads
+		collectedMetrics = filterTrackedWorkloadMetrics(collectedMetrics, workloads)
+	}
+	collectedMetrics = preferHealthMetrics(collectedMetrics, report.Spec.HealthMetricNames)
+	if report.Spec.MinPodUptime != nil && r.ScrapeEndpointSelector == nil && collectErr == nil && len(collectedMetrics) > 0 {
+		// Without uptimes every pod counts as not up long enough, so that a failed query never leads to approval. The
+		// health metrics were collected, so the failure neither fails the collection nor falls back to pod status.
+		uptimes, err := r.collectPodUptimes(ctx, prometheusURLs, report.Spec.Protocol, auth, queryTimeout, report.Spec.PodStartTimeMetric, collectedMetrics)
+		if err != nil {
+			klog.ErrorS(err, "Failed to query pod uptimes, counting every pod as not up long enough", "report", req.NamespacedName)
+		}
+		applyMinPodUptime(collectedMetrics, uptimes, report.Spec.MinPodUptime.Duration)
+	}
+	// Judge the tracked workloads by the readiness of their pods rather than not at all while Prometheus is down
+	var prometheusErr error
+	if collectErr != nil && r.FallbackToKubeStatus && workloads != nil {
+		fallbackMetrics, fallbackErr := r.collectKubeStatusMetrics(ctx, workloads, report.Spec.MinPodUptime)
+		if fallbackErr != nil {
+			klog.ErrorS(fallbackErr, "Failed to fall back to pod status", "report", req.NamespacedName)
+		} else {
+			klog.InfoS("Failed to query Prometheus, derived pod health from pod status instead", "report", req.NamespacedName, "prometheusError", collectErr.Error(), "pods", len(fallbackMetrics))
+			prometheusErr, collectErr = collectErr, nil
+			collectedMetrics = fallbackMetrics
+		}
+	}
+	span.SetAttributes(attribute.Int("workloads", len(collectedMetrics)))
+	tracing.RecordError(span, collectErr)
 
 	// 5. Update MetricCollectorReport status on hub
 	now := metav1.Now()
 	report.Status.LastCollectionTime = &now
+	report.Status.LastCollectionDurationMillis = collectionDuration.Milliseconds()
+	// Replace rather than merge the previous metrics, so that deleted workloads and pods drop out of the report
+	// as soon as Prometheus stops returning their series
 	report.Status.CollectedMetrics = collectedMetrics
+	report.Status.HealthyWorkloads = nil
+	if report.Spec.ReportUnhealthyOnly {
+		report.Status.CollectedMetrics, report.Status.HealthyWorkloads = summarizeHealthyMetrics(collectedMetrics)
+	}
 	report.Status.WorkloadsMonitored = int32(len(collectedMetrics))
+	report.Status.ParseErrors = int32(parseErrors)
+	if parseErrors > 0 {
+		klog.InfoS("Skipped series with malformed health values", "report", req.NamespacedName, "parseErrors", parseErrors)
+	}
+	var droppedMetrics int
+	report.Status.CollectedMetrics, droppedMetrics = capCollectedMetrics(report.Status.CollectedMetrics, r.MaxCollectedMetrics)
+	if droppedMetrics > 0 {
+		klog.InfoS("Too many collected metrics, dropping the metrics of healthy pods first", "report", req.NamespacedName, "maxCollectedMetrics", r.MaxCollectedMetrics, "dropped", droppedMetrics)
+	}
+	// An empty result may mean the health metrics are not in Prometheus at all, e.g. because they are not scraped
+	// or a gateway answered a backend error with an empty success, rather than that no workload matched
+	metricsAbsent := false
+	if collectErr == nil && prometheusErr == nil && len(collectedMetrics) == 0 && report.Spec.RequireMetricExists && r.ScrapeEndpointSelector == nil &&
+		report.Spec.QueryTemplate == "" && report.Spec.HealthExpression == "" && query != "" {
+		metricsAbsent, collectErr = r.healthMetricsAbsent(ctx, prometheusURLs, report.Spec.Protocol, auth, queryTimeout, report.Spec.HealthMetricNames)
+	}
+	report.Status.DesiredReplicas = nil
+	if collectErr == nil && prometheusErr == nil && r.ScrapeEndpointSelector == nil {
+		report.Status.DesiredReplicas = r.collectDesiredReplicas(ctx, prometheusURLs, report.Spec.Protocol, auth, queryTimeout, workloads)
+	}
 
-	if collectErr != nil {
-		klog.ErrorS(collectErr, "Failed to collect metrics", "prometheusUrl", prometheusURL)
+	collectionReason := autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded
+	switch {
+	case collectErr != nil:
+		klog.ErrorS(collectErr, "Failed to collect metrics", "prometheusUrls", prometheusURLs)
+		reportCollectionTotal.WithLabelValues(collectionResultFailure).Inc()
+		collectionReason = autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionFailed
 		meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
 			Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
 			Status:             metav1.ConditionFalse,
 			ObservedGeneration: report.Generation,
-			Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionFailed,
+			Reason:             collectionReason,
 			Message:            fmt.Sprintf("Failed to collect metrics: %v", collectErr),
 		})
-	} else {
+	case metricsAbsent:
+		metricNames := strings.Join(healthMetricNamesOrDefault(report.Spec.HealthMetricNames), ", ")
+		klog.InfoS("Prometheus has no series of the health metrics", "report", req.NamespacedName, "metrics", metricNames, "prometheusUrls", prometheusURLs)
+		reportCollectionTotal.WithLabelValues(collectionResultFailure).Inc()
+		collectionReason = autoapprovev1alpha1.MetricCollectorReportConditionReasonMetricNotFound
+		meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
+			Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: report.Generation,
+			Reason:             collectionReason,
+			Message:            fmt.Sprintf("Prometheus has no series of %s at all; check that the workloads export it and that Prometheus scrapes them", metricNames),
+		})
+	case prometheusErr != nil:
+		reportCollectionTotal.WithLabelValues(collectionResultFallback).Inc()
+		collectionReason = autoapprovev1alpha1.MetricCollectorReportConditionReasonKubeStatusFallback
+		meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
+			Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: report.Generation,
+			Reason:             collectionReason,
+			Message:            fmt.Sprintf("Failed to query Prometheus (%v); derived the health of %d pods from their Ready condition instead", prometheusErr, len(collectedMetrics)),
+		})
+	default:
 		klog.V(2).InfoS("Successfully collected metrics", "report", report.Name, "workloads", len(collectedMetrics))
+		reportCollectionTotal.WithLabelValues(collectionResultSuccess).Inc()
+		message := fmt.Sprintf("Successfully collected metrics from %d workloads", len(collectedMetrics))
+		if droppedMetrics > 0 {
+			message += fmt.Sprintf("; %d metrics of healthy pods were dropped to stay within %d, set reportUnhealthyOnly to count them instead", droppedMetrics, r.MaxCollectedMetrics)
+		}
 		meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
 			Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
 			Status:             metav1.ConditionTrue,
 			ObservedGeneration: report.Generation,
-			Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded,
-			Message:            fmt.Sprintf("Successfully collected metrics from %d workloads", len(collectedMetrics)),
+			Reason:             collectionReason,
+			Message:            message,
 		})
 	}
+	if collectionReason == autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionFailed ||
+		collectionReason == autoapprovev1alpha1.MetricCollectorReportConditionReasonMetricNotFound {
+		report.Status.ConsecutiveFailures++
+	} else {
+		report.Status.ConsecutiveFailures = 0
+	}
+	r.setReadyCondition(report)
+	report.Status.RecentCollections = appendCollectionSummary(report.Status.RecentCollections, autoapprovev1alpha1.CollectionSummary{
+		Time:               now,
+		Reason:             collectionReason,
+		WorkloadsMonitored: report.Status.WorkloadsMonitored,
+	}, r.RecentCollectionsLimit)
 
-	if err := r.HubClient.Status().Update(ctx, report); err != nil {
-		klog.ErrorS(err, "Failed to update MetricCollectorReport status", "report", req.NamespacedName)
-		return ctrl.Result{}, err
+	if err := r.applyStatus(ctx, report); err != nil {
+		return statusUpdateFailed(err, req.NamespacedName, "Failed to update MetricCollectorReport status")
 	}
 
-	klog.InfoS("Successfully updated MetricCollectorReport", "metricsCount", len(collectedMetrics), "prometheusUrl", prometheusURL)
-	return ctrl.Result{RequeueAfter: defaultCollectionInterval}, nil
+	reportLastCollectionAgeSeconds.observe(req.NamespacedName, now.Time)
+
+	klog.InfoS("Successfully updated MetricCollectorReport", "metricsCount", len(collectedMetrics), "prometheusUrls", prometheusURLs)
+	return ctrl.Result{RequeueAfter: utils.JitterDuration(defaultCollectionInterval, r.RequeueJitterFraction)}, nil
+}
+
+// resetCollectedStatus drops the metrics collected into the report status, along with the counts derived from them.
+func resetCollectedStatus(report *autoapprovev1alpha1.MetricCollectorReport) {
+	report.Status.CollectedMetrics = nil
+	report.Status.HealthyWorkloads = nil
+	report.Status.WorkloadsMonitored = 0
+	report.Status.ParseErrors = 0
 }
 
-// collectAllWorkloadMetrics queries Prometheus for all workload_health metrics
-func (r *Reconciler) collectAllWorkloadMetrics(ctx context.Context, promClient PrometheusClient) ([]autoapprovev1alpha1.WorkloadMetric, error) {
+// applyStatus writes the status fields owned by the metric collector with server-side apply. Fields owned by others,
+// such as BlockingWorkloads and the conditions of the approval-request-controller, are left untouched, and the write
+// does not conflict with theirs. Lists are sent even if empty, so that clearing them also removes the values
+// written by metric collectors that updated the whole status.
+func (r *Reconciler) applyStatus(ctx context.Context, report *autoapprovev1alpha1.MetricCollectorReport) error {
+	status := autoapprovev1alpha1.MetricCollectorReportStatus{
+		LastCollectionTime:           report.Status.LastCollectionTime,
+		LastCollectionDurationMillis: report.Status.LastCollectionDurationMillis,
+		LastQueriedURL:               report.Status.LastQueriedURL,
+		CollectedMetrics:             report.Status.CollectedMetrics,
+		DesiredReplicas:              report.Status.DesiredReplicas,
+		HealthyWorkloads:             report.Status.HealthyWorkloads,
+		RecentCollections:            report.Status.RecentCollections,
+	}
+	for _, conditionType := range []string{
+		autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+		autoapprovev1alpha1.MetricCollectorReportConditionTypePrometheusAuthResolved,
+		autoapprovev1alpha1.MetricCollectorReportConditionTypeReady,
+	} {
+		if cond := meta.FindStatusCondition(report.Status.Conditions, conditionType); cond != nil {
+			status.Conditions = append(status.Conditions, *cond)
+		}
+	}
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return fmt.Errorf("failed to convert MetricCollectorReport status: %w", err)
+	}
+	fields["workloadsMonitored"] = int64(report.Status.WorkloadsMonitored)
+	fields["parseErrors"] = int64(report.Status.ParseErrors)
+	fields["consecutiveFailures"] = int64(report.Status.ConsecutiveFailures)
+	for _, key := range []string{"collectedMetrics", "desiredReplicas", "healthyWorkloads"} {
+		if _, ok := fields[key]; !ok {
+			fields[key] = []interface{}{}
+		}
+	}
+
+	applied := &unstructured.Unstructured{Object: map[string]interface{}{"status": fields}}
+	applied.SetGroupVersionKind(autoapprovev1alpha1.GroupVersion.WithKind("MetricCollectorReport"))
+	applied.SetNamespace(report.Namespace)
+	applied.SetName(report.Name)
+	return r.HubClient.Status().Patch(ctx, applied, client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership)
+}
+
+// setReadyCondition sets the Ready condition of the report from its consecutive failed collections. It stays True
+// until ReadyFailureThreshold collections in a row have failed, so that a transient failure does not flap it.
+func (r *Reconciler) setReadyCondition(report *autoapprovev1alpha1.MetricCollectorReport) {
+	threshold := r.ReadyFailureThreshold
+	if threshold <= 0 {
+		threshold = defaultReadyFailureThreshold
+	}
+	cond := metav1.Condition{
+		Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: report.Generation,
+		Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonCollecting,
+		Message:            "Metrics are being collected",
+	}
+	switch failures := int(report.Status.ConsecutiveFailures); {
+	case failures >= threshold:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = autoapprovev1alpha1.MetricCollectorReportConditionReasonSustainedCollectionFailures
+		cond.Message = fmt.Sprintf("The last %d collections failed; see the MetricsCollected condition for the latest error", failures)
+	case failures > 0:
+		cond.Message = fmt.Sprintf("The last %d collections failed, Ready turns False after %d consecutive failures", failures, threshold)
+	}
+	meta.SetStatusCondition(&report.Status.Conditions, cond)
+}
+
+// appendCollectionSummary appends the summary of a collection to the history and trims it to the last limit
+// summaries, oldest first. A limit of zero or less drops the history.
+func appendCollectionSummary(history []autoapprovev1alpha1.CollectionSummary, summary autoapprovev1alpha1.CollectionSummary, limit int) []autoapprovev1alpha1.CollectionSummary {
+	if limit <= 0 {
+		return nil
+	}
+	history = append(history, summary)
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return history
+}
+
+// capCollectedMetrics keeps at most maxMetrics metrics, dropping the metrics of healthy pods first, and returns the
+// number of dropped metrics. Dropping a healthy pod can only make a workload look less healthy, so a capped report
+// never leads to approval that the full one would not. A maxMetrics of zero or less keeps all metrics.
+func capCollectedMetrics(metrics []autoapprovev1alpha1.WorkloadMetric, maxMetrics int) ([]autoapprovev1alpha1.WorkloadMetric, int) {
+	if maxMetrics <= 0 || len(metrics) <= maxMetrics {
+		return metrics, 0
+	}
+	capped := make([]autoapprovev1alpha1.WorkloadMetric, 0, maxMetrics)
+	for _, healthy := range []bool{false, true} {
+		for _, metric := range metrics {
+			if metric.Health == healthy && len(capped) < maxMetrics {
+				capped = append(capped, metric)
+			}
+		}
+	}
+	return capped, len(metrics) - len(capped)
+}
+
+// statusUpdateFailed returns the result of a reconcile whose MetricCollectorReport status update failed.
+// Transient failures are retried after statusUpdateRetryInterval without an error, since the retry re-reads the
+// report and the rate-limited backoff applied to errors can delay the next collection by minutes:
+//   - conflicts, which server-side apply with forced ownership should not run into;
+//   - the API server timing out, throttling or being unavailable.
+//
+// Any other failure, e.g. the report being rejected by validation, is returned for the rate-limited backoff.
+func statusUpdateFailed(err error, report types.NamespacedName, message string) (ctrl.Result, error) {
+	if errors.IsConflict(err) || errors.IsServerTimeout(err) || errors.IsTimeout(err) ||
+		errors.IsTooManyRequests(err) || errors.IsServiceUnavailable(err) {
+		klog.V(2).InfoS(message+", retrying", "report", report, "err", err)
+		return ctrl.Result{RequeueAfter: statusUpdateRetryInterval}, nil
+	}
+	klog.ErrorS(err, message, "report", report)
+	return ctrl.Result{}, err
+}
+
+// clearStaleCollection clears the collected metrics and the last collection time of the report if they were
+// collected for an older generation of its spec, and marks the MetricsCollected condition Unknown until the current
+// spec is collected. The condition keeps the generation it was last collected for, so that readers do not take the
+// cleared status for a collection of the current spec. It returns whether the status was changed.
+func clearStaleCollection(report *autoapprovev1alpha1.MetricCollectorReport) bool {
+	cond := meta.FindStatusCondition(report.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+	if cond == nil || cond.ObservedGeneration == report.Generation {
+		return false
+	}
+	resetCollectedStatus(report)
+	report.Status.DesiredReplicas = nil
+	report.Status.LastCollectionTime = nil
+	meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
+		Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: cond.ObservedGeneration,
+		Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonSpecChanged,
+		Message:            fmt.Sprintf("Spec changed from generation %d, collecting metrics for generation %d", cond.ObservedGeneration, report.Generation),
+	})
+	return true
+}
+
+// validatePrometheusURLs checks that every Prometheus URL is valid for the protocol.
+func validatePrometheusURLs(prometheusURLs []string, protocol autoapprovev1alpha1.PrometheusProtocol) error {
+	for _, prometheusURL := range prometheusURLs {
+		if err := utils.ValidatePrometheusURL(prometheusURL, protocol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTrackedWorkloads returns the workloads listed in the referenced WorkloadTracker for the given stage.
+// It returns nil, meaning all workloads are collected, if there is no reference or the tracker does not exist.
+func (r *Reconciler) getTrackedWorkloads(ctx context.Context, ref *autoapprovev1alpha1.WorkloadTrackerReference, stage string) ([]autoapprovev1alpha1.WorkloadReference, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	var workloads []autoapprovev1alpha1.WorkloadReference
+	var err error
+	switch ref.Kind {
+	case autoapprovev1alpha1.ClusterStagedWorkloadTrackerKind:
+		tracker := &autoapprovev1alpha1.ClusterStagedWorkloadTracker{}
+		err = r.HubClient.Get(ctx, types.NamespacedName{Name: ref.Name}, tracker)
+		workloads = tracker.WorkloadsForStage(stage)
+	case autoapprovev1alpha1.StagedWorkloadTrackerKind:
+		tracker := &autoapprovev1alpha1.StagedWorkloadTracker{}
+		err = r.HubClient.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, tracker)
+		workloads = tracker.WorkloadsForStage(stage)
+	default:
+		return nil, fmt.Errorf("unsupported workload tracker kind %q", ref.Kind)
+	}
+	if err != nil {
+		if errors.IsNotFound(err) {
+			klog.V(2).InfoS("Referenced WorkloadTracker not found, collecting all workloads", "kind", ref.Kind, "name", ref.Name, "namespace", ref.Namespace)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s %s: %w", ref.Kind, ref.Name, err)
+	}
+	return workloads, nil
+}
+
+// collectFromPrometheusReplicas collects workload metrics from each Prometheus URL and merges the results
+// per pod according to the merge policy. Replicas that fail are skipped; an error is returned only if
+// every replica fails. The metrics of healthQueryWorkloads come from their own HealthQuery rather than query.
+// The returned parse errors are the most series skipped for a malformed value by any of the replicas.
+func (r *Reconciler) collectFromPrometheusReplicas(
+	ctx context.Context,
+	prometheusURLs []string,
+	protocol autoapprovev1alpha1.PrometheusProtocol,
+	auth prometheusAuth,
+	queryTimeout time.Duration,
+	query string,
+	workloadKinds []string,
+	extraLabelKeys []string,
+	strictResultType bool,
+	healthStateMapping *autoapprovev1alpha1.HealthStateMapping,
+	allowAggregatedSeries bool,
+	healthQueryWorkloads []autoapprovev1alpha1.WorkloadReference,
+	mergePolicy autoapprovev1alpha1.ReplicaMergePolicy,
+) ([]autoapprovev1alpha1.WorkloadMetric, int, error) {
+	var results [][]autoapprovev1alpha1.WorkloadMetric
+	var parseErrors int
+	var errs []error
+	for _, prometheusURL := range prometheusURLs {
+		promClient, err := r.newPrometheusClient(prometheusURL, protocol, auth, queryTimeout)
+		if err != nil {
+			klog.ErrorS(err, "Failed to create client for Prometheus replica", "prometheusUrl", prometheusURL)
+			errs = append(errs, fmt.Errorf("%s: %w", prometheusURL, err))
+			continue
+		}
+		metrics, replicaParseErrors, err := collectWorkloadMetricsWithHealthQueries(ctx, promClient, query, workloadKinds, extraLabelKeys, strictResultType, healthStateMapping, allowAggregatedSeries, healthQueryWorkloads)
+		if err != nil {
+			klog.ErrorS(err, "Failed to collect metrics from Prometheus replica", "prometheusUrl", prometheusURL)
+			errs = append(errs, fmt.Errorf("%s: %w", prometheusURL, err))
+			continue
+		}
+		results = append(results, metrics)
+		parseErrors = max(parseErrors, replicaParseErrors)
+	}
+	if len(results) == 0 {
+		return nil, 0, utilerrors.NewAggregate(errs)
+	}
+	return mergeReplicaMetrics(results, mergePolicy), parseErrors, nil
+}
+
+// mergeReplicaMetrics merges the metrics collected from multiple Prometheus replicas into one entry per pod.
+// With ReplicaMergePolicyAll a pod is healthy only if all replicas reporting it say so; otherwise
+// (ReplicaMergePolicyAny or unset) a pod is healthy if any replica reports it healthy.
+func mergeReplicaMetrics(results [][]autoapprovev1alpha1.WorkloadMetric, mergePolicy autoapprovev1alpha1.ReplicaMergePolicy) []autoapprovev1alpha1.WorkloadMetric {
+	if len(results) == 1 {
+		return results[0]
+	}
+
+	type podKey struct {
+		namespace, workloadName, workloadKind, podName string
+	}
+	indexByPod := make(map[podKey]int)
+	var merged []autoapprovev1alpha1.WorkloadMetric
+	for _, metrics := range results {
+		for _, metric := range metrics {
+			key := podKey{metric.Namespace, metric.WorkloadName, metric.WorkloadKind, metric.PodName}
+			i, ok := indexByPod[key]
+			if !ok {
+				indexByPod[key] = len(merged)
+				merged = append(merged, metric)
+				continue
+			}
+			if mergePolicy == autoapprovev1alpha1.ReplicaMergePolicyAll {
+				merged[i].Health = merged[i].Health && metric.Health
+			} else {
+				merged[i].Health = merged[i].Health || metric.Health
+			}
+			// Keep the reason of an unhealthy pod only while the merged result is unhealthy
+			if merged[i].Health {
+				merged[i].UnhealthyReason = ""
+			} else if merged[i].UnhealthyReason == "" && !metric.Health {
+				merged[i].UnhealthyReason = metric.UnhealthyReason
+			}
+		}
+	}
+	return merged
+}
+
+// splitHealthQueryWorkloads splits the workloads into those checked with the workload_health metric and those
+// with their own HealthQuery or SLOQuery.
+func splitHealthQueryWorkloads(workloads []autoapprovev1alpha1.WorkloadReference) (defaultWorkloads, healthQueryWorkloads []autoapprovev1alpha1.WorkloadReference) {
+	for _, workload := range workloads {
+		if workload.HealthQuery != "" || workload.SLOQuery != "" {
+			healthQueryWorkloads = append(healthQueryWorkloads, workload)
+		} else {
+			defaultWorkloads = append(defaultWorkloads, workload)
+		}
+	}
+	return defaultWorkloads, healthQueryWorkloads
+}
+
+// collectWorkloadMetricsWithHealthQueries runs the fleet-wide query, unless it is empty, and the HealthQuery or
+// SLOQuery of each of healthQueryWorkloads against one Prometheus. Series of the fleet-wide query that belong to healthQueryWorkloads
+// are dropped so that each workload is only judged by its own query. Any failed query fails the collection.
+// It also returns the number of series skipped because their value was malformed.
+func collectWorkloadMetricsWithHealthQueries(
+	ctx context.Context,
+	promClient PrometheusClient,
+	query string,
+	workloadKinds []string,
+	extraLabelKeys []string,
+	strictResultType bool,
+	healthStateMapping *autoapprovev1alpha1.HealthStateMapping,
+	allowAggregatedSeries bool,
+	healthQueryWorkloads []autoapprovev1alpha1.WorkloadReference,
+) ([]autoapprovev1alpha1.WorkloadMetric, int, error) {
+	var metrics []autoapprovev1alpha1.WorkloadMetric
+	var parseErrors int
+	if query != "" {
+		collected, queryParseErrors, err := collectAllWorkloadMetrics(ctx, promClient, query, workloadKinds, extraLabelKeys, strictResultType, healthStateMapping, allowAggregatedSeries)
+		if err != nil {
+			return nil, 0, err
+		}
+		parseErrors += queryParseErrors
+		for _, metric := range collected {
+			if !isWorkloadMetric(metric, healthQueryWorkloads) {
+				metrics = append(metrics, metric)
+			}
+		}
+	}
+	for _, workload := range healthQueryWorkloads {
+		if len(workloadKinds) > 0 && !slices.Contains(workloadKinds, workload.Kind) {
+			continue
+		}
+		collected, queryParseErrors, err := collectHealthQueryMetrics(ctx, promClient, workload, extraLabelKeys, strictResultType, healthStateMapping, allowAggregatedSeries)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s of %s %s/%s: %w", workloadQueryField(workload), workload.Kind, workload.Namespace, workload.Name, err)
+		}
+		metrics = append(metrics, collected...)
+		parseErrors += queryParseErrors
+	}
+	return metrics, parseErrors, nil
+}
+
+// collectHealthQueryMetrics runs the HealthQuery or SLOQuery of a workload and converts each series into a metric of
+// a pod of that workload. The namespace, name and kind are taken from the workload rather than the series labels.
+// Series with a malformed sample or an unparseable value are skipped and counted in the returned parse errors.
+// The series of an SLOQuery are judged against the SLOThreshold of the workload, and those without a pod label are
+// always kept as an aggregated pod, since a quantile over a workload rarely keeps one.
+func collectHealthQueryMetrics(
+	ctx context.Context,
+	promClient PrometheusClient,
+	workload autoapprovev1alpha1.WorkloadReference,
+	extraLabelKeys []string,
+	strictResultType bool,
+	healthStateMapping *autoapprovev1alpha1.HealthStateMapping,
+	allowAggregatedSeries bool,
+) ([]autoapprovev1alpha1.WorkloadMetric, int, error) {
+	query := workload.HealthQuery
+	if workload.SLOQuery != "" {
+		if workload.SLOThreshold == nil {
+			return nil, 0, fmt.Errorf("sloQuery requires an sloThreshold")
+		}
+		query = workload.SLOQuery
+	}
+	data, err := promClient.Query(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	if strictResultType && data.ResultType != prometheusResultTypeVector {
+		return nil, 0, fmt.Errorf("query %q returned a %q result, but strictResultType requires an instant vector", query, data.ResultType)
+	}
+
 	var collectedMetrics []autoapprovev1alpha1.WorkloadMetric
+	var parseErrors int
+	for _, res := range data.Result {
+		podName := res.Metric["pod"]
+		if podName == "" {
+			if !allowAggregatedSeries && workload.SLOQuery == "" {
+				klog.V(4).InfoS("Skipping healthQuery series without a pod label", "namespace", workload.Namespace, "workload", workload.Name, "kind", workload.Kind)
+				continue
+			}
+			podName = autoapprovev1alpha1.AggregatedPodName
+		}
+		valueStr, err := res.latestSample()
+		if err != nil {
+			klog.ErrorS(err, "Skipping healthQuery series with a malformed sample", "namespace", workload.Namespace, "workload", workload.Name, "kind", workload.Kind, "pod", podName)
+			parseErrors++
+			continue
+		}
+		var healthy, active bool
+		var unhealthyReason string
+		if workload.SLOQuery != "" {
+			healthy, unhealthyReason, err = sloHealth(valueStr, workload.SLOThreshold)
+			active = true
+		} else {
+			healthy, unhealthyReason, active, err = seriesHealth(res.Metric, valueStr, healthStateMapping)
+		}
+		if err != nil {
+			klog.ErrorS(err, "Failed to parse health value from healthQuery result", "namespace", workload.Namespace, "workload", workload.Name, "kind", workload.Kind, "valueStr", valueStr)
+			parseErrors++
+			continue
+		}
+		if !active {
+			continue
+		}
+		collectedMetrics = append(collectedMetrics, autoapprovev1alpha1.WorkloadMetric{
+			PodName:         podName,
+			WorkloadName:    workload.Name,
+			Namespace:       workload.Namespace,
+			WorkloadKind:    workload.Kind,
+			Health:          healthy,
+			UnhealthyReason: unhealthyReason,
+			HealthMetric:    res.Metric[metricNameLabel],
+			Value:           valueStr,
+			ExtraLabels:     extraLabels(res.Metric, extraLabelKeys),
+		})
+	}
+	return collectedMetrics, parseErrors, nil
+}
 
-	// Query all workload_health metrics (no filtering)
-	query := "workload_health"
+// workloadQueryField returns the name of the field holding the query of a workload with its own query.
+func workloadQueryField(workload autoapprovev1alpha1.WorkloadReference) string {
+	if workload.SLOQuery != "" {
+		return "sloQuery"
+	}
+	return "healthQuery"
+}
 
+// sloHealth judges a value of the SLOQuery of a workload: it is healthy if it is at most threshold. NaN, which
+// histogram_quantile returns without any observations, and +Inf, which it returns when the quantile falls into the
+// highest bucket, are unhealthy with a reason.
+func sloHealth(valueStr string, threshold *resource.Quantity) (bool, string, error) {
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return false, "", err
+	}
+	switch {
+	case math.IsNaN(value):
+		return false, autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNaN, nil
+	case math.IsInf(value, 1):
+		return false, autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInfinite, nil
+	case value > threshold.AsApproximateFloat64():
+		return false, autoapprovev1alpha1.WorkloadMetricUnhealthyReasonSLOThresholdExceeded, nil
+	}
+	return true, "", nil
+}
+
+// filterTrackedWorkloadMetrics returns the metrics of the given workloads, matched by namespace, name and kind.
+func filterTrackedWorkloadMetrics(
+	metrics []autoapprovev1alpha1.WorkloadMetric,
+	workloads []autoapprovev1alpha1.WorkloadReference,
+) []autoapprovev1alpha1.WorkloadMetric {
+	var tracked []autoapprovev1alpha1.WorkloadMetric
+	for _, metric := range metrics {
+		if isWorkloadMetric(metric, workloads) {
+			tracked = append(tracked, metric)
+		}
+	}
+	return tracked
+}
+
+// isWorkloadMetric reports whether the metric belongs to one of the workloads, matched by namespace, name and kind.
+func isWorkloadMetric(metric autoapprovev1alpha1.WorkloadMetric, workloads []autoapprovev1alpha1.WorkloadReference) bool {
+	for _, workload := range workloads {
+		if metric.Namespace == workload.Namespace && metric.WorkloadName == workload.Name && metric.WorkloadKind == workload.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeHealthyMetrics drops the metrics of healthy pods and counts them per workload instead,
+// returning the metrics of the unhealthy pods and the healthy pod counts in order of first appearance.
+// Like utils.CountHealthyPodsForWorkload, a pod with several series is healthy if any of them is.
+func summarizeHealthyMetrics(metrics []autoapprovev1alpha1.WorkloadMetric) ([]autoapprovev1alpha1.WorkloadMetric, []autoapprovev1alpha1.WorkloadHealthyCount) {
+	type workloadKey struct {
+		namespace, workloadName, workloadKind string
+	}
+	type podKey struct {
+		workload workloadKey
+		podName  string
+	}
+	healthyPods := make(map[podKey]bool)
+	for _, metric := range metrics {
+		if metric.Health {
+			healthyPods[podKey{workloadKey{metric.Namespace, metric.WorkloadName, metric.WorkloadKind}, metric.PodName}] = true
+		}
+	}
+
+	var unhealthy []autoapprovev1alpha1.WorkloadMetric
+	var healthyCounts []autoapprovev1alpha1.WorkloadHealthyCount
+	indexByWorkload := make(map[workloadKey]int)
+	countedPods := make(map[podKey]bool)
+	for _, metric := range metrics {
+		key := workloadKey{metric.Namespace, metric.WorkloadName, metric.WorkloadKind}
+		pod := podKey{key, metric.PodName}
+		if !healthyPods[pod] {
+			unhealthy = append(unhealthy, metric)
+			continue
+		}
+		if countedPods[pod] {
+			continue
+		}
+		countedPods[pod] = true
+		i, ok := indexByWorkload[key]
+		if !ok {
+			i = len(healthyCounts)
+			indexByWorkload[key] = i
+			healthyCounts = append(healthyCounts, autoapprovev1alpha1.WorkloadHealthyCount{
+				Namespace:    metric.Namespace,
+				WorkloadName: metric.WorkloadName,
+				WorkloadKind: metric.WorkloadKind,
+			})
+		}
+		healthyCounts[i].TotalHealthy++
+	}
+	return unhealthy, healthyCounts
+}
+
+// collectAllWorkloadMetrics runs the given workload_health query against Prometheus and converts the result
+// with workloadMetricsFromSeries. If strictResultType is set, any result other than an instant vector is an error;
+// otherwise the latest sample of each range matrix series is used.
+func collectAllWorkloadMetrics(
+	ctx context.Context,
+	promClient PrometheusClient,
+	query string,
+	workloadKinds []string,
+	extraLabelKeys []string,
+	strictResultType bool,
+	healthStateMapping *autoapprovev1alpha1.HealthStateMapping,
+	allowAggregatedSeries bool,
+) ([]autoapprovev1alpha1.WorkloadMetric, int, error) {
 	data, err := promClient.Query(ctx, query)
 	if err != nil {
-		klog.ErrorS(err, "Failed to query Prometheus for workload_health metrics")
-		return nil, err
+		klog.ErrorS(err, "Failed to query Prometheus for workload_health metrics", "query", query)
+		return nil, 0, err
+	}
+	if strictResultType && data.ResultType != prometheusResultTypeVector {
+		return nil, 0, fmt.Errorf("query %q returned a %q result, but strictResultType requires an instant vector", query, data.ResultType)
 	}
 
 	if len(data.Result) == 0 {
 		klog.V(4).InfoS("No workload_health metrics found in Prometheus")
-		return collectedMetrics, nil
+		return nil, 0, nil
+	}
+
+	collectedMetrics, parseErrors := workloadMetricsFromSeries(data.Result, workloadKinds, extraLabelKeys, healthStateMapping, allowAggregatedSeries)
+	klog.V(2).InfoS("Collected workload metrics from Prometheus", "count", len(collectedMetrics), "parseErrors", parseErrors)
+	return collectedMetrics, parseErrors, nil
+}
+
+// workloadMetricsFromSeries converts workload_health series into workload metrics, returning them along with the
+// number of series skipped for a malformed health value.
+// If workloadKinds is non-empty, only series whose workload_kind label is in the set are kept.
+// The series labels listed in extraLabelKeys are copied into the ExtraLabels of each metric.
+// If healthStateMapping is set, health is read from its label.
+// Series without a pod label are skipped unless allowAggregatedSeries is set, in which case they are recorded
+// with the AggregatedPodName.
+func workloadMetricsFromSeries(
+	series []PrometheusResult,
+	workloadKinds []string,
+	extraLabelKeys []string,
+	healthStateMapping *autoapprovev1alpha1.HealthStateMapping,
+	allowAggregatedSeries bool,
+) ([]autoapprovev1alpha1.WorkloadMetric, int) {
+	var collectedMetrics []autoapprovev1alpha1.WorkloadMetric
+	var parseErrors int
+
+	allowedKinds := make(map[string]bool, len(workloadKinds))
+	for _, kind := range workloadKinds {
+		allowedKinds[kind] = true
 	}
 
 	// Extract metrics from Prometheus result
-	for _, res := range data.Result {
+	for _, res := range series {
 		// Extract labels from the Prometheus metric
 		// The workload_health metric includes labels like: workload_health{namespace="test-ns",app="sample-metric-app",workload_kind="Deployment",pod="sample-metric-app-xxx"}
 		// These labels come from multiple sources:
@@ -147,52 +1050,412 @@ func (r *Reconciler) collectAllWorkloadMetrics(ctx context.Context, promClient P
 		workloadKind := res.Metric["workload_kind"]
 		podName := res.Metric["pod"]
 
+		if podName == "" && allowAggregatedSeries {
+			// A pre-aggregated series of the whole workload, e.g. from a recording rule, counts as a single pod
+			podName = autoapprovev1alpha1.AggregatedPodName
+		}
 		if namespace == "" || workloadName == "" || workloadKind == "" || podName == "" {
 			klog.V(4).InfoS("Skipping metric with missing required labels", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "pod", podName)
 			continue
 		}
 
+		if len(allowedKinds) > 0 && !allowedKinds[workloadKind] {
+			klog.V(4).InfoS("Skipping metric with workload kind not in the allowed set", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "pod", podName)
+			continue
+		}
+
 		// Extract health value from Prometheus result
-		// Prometheus returns values as [timestamp, value_string] array
-		// We need at least 2 elements: index 0 is timestamp, index 1 is the metric value
-		var health float64
-		if len(res.Value) >= 2 {
-			if valueStr, ok := res.Value[1].(string); ok {
-				if _, err := fmt.Sscanf(valueStr, "%f", &health); err != nil {
-					klog.ErrorS(err, "Failed to parse health value from Prometheus result", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "valueStr", valueStr)
-					continue
-				}
-			} else {
-				klog.ErrorS(nil, "Health value is not a string in Prometheus result", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "value", res.Value[1])
-				continue
-			}
-		} else {
-			klog.ErrorS(nil, "Prometheus result value array has insufficient elements", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "valueLength", len(res.Value))
+		// Prometheus returns values as [timestamp, value_string] arrays, either a single one
+		// in "value" (instant vector) or a list in "values" (range matrix, latest sample wins).
+		// A malformed sample, e.g. an empty or single-element array, is skipped rather than read as a health of 0,
+		// and counted so that operators notice it in the report status
+		valueStr, err := res.latestSample()
+		if err != nil {
+			klog.ErrorS(err, "Skipping series with a malformed sample", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "pod", podName)
+			parseErrors++
+			continue
+		}
+		healthy, unhealthyReason, active, err := seriesHealth(res.Metric, valueStr, healthStateMapping)
+		if err != nil {
+			klog.ErrorS(err, "Failed to parse health value from Prometheus result", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "valueStr", valueStr)
+			parseErrors++
+			continue
+		}
+		if !active {
+			klog.V(4).InfoS("Skipping series of an inactive state", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "pod", podName, "label", healthStateMapping.Label, "state", res.Metric[healthStateMapping.Label])
 			continue
 		}
+		if unhealthyReason != "" {
+			klog.V(2).InfoS("Workload reported a non-finite health value, treating it as unhealthy", "namespace", namespace, "workload", workloadName, "kind", workloadKind, "pod", podName, "valueStr", valueStr)
+		}
 
-		// Convert float to bool: workload is healthy if metric value >= 1.0
-		// We use >= instead of == to handle floating point precision issues that can occur
-		// during JSON serialization/deserialization. The metric app emits 1.0 for healthy
-		// and 0.0 for unhealthy, so >= 1.0 safely distinguishes between the two states.
 		workloadMetrics := autoapprovev1alpha1.WorkloadMetric{
-			PodName:      podName,
-			WorkloadName: workloadName,
-			Namespace:    namespace,
-			WorkloadKind: workloadKind,
-			Health:       health >= 1.0,
+			PodName:         podName,
+			WorkloadName:    workloadName,
+			Namespace:       namespace,
+			WorkloadKind:    workloadKind,
+			Health:          healthy,
+			UnhealthyReason: unhealthyReason,
+			HealthMetric:    res.Metric[metricNameLabel],
+			Value:           valueStr,
+			ExtraLabels:     extraLabels(res.Metric, extraLabelKeys),
 		}
 		collectedMetrics = append(collectedMetrics, workloadMetrics)
 	}
+	return collectedMetrics, parseErrors
+}
+
+// seriesHealth converts the latest sample value of a series into the health of a pod. Without a HealthStateMapping
+// the health is parsed from the value. With one, the pod is healthy if the mapping's label holds one of its healthy
+// values; active is false for series with a value of 0, which state-set metrics report for the states a pod is not in.
+func seriesHealth(seriesLabels map[string]string, valueStr string, healthStateMapping *autoapprovev1alpha1.HealthStateMapping) (healthy bool, unhealthyReason string, active bool, err error) {
+	if healthStateMapping == nil {
+		healthy, unhealthyReason, err = parseHealthValue(valueStr)
+		return healthy, unhealthyReason, true, err
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return false, "", false, err
+	}
+	if value == 0 {
+		return false, "", false, nil
+	}
+	return slices.Contains(healthStateMapping.HealthyValues, seriesLabels[healthStateMapping.Label]), "", true, nil
+}
+
+// parseHealthValue converts a Prometheus sample value into the health of a pod.
+// Prometheus encodes special values as "NaN", "+Inf" and "-Inf"; exporters commonly emit NaN during startup.
+// These are reported as unhealthy with a reason, so that they can be told apart from a health value of 0.
+func parseHealthValue(valueStr string) (bool, string, error) {
+	health, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return false, "", err
+	}
+	switch {
+	case math.IsNaN(health):
+		return false, autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNaN, nil
+	case math.IsInf(health, 0):
+		return false, autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInfinite, nil
+	}
+	// Convert float to bool: workload is healthy if metric value >= 1.0
+	// We use >= instead of == to handle floating point precision issues that can occur
+	// during JSON serialization/deserialization. The metric app emits 1.0 for healthy
+	// and 0.0 for unhealthy, so >= 1.0 safely distinguishes between the two states.
+	return health >= 1.0, "", nil
+}
+
+// extraLabels returns the values of the given label keys present on a Prometheus series,
+// or nil if none of them are present.
+func extraLabels(seriesLabels map[string]string, keys []string) map[string]string {
+	var labels map[string]string
+	for _, key := range keys {
+		value, ok := seriesLabels[key]
+		if !ok {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string, len(keys))
+		}
+		labels[key] = value
+	}
+	return labels
+}
+
+// collectDesiredReplicas queries kube-state-metrics for the desired replica count of each workload that sets
+// UseDesiredReplicas, trying the Prometheus URLs in order. This is best effort: workloads of unsupported kinds or
+// without a kube-state-metrics series are omitted, and the approval controller falls back to HealthyReplicas.
+func (r *Reconciler) collectDesiredReplicas(
+	ctx context.Context,
+	prometheusURLs []string,
+	protocol autoapprovev1alpha1.PrometheusProtocol,
+	auth prometheusAuth,
+	queryTimeout time.Duration,
+	workloads []autoapprovev1alpha1.WorkloadReference,
+) []autoapprovev1alpha1.WorkloadDesiredReplicas {
+	var desiredReplicas []autoapprovev1alpha1.WorkloadDesiredReplicas
+	for _, workload := range workloads {
+		if !workload.UseDesiredReplicas {
+			continue
+		}
+		ksmMetric, ok := desiredReplicasMetrics[workload.Kind]
+		if !ok {
+			klog.V(2).InfoS("Desired replicas are not supported for workload kind", "namespace", workload.Namespace, "workload", workload.Name, "kind", workload.Kind)
+			continue
+		}
+		query := fmt.Sprintf("%s{namespace=%q,%s=%q}", ksmMetric.metric, workload.Namespace, ksmMetric.nameLabel, workload.Name)
+
+		for _, prometheusURL := range prometheusURLs {
+			promClient, err := r.newPrometheusClient(prometheusURL, protocol, auth, queryTimeout)
+			if err != nil {
+				klog.ErrorS(err, "Failed to create Prometheus client", "prometheusUrl", prometheusURL)
+				continue
+			}
+			replicas, found, err := queryDesiredReplicas(ctx, promClient, query)
+			if err != nil {
+				klog.ErrorS(err, "Failed to query desired replicas", "prometheusUrl", prometheusURL, "query", query)
+				continue
+			}
+			if found {
+				desiredReplicas = append(desiredReplicas, autoapprovev1alpha1.WorkloadDesiredReplicas{
+					Namespace:       workload.Namespace,
+					WorkloadName:    workload.Name,
+					WorkloadKind:    workload.Kind,
+					DesiredReplicas: replicas,
+				})
+			} else {
+				klog.V(2).InfoS("No kube-state-metrics series for desired replicas", "prometheusUrl", prometheusURL, "query", query)
+			}
+			break
+		}
+	}
+	return desiredReplicas
+}
 
-	klog.V(2).InfoS("Collected workload metrics from Prometheus", "count", len(collectedMetrics))
-	return collectedMetrics, nil
+// queryDesiredReplicas runs a kube-state-metrics replica query and returns the replica count of the first series.
+// A count that is not a number, NaN, negative or beyond int32 is an error.
+func queryDesiredReplicas(ctx context.Context, promClient PrometheusClient, query string) (int32, bool, error) {
+	data, err := promClient.Query(ctx, query)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(data.Result) == 0 {
+		return 0, false, nil
+	}
+	valueStr, err := data.Result[0].latestSample()
+	if err != nil {
+		return 0, false, err
+	}
+	replicas, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to parse desired replicas %q: %w", valueStr, err)
+	}
+	if math.IsNaN(replicas) || replicas < 0 || replicas > math.MaxInt32 {
+		return 0, false, fmt.Errorf("invalid desired replicas %q", valueStr)
+	}
+	return int32(replicas), true, nil
+}
+
+// CollectWorkloadMetrics queries Prometheus for the workload_health series of the given workloads, or their
+// HealthQuery if set, the same way the metric collector does for a report that references a WorkloadTracker listing them.
+func CollectWorkloadMetrics(
+	ctx context.Context,
+	promClient PrometheusClient,
+	workloads []autoapprovev1alpha1.WorkloadReference,
+) ([]autoapprovev1alpha1.WorkloadMetric, error) {
+	defaultWorkloads, healthQueryWorkloads := splitHealthQueryWorkloads(workloads)
+	query := buildPromQLQuery(defaultWorkloads, nil)
+	if len(defaultWorkloads) == 0 && len(healthQueryWorkloads) > 0 {
+		query = ""
+	}
+	metrics, _, err := collectWorkloadMetricsWithHealthQueries(ctx, promClient, query, nil, nil, false, nil, false, healthQueryWorkloads)
+	return metrics, err
+}
+
+// buildPromQLQuery builds the PromQL query for the health metrics of the given workloads.
+// Each workload becomes a selector on the namespace, app and workload_kind labels for each of metricNames
+// (workload_health if empty), and the selectors are combined with "or",
+// e.g. workload_health{namespace="x",app="y",workload_kind="Deployment"}.
+// It falls back to the bare metric names when no workloads are given.
+func buildPromQLQuery(workloads []autoapprovev1alpha1.WorkloadReference, metricNames []string) string {
+	metricNames = healthMetricNamesOrDefault(metricNames)
+	if len(workloads) == 0 {
+		return strings.Join(metricNames, " or ")
+	}
+
+	selectors := make([]string, 0, len(workloads)*len(metricNames))
+	for _, workload := range workloads {
+		matchers := []string{
+			fmt.Sprintf("namespace=%q", workload.Namespace),
+			fmt.Sprintf("app=%q", workload.Name),
+		}
+		if workload.Kind != "" {
+			matchers = append(matchers, fmt.Sprintf("workload_kind=%q", workload.Kind))
+		}
+		for _, metricName := range metricNames {
+			selectors = append(selectors, fmt.Sprintf("%s{%s}", metricName, strings.Join(matchers, ",")))
+		}
+	}
+	return strings.Join(selectors, " or ")
+}
+
+// collectPodUptimes queries the uptime in seconds of the pods of the given metrics from the start time metric,
+// keyed by namespace and pod name. The first Prometheus replica that answers is used.
+func (r *Reconciler) collectPodUptimes(
+	ctx context.Context,
+	prometheusURLs []string,
+	protocol autoapprovev1alpha1.PrometheusProtocol,
+	auth prometheusAuth,
+	queryTimeout time.Duration,
+	startTimeMetric string,
+	metrics []autoapprovev1alpha1.WorkloadMetric,
+) (map[types.NamespacedName]float64, error) {
+	if startTimeMetric == "" {
+		startTimeMetric = defaultPodStartTimeMetric
+	}
+	var namespaces []string
+	for _, metric := range metrics {
+		if !slices.Contains(namespaces, metric.Namespace) {
+			namespaces = append(namespaces, metric.Namespace)
+		}
+	}
+	query := fmt.Sprintf("time() - max by (namespace, pod) (%s{namespace=~%q})", startTimeMetric, strings.Join(namespaces, "|"))
+
+	var errs []error
+	for _, prometheusURL := range prometheusURLs {
+		promClient, err := r.newPrometheusClient(prometheusURL, protocol, auth, queryTimeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prometheusURL, err))
+			continue
+		}
+		data, err := promClient.Query(ctx, query)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prometheusURL, err))
+			continue
+		}
+		uptimes := make(map[types.NamespacedName]float64, len(data.Result))
+		for _, res := range data.Result {
+			valueStr, err := res.latestSample()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read pod uptime: %w", err)
+			}
+			uptime, err := strconv.ParseFloat(valueStr, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pod uptime %q: %w", valueStr, err)
+			}
+			uptimes[types.NamespacedName{Namespace: res.Metric["namespace"], Name: res.Metric["pod"]}] = uptime
+		}
+		return uptimes, nil
+	}
+	return nil, fmt.Errorf("failed to query pod uptimes: %w", utilerrors.NewAggregate(errs))
+}
+
+// applyMinPodUptime records the uptime of each pod in its metric, and marks the healthy pods that have not been up
+// for minUptime, or whose uptime is unknown, unhealthy with the InsufficientUptime reason. Aggregated series have
+// no pod to look up and are left as they are.
+func applyMinPodUptime(metrics []autoapprovev1alpha1.WorkloadMetric, uptimes map[types.NamespacedName]float64, minUptime time.Duration) {
+	for i := range metrics {
+		metric := &metrics[i]
+		if metric.PodName == autoapprovev1alpha1.AggregatedPodName {
+			continue
+		}
+		uptime, ok := uptimes[types.NamespacedName{Namespace: metric.Namespace, Name: metric.PodName}]
+		if ok {
+			uptimeSeconds := int64(uptime)
+			metric.UptimeSeconds = &uptimeSeconds
+		}
+		if metric.Health && (!ok || uptime < minUptime.Seconds()) {
+			metric.Health = false
+			metric.UnhealthyReason = autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInsufficientUptime
+		}
+	}
+}
+
+// healthMetricNamesOrDefault returns the candidate health metrics, or workload_health if there are none.
+func healthMetricNamesOrDefault(metricNames []string) []string {
+	if len(metricNames) == 0 {
+		return []string{workloadHealthMetric}
+	}
+	return metricNames
+}
+
+// healthMetricsAbsent reports whether none of the Prometheus replicas has any series of the health metrics.
+// It asks for the names of the existing health metrics rather than their series, to keep the result small.
+func (r *Reconciler) healthMetricsAbsent(
+	ctx context.Context,
+	prometheusURLs []string,
+	protocol autoapprovev1alpha1.PrometheusProtocol,
+	auth prometheusAuth,
+	queryTimeout time.Duration,
+	metricNames []string,
+) (bool, error) {
+	query := fmt.Sprintf("group by (%s) ({%s=~%q})", metricNameLabel, metricNameLabel, strings.Join(healthMetricNamesOrDefault(metricNames), "|"))
+	var errs []error
+	for _, prometheusURL := range prometheusURLs {
+		promClient, err := r.newPrometheusClient(prometheusURL, protocol, auth, queryTimeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prometheusURL, err))
+			continue
+		}
+		data, err := promClient.Query(ctx, query)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prometheusURL, err))
+			continue
+		}
+		if len(data.Result) > 0 {
+			return false, nil
+		}
+	}
+	if len(errs) > 0 {
+		return false, fmt.Errorf("failed to check that the health metrics exist: %w", utilerrors.NewAggregate(errs))
+	}
+	return true, nil
+}
+
+// preferHealthMetrics keeps, for each workload, only the metrics read from the first of metricNames that has
+// series for it, so that a workload exposing several candidate health metrics is judged by the preferred one.
+// Metrics read from any other metric, e.g. of a HealthQuery, are kept as they are. With fewer than two
+// metricNames there is nothing to choose between and the metrics are returned unchanged.
+func preferHealthMetrics(metrics []autoapprovev1alpha1.WorkloadMetric, metricNames []string) []autoapprovev1alpha1.WorkloadMetric {
+	if len(metricNames) < 2 {
+		return metrics
+	}
+
+	type workloadKey struct {
+		namespace, workloadName, workloadKind string
+	}
+	preferred := make(map[workloadKey]int)
+	for _, metric := range metrics {
+		rank := slices.Index(metricNames, metric.HealthMetric)
+		if rank < 0 {
+			continue
+		}
+		key := workloadKey{metric.Namespace, metric.WorkloadName, metric.WorkloadKind}
+		if current, ok := preferred[key]; !ok || rank < current {
+			preferred[key] = rank
+		}
+	}
+
+	kept := make([]autoapprovev1alpha1.WorkloadMetric, 0, len(metrics))
+	for _, metric := range metrics {
+		rank := slices.Index(metricNames, metric.HealthMetric)
+		if rank >= 0 && rank != preferred[workloadKey{metric.Namespace, metric.WorkloadName, metric.WorkloadKind}] {
+			continue
+		}
+		kept = append(kept, metric)
+	}
+	return kept
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// A spec change enqueues the report right away, ahead of the requeue scheduled by its last collection.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("metriccollector-controller").
 		For(&autoapprovev1alpha1.MetricCollectorReport{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
+
+// prometheusClientOptions returns the options used for every Prometheus client created by the reconciler.
+func (r *Reconciler) prometheusClientOptions(queryTimeout time.Duration) []PrometheusClientOption {
+	return []PrometheusClientOption{
+		WithQueryTimeout(queryTimeout),
+		WithProxyURL(r.PrometheusProxyURL),
+		WithPostQueryThreshold(r.PrometheusPostQueryThreshold),
+		WithUserAgent(r.PrometheusUserAgent),
+		WithTracer(r.Tracer),
+	}
+}
+
+// newPrometheusClient returns a client that queries prometheusURL with the given protocol and auth.
+// queryTimeout, if positive, is the server-side timeout sent with every query.
+func (r *Reconciler) newPrometheusClient(prometheusURL string, protocol autoapprovev1alpha1.PrometheusProtocol, auth prometheusAuth, queryTimeout time.Duration) (PrometheusClient, error) {
+	if protocol != autoapprovev1alpha1.PrometheusProtocolGRPC {
+		return r.limitQueries(NewPrometheusClient(prometheusURL, auth.authType, auth.secret, r.prometheusClientOptions(queryTimeout)...)), nil
+	}
+	conn, err := r.grpcConn(prometheusURL)
+	if err != nil {
+		return nil, err
+	}
+	return r.limitQueries(NewThanosClient(conn, prometheusURL, auth.authType, auth.secret, r.Tracer, queryTimeout)), nil
+}
@@ -0,0 +1,1263 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+const (
+	testReportNamespace = "fleet-member-member-1"
+	testReportName      = "mc-test-run-canary"
+)
+
+var testReportKey = types.NamespacedName{Namespace: testReportNamespace, Name: testReportName}
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add client-go scheme: %v", err)
+	}
+	if err := autoapprovev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add autoapprove scheme: %v", err)
+	}
+	return scheme
+}
+
+func newTestClientBuilder(t *testing.T, objs ...client.Object) *fake.ClientBuilder {
+	t.Helper()
+	return fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&autoapprovev1alpha1.MetricCollectorReport{})
+}
+
+func newTestReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+	return &Reconciler{HubClient: newTestClientBuilder(t, objs...).Build()}
+}
+
+func newTestReport(prometheusURL string) *autoapprovev1alpha1.MetricCollectorReport {
+	return &autoapprovev1alpha1.MetricCollectorReport{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testReportNamespace, Name: testReportName, Generation: 1},
+		Spec:       autoapprovev1alpha1.MetricCollectorReportSpec{PrometheusURL: prometheusURL},
+	}
+}
+
+// testPrometheus is a Prometheus HTTP API that answers every query with the same result, or that of resultFor if
+// set, an instant vector unless resultType is set.
+type testPrometheus struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	queries    []string
+	result     []PrometheusResult
+	resultFor  func(query string) []PrometheusResult
+	resultType string
+}
+
+func newTestPrometheus(t *testing.T, result []PrometheusResult) *testPrometheus {
+	t.Helper()
+	p := &testPrometheus{result: result}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p.mu.Lock()
+		query := req.Form.Get("query")
+		p.queries = append(p.queries, query)
+		result, resultType := p.result, p.resultType
+		if p.resultFor != nil {
+			result = p.resultFor(query)
+		}
+		p.mu.Unlock()
+		if resultType == "" {
+			resultType = prometheusResultTypeVector
+		}
+		_ = json.NewEncoder(w).Encode(PrometheusResponse{
+			Status: "success",
+			Data:   PrometheusData{ResultType: resultType, Result: result},
+		})
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+// setResult changes the result of the following queries.
+func (p *testPrometheus) setResult(result []PrometheusResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.result = result
+}
+
+func (p *testPrometheus) receivedQueries() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.queries...)
+}
+
+// healthSeries returns a workload_health series of pod app-<n> of the app Deployment in app-ns with the value.
+func healthSeries(pod, value string) PrometheusResult {
+	return PrometheusResult{
+		Metric: map[string]string{"namespace": "app-ns", "app": "app", "workload_kind": "Deployment", "pod": pod},
+		Value:  []interface{}{float64(1735689600), value},
+	}
+}
+
+// reconcileReport reconciles the test report once and returns the stored report.
+func reconcileReport(t *testing.T, r *Reconciler) *autoapprovev1alpha1.MetricCollectorReport {
+	t.Helper()
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: testReportKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	report := &autoapprovev1alpha1.MetricCollectorReport{}
+	if err := r.HubClient.Get(context.Background(), testReportKey, report); err != nil {
+		t.Fatalf("failed to get MetricCollectorReport: %v", err)
+	}
+	return report
+}
+
+func TestReconcileInvalidPrometheusURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		prometheusURL string
+		wantMessage   string
+	}{
+		{
+			name:          "empty",
+			prometheusURL: "",
+			wantMessage:   "Invalid PrometheusURL: prometheusUrl is empty",
+		},
+		{
+			name:          "unsupported scheme",
+			prometheusURL: "ftp://prometheus:9090",
+			wantMessage:   `Invalid PrometheusURL: prometheusUrl "ftp://prometheus:9090" must use the http, https or unix scheme`,
+		},
+		{
+			name:          "no host",
+			prometheusURL: "http:///api",
+			wantMessage:   `Invalid PrometheusURL: prometheusUrl "http:///api" has no host`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newTestReport(tt.prometheusURL)
+			// Metrics from an earlier collection must not outlive the invalid URL.
+			report.Status.WorkloadsMonitored = 1
+			report.Status.CollectedMetrics = []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", PodName: "app-1", Health: true},
+			}
+			r := newTestReconciler(t, report)
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: testReportKey})
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v, want nil", err)
+			}
+			if diff := cmp.Diff(ctrl.Result{}, result); diff != "" {
+				t.Errorf("Reconcile() result mismatch (-want +got):\n%s", diff)
+			}
+
+			got := &autoapprovev1alpha1.MetricCollectorReport{}
+			if err := r.HubClient.Get(context.Background(), testReportKey, got); err != nil {
+				t.Fatalf("failed to get MetricCollectorReport: %v", err)
+			}
+			want := autoapprovev1alpha1.MetricCollectorReportStatus{
+				Conditions: []metav1.Condition{{
+					Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 1,
+					Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonInvalidPrometheusURL,
+					Message:            tt.wantMessage,
+				}},
+			}
+			if diff := cmp.Diff(want, got.Status, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("status mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeReplicaMetrics(t *testing.T) {
+	// Replica a missed a scrape of app-2, and the replicas disagree on the health of app-1.
+	replicaA := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false},
+	}
+	replicaB := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: true},
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-2", Health: false},
+	}
+	tests := []struct {
+		name        string
+		results     [][]autoapprovev1alpha1.WorkloadMetric
+		mergePolicy autoapprovev1alpha1.ReplicaMergePolicy
+		want        []autoapprovev1alpha1.WorkloadMetric
+	}{
+		{
+			name:    "single replica",
+			results: [][]autoapprovev1alpha1.WorkloadMetric{replicaB},
+			want:    replicaB,
+		},
+		{
+			name:        "any",
+			results:     [][]autoapprovev1alpha1.WorkloadMetric{replicaA, replicaB},
+			mergePolicy: autoapprovev1alpha1.ReplicaMergePolicyAny,
+			want: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: true},
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-2", Health: false},
+			},
+		},
+		{
+			name:    "unset defaults to any",
+			results: [][]autoapprovev1alpha1.WorkloadMetric{replicaA, replicaB},
+			want: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: true},
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-2", Health: false},
+			},
+		},
+		{
+			name:        "all",
+			results:     [][]autoapprovev1alpha1.WorkloadMetric{replicaB, replicaA},
+			mergePolicy: autoapprovev1alpha1.ReplicaMergePolicyAll,
+			want: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false},
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-2", Health: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeReplicaMetrics(tt.results, tt.mergePolicy)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mergeReplicaMetrics() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseHealthValue(t *testing.T) {
+	tests := []struct {
+		value      string
+		wantHealth bool
+		wantReason string
+		wantErr    bool
+	}{
+		{value: "1", wantHealth: true},
+		{value: "1.0000001", wantHealth: true},
+		{value: "0", wantHealth: false},
+		{value: "0.5", wantHealth: false},
+		{value: "NaN", wantReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNaN},
+		{value: "+Inf", wantReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInfinite},
+		{value: "-Inf", wantReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInfinite},
+		{value: "healthy", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			health, reason, err := parseHealthValue(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseHealthValue(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if health != tt.wantHealth || reason != tt.wantReason {
+				t.Errorf("parseHealthValue(%q) = %v, %q, want %v, %q", tt.value, health, reason, tt.wantHealth, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestQueryDesiredReplicas(t *testing.T) {
+	tests := []struct {
+		value        string
+		wantReplicas int32
+		wantErr      bool
+	}{
+		{value: "3", wantReplicas: 3},
+		{value: "0", wantReplicas: 0},
+		{value: "NaN", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "+Inf", wantErr: true},
+		{value: "3abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			prom := newTestPrometheus(t, []PrometheusResult{{
+				Metric: map[string]string{"namespace": "app-ns", "deployment": "app"},
+				Value:  []interface{}{float64(1735689600), tt.value},
+			}})
+			replicas, found, err := queryDesiredReplicas(context.Background(), NewPrometheusClient(prom.URL, "", nil), "kube_deployment_spec_replicas")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("queryDesiredReplicas() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if found != !tt.wantErr || replicas != tt.wantReplicas {
+				t.Errorf("queryDesiredReplicas() = %d, %v, want %d, %v", replicas, found, tt.wantReplicas, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSummarizeHealthyMetrics(t *testing.T) {
+	metrics := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-0", Health: true},
+		{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-1", Health: false},
+		// A pod with several series is healthy if any of them is
+		{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-2", Health: false},
+		{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-2", Health: true},
+		// The same pod name in another namespace is another pod
+		{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-0", Health: true},
+	}
+	wantUnhealthy := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-1", Health: false},
+	}
+	wantHealthy := []autoapprovev1alpha1.WorkloadHealthyCount{
+		{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", TotalHealthy: 2},
+		{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "Deployment", TotalHealthy: 1},
+	}
+
+	gotUnhealthy, gotHealthy := summarizeHealthyMetrics(metrics)
+	if diff := cmp.Diff(wantUnhealthy, gotUnhealthy); diff != "" {
+		t.Errorf("summarizeHealthyMetrics() unhealthy metrics mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantHealthy, gotHealthy); diff != "" {
+		t.Errorf("summarizeHealthyMetrics() healthy counts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileHealthExpression(t *testing.T) {
+	const expression = `(workload_health == bool 1) * on(namespace, pod) (workload_ready == bool 1)`
+	prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1"), healthSeries("app-1", "0")})
+	report := newTestReport(prom.URL)
+	report.Spec.HealthExpression = expression
+	r := newTestReconciler(t, report)
+
+	got := reconcileReport(t, r)
+	if diff := cmp.Diff([]string{expression}, prom.receivedQueries()); diff != "" {
+		t.Errorf("Prometheus queries mismatch (-want +got):\n%s", diff)
+	}
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false, Value: "0"},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected) {
+		t.Errorf("MetricsCollected condition = %+v, want True", got.Status.Conditions)
+	}
+}
+
+func TestReconcileReportQueryReplacesHealthQueries(t *testing.T) {
+	tests := []struct {
+		name             string
+		queryTemplate    string
+		healthExpression string
+	}{
+		{name: "query template", queryTemplate: `workload_health{namespace="app-ns"}`},
+		{name: "health expression", healthExpression: `workload_health == bool 1`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1")})
+			tracker := &autoapprovev1alpha1.StagedWorkloadTracker{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-run", Namespace: "test-ns"},
+				Workloads: []autoapprovev1alpha1.WorkloadReference{{
+					Name: "app", Namespace: "app-ns", Kind: "Deployment", HealthyReplicas: 1,
+					HealthQuery: `kube_pod_status_ready{namespace="app-ns",condition="true"}`,
+				}},
+			}
+			report := newTestReport(prom.URL)
+			report.Labels = map[string]string{stageLabel: "canary"}
+			report.Spec.WorkloadTrackerRef = &autoapprovev1alpha1.WorkloadTrackerReference{
+				Kind:      autoapprovev1alpha1.StagedWorkloadTrackerKind,
+				Name:      tracker.Name,
+				Namespace: tracker.Namespace,
+			}
+			report.Spec.QueryTemplate = tt.queryTemplate
+			report.Spec.HealthExpression = tt.healthExpression
+			r := newTestReconciler(t, report, tracker)
+
+			got := reconcileReport(t, r)
+			if diff := cmp.Diff([]string{tt.queryTemplate + tt.healthExpression}, prom.receivedQueries()); diff != "" {
+				t.Errorf("Prometheus queries mismatch (-want +got):\n%s", diff)
+			}
+			want := []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+			}
+			if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+				t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileInvalidSpecResetsCollectedStatus(t *testing.T) {
+	prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1")})
+	tests := []struct {
+		name       string
+		setSpec    func(spec *autoapprovev1alpha1.MetricCollectorReportSpec)
+		wantReason string
+	}{
+		{
+			name:       "invalid Prometheus URL",
+			setSpec:    func(spec *autoapprovev1alpha1.MetricCollectorReportSpec) { spec.PrometheusURL = "ftp://prometheus" },
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonInvalidPrometheusURL,
+		},
+		{
+			name:       "invalid query template",
+			setSpec:    func(spec *autoapprovev1alpha1.MetricCollectorReportSpec) { spec.QueryTemplate = "{{.Unknown}}" },
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonInvalidQueryTemplate,
+		},
+		{
+			name: "health expression with query template",
+			setSpec: func(spec *autoapprovev1alpha1.MetricCollectorReportSpec) {
+				spec.QueryTemplate = `workload_health{cluster="{{.Cluster}}"}`
+				spec.HealthExpression = "workload_health == bool 1"
+			},
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonInvalidHealthExpression,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, newTestReport(prom.URL))
+			if got := reconcileReport(t, r); len(got.Status.CollectedMetrics) != 1 {
+				t.Fatalf("CollectedMetrics = %+v, want 1 metric", got.Status.CollectedMetrics)
+			}
+
+			report := &autoapprovev1alpha1.MetricCollectorReport{}
+			if err := r.HubClient.Get(context.Background(), testReportKey, report); err != nil {
+				t.Fatalf("failed to get MetricCollectorReport: %v", err)
+			}
+			tt.setSpec(&report.Spec)
+			if err := r.HubClient.Update(context.Background(), report); err != nil {
+				t.Fatalf("failed to update MetricCollectorReport: %v", err)
+			}
+
+			got := reconcileReport(t, r)
+			if len(got.Status.CollectedMetrics) != 0 || got.Status.WorkloadsMonitored != 0 || got.Status.ParseErrors != 0 {
+				t.Errorf("collected status = %d metrics, %d monitored, %d parse errors, want all cleared",
+					len(got.Status.CollectedMetrics), got.Status.WorkloadsMonitored, got.Status.ParseErrors)
+			}
+			wantCond := metav1.Condition{
+				Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: got.Generation,
+				Reason:             tt.wantReason,
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+			if diff := cmp.Diff(&wantCond, cond, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message")); diff != "" {
+				t.Errorf("MetricsCollected condition mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResetCollectedStatus(t *testing.T) {
+	now := metav1.Now()
+	report := &autoapprovev1alpha1.MetricCollectorReport{
+		Status: autoapprovev1alpha1.MetricCollectorReportStatus{
+			LastCollectionTime: &now,
+			CollectedMetrics:   []autoapprovev1alpha1.WorkloadMetric{{Namespace: "app-ns", WorkloadName: "app", PodName: "app-0"}},
+			HealthyWorkloads:   []autoapprovev1alpha1.WorkloadHealthyCount{{Namespace: "app-ns", WorkloadName: "app", TotalHealthy: 1}},
+			WorkloadsMonitored: 1,
+			ParseErrors:        2,
+		},
+	}
+	resetCollectedStatus(report)
+	want := autoapprovev1alpha1.MetricCollectorReportStatus{LastCollectionTime: &now}
+	if diff := cmp.Diff(want, report.Status); diff != "" {
+		t.Errorf("resetCollectedStatus() status mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileStrictResultType(t *testing.T) {
+	// A range selector in the query yields a matrix of the pod's recent samples, the latest of them healthy
+	matrixSeries := PrometheusResult{
+		Metric: healthSeries("app-0", "1").Metric,
+		Values: [][]interface{}{{float64(1735689540), "0"}, {float64(1735689600), "1"}},
+	}
+	tests := []struct {
+		name             string
+		resultType       string
+		result           []PrometheusResult
+		strictResultType bool
+		wantReason       string
+		wantMetrics      []autoapprovev1alpha1.WorkloadMetric
+	}{
+		{
+			name:             "vector in strict mode",
+			resultType:       prometheusResultTypeVector,
+			result:           []PrometheusResult{healthSeries("app-0", "1")},
+			strictResultType: true,
+			wantReason:       autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded,
+			wantMetrics: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+			},
+		},
+		{
+			name:             "matrix in strict mode",
+			resultType:       "matrix",
+			result:           []PrometheusResult{matrixSeries},
+			strictResultType: true,
+			wantReason:       autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionFailed,
+		},
+		{
+			name:       "matrix in lenient mode",
+			resultType: "matrix",
+			result:     []PrometheusResult{matrixSeries},
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded,
+			wantMetrics: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prom := newTestPrometheus(t, tt.result)
+			prom.resultType = tt.resultType
+			report := newTestReport(prom.URL)
+			report.Spec.StrictResultType = tt.strictResultType
+			r := newTestReconciler(t, report)
+
+			got := reconcileReport(t, r)
+			cond := meta.FindStatusCondition(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Fatalf("MetricsCollected condition = %+v, want reason %s", cond, tt.wantReason)
+			}
+			if tt.wantReason == autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionFailed && !strings.Contains(cond.Message, `"matrix"`) {
+				t.Errorf("MetricsCollected message = %q, want it to name the matrix result type", cond.Message)
+			}
+			if diff := cmp.Diff(tt.wantMetrics, got.Status.CollectedMetrics); diff != "" {
+				t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileSpecChangeClearsStaleCollection(t *testing.T) {
+	oldProm := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1"), healthSeries("app-1", "1")})
+	newProm := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "0")})
+
+	// Record the stored report after every status write to see the cleared status the reconcile writes before
+	// collecting again
+	var written []*autoapprovev1alpha1.MetricCollectorReport
+	c := newTestClientBuilder(t, newTestReport(oldProm.URL)).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			if err := c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...); err != nil {
+				return err
+			}
+			report := &autoapprovev1alpha1.MetricCollectorReport{}
+			if err := c.Get(ctx, testReportKey, report); err != nil {
+				return err
+			}
+			written = append(written, report)
+			return nil
+		},
+	}).Build()
+	r := &Reconciler{HubClient: c}
+
+	collected := reconcileReport(t, r)
+	if collected.Status.LastCollectionTime == nil || len(collected.Status.CollectedMetrics) != 2 {
+		t.Fatalf("status after the first collection = %+v, want 2 metrics and a collection time", collected.Status)
+	}
+
+	collected.Spec.PrometheusURL = newProm.URL
+	collected.Generation = 2
+	if err := c.Update(context.Background(), collected); err != nil {
+		t.Fatalf("failed to update MetricCollectorReport: %v", err)
+	}
+	written = nil
+	got := reconcileReport(t, r)
+
+	if len(written) != 2 {
+		t.Fatalf("status writes = %d, want 2 (clear, then collect)", len(written))
+	}
+	cleared := written[0]
+	if cleared.Status.LastCollectionTime != nil || len(cleared.Status.CollectedMetrics) != 0 || cleared.Status.WorkloadsMonitored != 0 {
+		t.Errorf("cleared status = %+v, want no collection time and no metrics", cleared.Status)
+	}
+	wantCleared := metav1.Condition{
+		Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: 1,
+		Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonSpecChanged,
+		Message:            "Spec changed from generation 1, collecting metrics for generation 2",
+	}
+	cond := meta.FindStatusCondition(cleared.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+	if diff := cmp.Diff(&wantCleared, cond, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+		t.Errorf("cleared MetricsCollected condition mismatch (-want +got):\n%s", diff)
+	}
+
+	// The same reconcile collects the new spec right away
+	if n := len(newProm.receivedQueries()); n == 0 {
+		t.Errorf("new Prometheus queries = %d, want at least 1", n)
+	}
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: false, Value: "0"},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+	cond = meta.FindStatusCondition(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != 2 {
+		t.Errorf("MetricsCollected condition = %+v, want True for generation 2", cond)
+	}
+}
+
+func TestReconcileStatusUpdateFailure(t *testing.T) {
+	gr := autoapprovev1alpha1.GroupVersion.WithResource("metriccollectorreports").GroupResource()
+	tests := []struct {
+		name       string
+		err        error
+		wantResult ctrl.Result
+		wantErr    bool
+	}{
+		{
+			name:       "conflict",
+			err:        apierrors.NewConflict(gr, testReportName, errors.New("object was modified")),
+			wantResult: ctrl.Result{RequeueAfter: statusUpdateRetryInterval},
+		},
+		{
+			name:       "throttled",
+			err:        apierrors.NewTooManyRequests("slow down", 1),
+			wantResult: ctrl.Result{RequeueAfter: statusUpdateRetryInterval},
+		},
+		{
+			name:    "invalid",
+			err:     apierrors.NewInvalid(autoapprovev1alpha1.GroupVersion.WithKind("MetricCollectorReport").GroupKind(), testReportName, nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1")})
+			// Fail the first status write only, as a transient failure would
+			failed := false
+			c := newTestClientBuilder(t, newTestReport(prom.URL)).WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if !failed {
+						failed = true
+						return tt.err
+					}
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+			r := &Reconciler{HubClient: c}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: testReportKey})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantResult, result); diff != "" {
+				t.Errorf("Reconcile() result mismatch (-want +got):\n%s", diff)
+			}
+
+			// The retry collects into the report
+			got := reconcileReport(t, r)
+			if len(got.Status.CollectedMetrics) != 1 {
+				t.Errorf("CollectedMetrics after the retry = %v, want 1 metric", got.Status.CollectedMetrics)
+			}
+		})
+	}
+}
+
+func TestReconcileHealthStateMapping(t *testing.T) {
+	stateSeries := func(pod, state, value string) PrometheusResult {
+		series := healthSeries(pod, value)
+		series.Metric["state"] = state
+		return series
+	}
+	prom := newTestPrometheus(t, []PrometheusResult{
+		// Info-style series, one per pod
+		stateSeries("app-0", "Running", "1"),
+		stateSeries("app-1", "Pending", "1"),
+		// A state set, one series per state of which only the current one is 1
+		stateSeries("app-2", "Running", "0"),
+		stateSeries("app-2", "Failed", "1"),
+	})
+	report := newTestReport(prom.URL)
+	report.Spec.HealthStateMapping = &autoapprovev1alpha1.HealthStateMapping{Label: "state", HealthyValues: []string{"Running", "Succeeded"}}
+	r := newTestReconciler(t, report)
+
+	got := reconcileReport(t, r)
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false, Value: "1"},
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-2", Health: false, Value: "1"},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+	if got.Status.ParseErrors != 0 {
+		t.Errorf("ParseErrors = %d, want 0", got.Status.ParseErrors)
+	}
+}
+
+func TestReconcileAppliesOwnedStatusWithCap(t *testing.T) {
+	prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1"), healthSeries("app-1", "0"), healthSeries("app-2", "1")})
+	r := &Reconciler{
+		HubClient:           newTestClientBuilder(t, newTestReport(prom.URL)).WithReturnManagedFields().Build(),
+		MaxCollectedMetrics: 2,
+	}
+
+	// The approval controller writes BlockingWorkloads. The fake client has no schema for the report, so it merges
+	// conditions as an atomic list and the conditions of other writers cannot be checked here.
+	report := &autoapprovev1alpha1.MetricCollectorReport{}
+	if err := r.HubClient.Get(context.Background(), testReportKey, report); err != nil {
+		t.Fatalf("failed to get MetricCollectorReport: %v", err)
+	}
+	blocking := []autoapprovev1alpha1.BlockingWorkload{{Cluster: "member-1", Namespace: "app-ns", WorkloadName: "app"}}
+	report.Status.BlockingWorkloads = blocking
+	if err := r.HubClient.Status().Update(context.Background(), report); err != nil {
+		t.Fatalf("failed to update MetricCollectorReport status: %v", err)
+	}
+
+	got := reconcileReport(t, r)
+	// Metrics of healthy pods are dropped first
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false, Value: "0"},
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+	if cond == nil || cond.Status != metav1.ConditionTrue || !strings.Contains(cond.Message, "1 metrics of healthy pods were dropped") {
+		t.Errorf("MetricsCollected condition = %+v, want True reporting 1 dropped metric", cond)
+	}
+
+	if diff := cmp.Diff(blocking, got.Status.BlockingWorkloads); diff != "" {
+		t.Errorf("BlockingWorkloads mismatch (-want +got):\n%s", diff)
+	}
+	var applied bool
+	for _, entry := range got.ManagedFields {
+		if entry.Manager == fieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			applied = true
+		}
+	}
+	if !applied {
+		t.Errorf("managed fields = %+v, want an apply by %s", got.ManagedFields, fieldManager)
+	}
+}
+
+func TestReconcileReplacesCollectedMetrics(t *testing.T) {
+	prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1"), healthSeries("app-1", "1")})
+	r := newTestReconciler(t, newTestReport(prom.URL))
+	if got := reconcileReport(t, r); len(got.Status.CollectedMetrics) != 2 {
+		t.Fatalf("CollectedMetrics after the first collection = %v, want 2 metrics", got.Status.CollectedMetrics)
+	}
+
+	// app-1 was deleted and its series is gone from Prometheus
+	prom.setResult([]PrometheusResult{healthSeries("app-0", "1")})
+	got := reconcileReport(t, r)
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+	if got.Status.WorkloadsMonitored != 1 {
+		t.Errorf("WorkloadsMonitored = %d, want 1", got.Status.WorkloadsMonitored)
+	}
+
+	// Every workload is gone
+	prom.setResult(nil)
+	if got := reconcileReport(t, r); len(got.Status.CollectedMetrics) != 0 {
+		t.Errorf("CollectedMetrics without series = %v, want none", got.Status.CollectedMetrics)
+	}
+}
+
+func TestAppendCollectionSummary(t *testing.T) {
+	summaries := func(workloads ...int32) []autoapprovev1alpha1.CollectionSummary {
+		var history []autoapprovev1alpha1.CollectionSummary
+		for _, n := range workloads {
+			history = append(history, autoapprovev1alpha1.CollectionSummary{
+				Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded,
+				WorkloadsMonitored: n,
+			})
+		}
+		return history
+	}
+	tests := []struct {
+		name    string
+		history []autoapprovev1alpha1.CollectionSummary
+		limit   int
+		want    []autoapprovev1alpha1.CollectionSummary
+	}{
+		{
+			name:  "first collection",
+			limit: 3,
+			want:  summaries(1),
+		},
+		{
+			name:    "below the limit",
+			history: summaries(3, 2),
+			limit:   3,
+			want:    summaries(3, 2, 1),
+		},
+		{
+			name:    "at the limit drops the oldest",
+			history: summaries(4, 3, 2),
+			limit:   3,
+			want:    summaries(3, 2, 1),
+		},
+		{
+			name:    "above a lowered limit",
+			history: summaries(5, 4, 3, 2),
+			limit:   2,
+			want:    summaries(2, 1),
+		},
+		{
+			name:    "disabled",
+			history: summaries(3, 2),
+			limit:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appendCollectionSummary(tt.history, summaries(1)[0], tt.limit)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("appendCollectionSummary() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileTrimsRecentCollections(t *testing.T) {
+	prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1")})
+	r := newTestReconciler(t, newTestReport(prom.URL))
+	r.RecentCollectionsLimit = 2
+
+	var got *autoapprovev1alpha1.MetricCollectorReport
+	for i := 0; i < 3; i++ {
+		got = reconcileReport(t, r)
+	}
+	if n := len(got.Status.RecentCollections); n != 2 {
+		t.Fatalf("RecentCollections = %d entries, want 2", n)
+	}
+	for _, summary := range got.Status.RecentCollections {
+		if summary.Reason != autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded || summary.WorkloadsMonitored != 1 {
+			t.Errorf("collection summary = %+v, want a successful collection of 1 workload", summary)
+		}
+	}
+}
+
+func TestReconcileHealthMetricNames(t *testing.T) {
+	series := func(metricName, app, pod, value string) PrometheusResult {
+		result := healthSeries(pod, value)
+		result.Metric[metricNameLabel] = metricName
+		result.Metric["app"] = app
+		return result
+	}
+	prom := newTestPrometheus(t, []PrometheusResult{
+		// app emits both candidates and is judged by the preferred workload_health
+		series("workload_health", "app", "app-0", "1"),
+		series("app_up", "app", "app-0", "0"),
+		// legacy only emits the alternate app_up
+		series("app_up", "legacy", "legacy-0", "1"),
+	})
+	report := newTestReport(prom.URL)
+	report.Spec.HealthMetricNames = []string{"workload_health", "app_up"}
+	r := newTestReconciler(t, report)
+
+	got := reconcileReport(t, r)
+	if diff := cmp.Diff([]string{"workload_health or app_up"}, prom.receivedQueries()); diff != "" {
+		t.Errorf("Prometheus queries mismatch (-want +got):\n%s", diff)
+	}
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1", HealthMetric: "workload_health"},
+		{Namespace: "app-ns", WorkloadName: "legacy", WorkloadKind: "Deployment", PodName: "legacy-0", Health: true, Value: "1", HealthMetric: "app_up"},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildPromQLQueryHealthMetricNames(t *testing.T) {
+	workloads := []autoapprovev1alpha1.WorkloadReference{{Name: "app", Namespace: "app-ns", Kind: "Deployment"}}
+	tests := []struct {
+		name        string
+		workloads   []autoapprovev1alpha1.WorkloadReference
+		metricNames []string
+		want        string
+	}{
+		{
+			name:      "default metric",
+			workloads: workloads,
+			want:      `workload_health{namespace="app-ns",app="app",workload_kind="Deployment"}`,
+		},
+		{
+			name:        "candidate metrics",
+			workloads:   workloads,
+			metricNames: []string{"workload_health", "app_up"},
+			want:        `workload_health{namespace="app-ns",app="app",workload_kind="Deployment"} or app_up{namespace="app-ns",app="app",workload_kind="Deployment"}`,
+		},
+		{
+			name:        "candidate metrics without workloads",
+			metricNames: []string{"workload_health", "app_up"},
+			want:        "workload_health or app_up",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildPromQLQuery(tt.workloads, tt.metricNames); got != tt.want {
+				t.Errorf("buildPromQLQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileAggregatedSeries(t *testing.T) {
+	aggregated := healthSeries("", "1")
+	delete(aggregated.Metric, "pod")
+	tests := []struct {
+		name                  string
+		allowAggregatedSeries bool
+		want                  []autoapprovev1alpha1.WorkloadMetric
+	}{
+		{
+			name: "skipped by default",
+			want: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+			},
+		},
+		{
+			name:                  "accepted",
+			allowAggregatedSeries: true,
+			want: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: autoapprovev1alpha1.AggregatedPodName, Health: true, Value: "1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1"), aggregated})
+			report := newTestReport(prom.URL)
+			report.Spec.AllowAggregatedSeries = tt.allowAggregatedSeries
+			r := newTestReconciler(t, report)
+
+			got := reconcileReport(t, r)
+			if diff := cmp.Diff(tt.want, got.Status.CollectedMetrics); diff != "" {
+				t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileRequireMetricExists(t *testing.T) {
+	isExistenceQuery := func(query string) bool {
+		return strings.Contains(query, "__name__")
+	}
+	tests := []struct {
+		name                string
+		requireMetricExists bool
+		metricExists        bool
+		wantStatus          metav1.ConditionStatus
+		wantReason          string
+		wantExistenceQuery  bool
+	}{
+		{
+			name:       "not required",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded,
+		},
+		{
+			name:                "metric exists without matching series",
+			requireMetricExists: true,
+			metricExists:        true,
+			wantStatus:          metav1.ConditionTrue,
+			wantReason:          autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded,
+			wantExistenceQuery:  true,
+		},
+		{
+			name:                "metric absent",
+			requireMetricExists: true,
+			wantStatus:          metav1.ConditionFalse,
+			wantReason:          autoapprovev1alpha1.MetricCollectorReportConditionReasonMetricNotFound,
+			wantExistenceQuery:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prom := newTestPrometheus(t, nil)
+			prom.resultFor = func(query string) []PrometheusResult {
+				if tt.metricExists && isExistenceQuery(query) {
+					return []PrometheusResult{{Metric: map[string]string{"__name__": workloadHealthMetric}, Value: []interface{}{float64(1735689600), "1"}}}
+				}
+				return nil
+			}
+			report := newTestReport(prom.URL)
+			report.Spec.RequireMetricExists = tt.requireMetricExists
+			r := newTestReconciler(t, report)
+
+			got := reconcileReport(t, r)
+			cond := meta.FindStatusCondition(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+			if cond == nil || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("MetricsCollected condition = %+v, want %s with reason %s", cond, tt.wantStatus, tt.wantReason)
+			}
+			if gotExistenceQuery := slices.ContainsFunc(prom.receivedQueries(), isExistenceQuery); gotExistenceQuery != tt.wantExistenceQuery {
+				t.Errorf("existence query sent = %v, want %v (queries %v)", gotExistenceQuery, tt.wantExistenceQuery, prom.receivedQueries())
+			}
+		})
+	}
+}
+
+func TestReconcileMinPodUptime(t *testing.T) {
+	uptimeSeries := func(pod, value string) PrometheusResult {
+		return PrometheusResult{
+			Metric: map[string]string{"namespace": "app-ns", "pod": pod},
+			Value:  []interface{}{float64(1735689600), value},
+		}
+	}
+	prom := newTestPrometheus(t, nil)
+	prom.resultFor = func(query string) []PrometheusResult {
+		if strings.Contains(query, "app_start_time_seconds") {
+			// app-2 has no start time series
+			return []PrometheusResult{uptimeSeries("app-0", "600"), uptimeSeries("app-1", "60"), uptimeSeries("app-3", "600")}
+		}
+		return []PrometheusResult{healthSeries("app-0", "1"), healthSeries("app-1", "1"), healthSeries("app-2", "1"), healthSeries("app-3", "0")}
+	}
+	report := newTestReport(prom.URL)
+	report.Spec.MinPodUptime = &metav1.Duration{Duration: 5 * time.Minute}
+	report.Spec.PodStartTimeMetric = "app_start_time_seconds"
+	r := newTestReconciler(t, report)
+
+	got := reconcileReport(t, r)
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1", UptimeSeconds: ptr.To[int64](600)},
+		{
+			Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false, Value: "1", UptimeSeconds: ptr.To[int64](60),
+			UnhealthyReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInsufficientUptime,
+		},
+		{
+			Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-2", Health: false, Value: "1",
+			UnhealthyReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInsufficientUptime,
+		},
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-3", Health: false, Value: "0", UptimeSeconds: ptr.To[int64](600)},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+	if want := `time() - max by (namespace, pod) (app_start_time_seconds{namespace=~"app-ns"})`; !slices.Contains(prom.receivedQueries(), want) {
+		t.Errorf("Prometheus queries = %v, want the uptime query %s", prom.receivedQueries(), want)
+	}
+}
+
+func TestReconcileMinPodUptimeQueryFailure(t *testing.T) {
+	prom := newTestPrometheus(t, nil)
+	prom.resultFor = func(query string) []PrometheusResult {
+		if strings.Contains(query, "process_start_time_seconds") {
+			// An uptime that is not a number fails the uptime query
+			return []PrometheusResult{{Metric: map[string]string{"namespace": "app-ns", "pod": "app-0"}, Value: []interface{}{float64(1735689600), "soon"}}}
+		}
+		return []PrometheusResult{healthSeries("app-0", "1")}
+	}
+	report := newTestReport(prom.URL)
+	report.Spec.MinPodUptime = &metav1.Duration{Duration: 5 * time.Minute}
+	r := newTestReconciler(t, report)
+
+	got := reconcileReport(t, r)
+	cond := meta.FindStatusCondition(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded {
+		t.Errorf("MetricsCollected condition = %+v, want True with reason %s", cond, autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionSucceeded)
+	}
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{
+			Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: false, Value: "1",
+			UnhealthyReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInsufficientUptime,
+		},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileCountsMalformedSamples(t *testing.T) {
+	withValue := func(pod string, value []interface{}) PrometheusResult {
+		series := healthSeries(pod, "")
+		series.Value = value
+		return series
+	}
+	prom := newTestPrometheus(t, []PrometheusResult{
+		healthSeries("app-0", "1"),
+		healthSeries("app-1", "0"),
+		withValue("app-2", []interface{}{}),
+		withValue("app-3", []interface{}{float64(1735689600)}),
+		healthSeries("app-4", "not-a-number"),
+	})
+	r := newTestReconciler(t, newTestReport(prom.URL))
+
+	got := reconcileReport(t, r)
+	// Malformed series are skipped rather than counted as unhealthy pods, unlike a legitimate 0
+	want := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, Value: "1"},
+		{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false, Value: "0"},
+	}
+	if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+		t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+	}
+	if got.Status.ParseErrors != 3 {
+		t.Errorf("ParseErrors = %d, want 3", got.Status.ParseErrors)
+	}
+}
+
+func TestReconcileReadyCondition(t *testing.T) {
+	prom := newTestPrometheus(t, []PrometheusResult{healthSeries("app-0", "1")})
+	// A matrix result fails collection of a report with StrictResultType set
+	setFailing := func(failing bool) {
+		prom.mu.Lock()
+		defer prom.mu.Unlock()
+		prom.resultType = ""
+		if failing {
+			prom.resultType = "matrix"
+		}
+	}
+	report := newTestReport(prom.URL)
+	report.Spec.StrictResultType = true
+	r := newTestReconciler(t, report)
+	r.ReadyFailureThreshold = 2
+
+	steps := []struct {
+		failing                 bool
+		wantConsecutiveFailures int32
+		wantStatus              metav1.ConditionStatus
+		wantReason              string
+	}{
+		{wantStatus: metav1.ConditionTrue, wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollecting},
+		{failing: true, wantConsecutiveFailures: 1, wantStatus: metav1.ConditionTrue, wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollecting},
+		{failing: true, wantConsecutiveFailures: 2, wantStatus: metav1.ConditionFalse, wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonSustainedCollectionFailures},
+		{failing: true, wantConsecutiveFailures: 3, wantStatus: metav1.ConditionFalse, wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonSustainedCollectionFailures},
+		{wantStatus: metav1.ConditionTrue, wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollecting},
+	}
+	for i, step := range steps {
+		setFailing(step.failing)
+		got := reconcileReport(t, r)
+		if got.Status.ConsecutiveFailures != step.wantConsecutiveFailures {
+			t.Errorf("collection %d: ConsecutiveFailures = %d, want %d", i, got.Status.ConsecutiveFailures, step.wantConsecutiveFailures)
+		}
+		cond := meta.FindStatusCondition(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeReady)
+		if cond == nil || cond.Status != step.wantStatus || cond.Reason != step.wantReason {
+			t.Errorf("collection %d: Ready condition = %+v, want %s with reason %s", i, cond, step.wantStatus, step.wantReason)
+		}
+	}
+}
+
+func TestSLOHealth(t *testing.T) {
+	threshold := resource.MustParse("500m")
+	tests := []struct {
+		value       string
+		wantHealthy bool
+		wantReason  string
+		wantErr     bool
+	}{
+		{value: "0.42", wantHealthy: true},
+		{value: "0.5", wantHealthy: true},
+		{value: "0.73", wantReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonSLOThresholdExceeded},
+		{value: "NaN", wantReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNaN},
+		{value: "+Inf", wantReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonInfinite},
+		{value: "fast", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			healthy, reason, err := sloHealth(tt.value, &threshold)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("sloHealth() error = %v, want error %v", err, tt.wantErr)
+			}
+			if healthy != tt.wantHealthy || reason != tt.wantReason {
+				t.Errorf("sloHealth() = (%v, %q), want (%v, %q)", healthy, reason, tt.wantHealthy, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestReconcileSLOQuery(t *testing.T) {
+	const sloQuery = `histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket{namespace="app-ns",app="app"}[5m])))`
+	tests := []struct {
+		name       string
+		quantile   string
+		wantHealth bool
+		wantReason string
+	}{
+		{name: "p99 within the SLO", quantile: "0.42", wantHealth: true},
+		{name: "p99 above the SLO", quantile: "0.73", wantReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonSLOThresholdExceeded},
+		{name: "no traffic", quantile: "NaN", wantReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNaN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prom := newTestPrometheus(t, nil)
+			// histogram_quantile aggregates the pods away, leaving a single series without a pod label
+			prom.resultFor = func(query string) []PrometheusResult {
+				if query != sloQuery {
+					return nil
+				}
+				return []PrometheusResult{{Metric: map[string]string{}, Value: []interface{}{float64(1735689600), tt.quantile}}}
+			}
+			tracker := &autoapprovev1alpha1.StagedWorkloadTracker{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-run", Namespace: "test-ns"},
+				Workloads: []autoapprovev1alpha1.WorkloadReference{{
+					Name: "app", Namespace: "app-ns", Kind: "Deployment", HealthyReplicas: 1,
+					SLOQuery: sloQuery, SLOThreshold: ptr.To(resource.MustParse("500m")),
+				}},
+			}
+			report := newTestReport(prom.URL)
+			report.Labels = map[string]string{stageLabel: "canary"}
+			report.Spec.WorkloadTrackerRef = &autoapprovev1alpha1.WorkloadTrackerReference{
+				Kind:      autoapprovev1alpha1.StagedWorkloadTrackerKind,
+				Name:      tracker.Name,
+				Namespace: tracker.Namespace,
+			}
+			r := newTestReconciler(t, report, tracker)
+
+			got := reconcileReport(t, r)
+			if !slices.Contains(prom.receivedQueries(), sloQuery) {
+				t.Errorf("queries = %q, want the sloQuery among them", prom.receivedQueries())
+			}
+			want := []autoapprovev1alpha1.WorkloadMetric{{
+				Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: autoapprovev1alpha1.AggregatedPodName,
+				Health: tt.wantHealth, UnhealthyReason: tt.wantReason, Value: tt.quantile,
+			}}
+			if diff := cmp.Diff(want, got.Status.CollectedMetrics); diff != "" {
+				t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
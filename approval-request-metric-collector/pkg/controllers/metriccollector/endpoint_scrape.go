@@ -0,0 +1,292 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+const (
+	// scrapeTimeout bounds the scrape of a single endpoint.
+	scrapeTimeout = 10 * time.Second
+
+	// maxScrapeBytes caps the size of a scraped response, so that a misbehaving exporter cannot exhaust memory.
+	maxScrapeBytes = 16 << 20
+
+	// scrapePortName is the EndpointSlice port scraped when a slice has several ports.
+	scrapePortName = "metrics"
+
+	// scrapeWorkloadLabel is the pod label holding the workload name, which the example Prometheus configuration
+	// relabels to the app label of the series.
+	scrapeWorkloadLabel = "app"
+)
+
+// collectScrapedMetrics reads the health metrics by scraping the /metrics endpoint of every pod behind the
+// EndpointSlices matching ScrapeEndpointSelector on the member cluster, instead of querying Prometheus. The
+// namespace, pod and app labels of the series are set from the pod, as the Prometheus relabeling would, and the
+// series are then converted like a Prometheus result. Terminating endpoints are skipped, but endpoints that are not
+// ready are scraped, so that an unhealthy pod failing its readiness probe is not simply left out. If any endpoint
+// cannot be scraped, the collection fails, so that a pod whose health is unknown never leads to approval. If minUptime
+// is set, pods that have not been up that long according to their start time are unhealthy, as with Prometheus.
+func (r *Reconciler) collectScrapedMetrics(
+	ctx context.Context,
+	metricNames []string,
+	workloadKinds []string,
+	extraLabelKeys []string,
+	healthStateMapping *autoapprovev1alpha1.HealthStateMapping,
+	allowAggregatedSeries bool,
+	minUptime *metav1.Duration,
+) ([]autoapprovev1alpha1.WorkloadMetric, int, error) {
+	sliceList := &discoveryv1.EndpointSliceList{}
+	if err := r.EndpointReader.List(ctx, sliceList, client.MatchingLabelsSelector{Selector: r.ScrapeEndpointSelector}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list EndpointSlices matching %q: %w", r.ScrapeEndpointSelector, err)
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range healthMetricNamesOrDefault(metricNames) {
+		wanted[name] = true
+	}
+
+	var series []PrometheusResult
+	var parseErrors int
+	var errs []error
+	// A pod behind several Services appears in the slices of each of them, but is scraped once
+	scraped := make(map[types.NamespacedName]bool)
+	uptimes := make(map[types.NamespacedName]float64)
+	for i := range sliceList.Items {
+		slice := &sliceList.Items[i]
+		port, ok := scrapePort(slice)
+		if !ok {
+			klog.V(2).InfoS("Skipping EndpointSlice without a port to scrape", "namespace", slice.Namespace, "endpointSlice", slice.Name, "portName", scrapePortName)
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if len(endpoint.Addresses) == 0 || endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+				continue
+			}
+			if endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating {
+				continue
+			}
+			podKey := types.NamespacedName{Namespace: slice.Namespace, Name: endpoint.TargetRef.Name}
+			if scraped[podKey] {
+				continue
+			}
+			scraped[podKey] = true
+
+			pod := &corev1.Pod{}
+			if err := r.EndpointReader.Get(ctx, podKey, pod); err != nil {
+				if !errors.IsNotFound(err) {
+					errs = append(errs, fmt.Errorf("failed to get pod %s: %w", podKey, err))
+				}
+				continue
+			}
+			if pod.Status.StartTime != nil {
+				uptimes[podKey] = time.Since(pod.Status.StartTime.Time).Seconds()
+			}
+			target := "http://" + net.JoinHostPort(endpoint.Addresses[0], strconv.Itoa(int(port))) + "/metrics"
+			podSeries, podParseErrors, err := r.scrapeEndpoint(ctx, target, wanted)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("pod %s: %w", podKey, err))
+				continue
+			}
+			parseErrors += podParseErrors
+			for _, res := range podSeries {
+				// Like Prometheus without honor_labels, the labels of the target win over those of the exporter
+				res.Metric["namespace"] = pod.Namespace
+				res.Metric["pod"] = pod.Name
+				res.Metric[scrapeWorkloadLabel] = pod.Labels[scrapeWorkloadLabel]
+				series = append(series, res)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, 0, fmt.Errorf("failed to scrape %d endpoints: %w", len(errs), utilerrors.NewAggregate(errs))
+	}
+
+	collectedMetrics, seriesParseErrors := workloadMetricsFromSeries(series, workloadKinds, extraLabelKeys, healthStateMapping, allowAggregatedSeries)
+	if minUptime != nil {
+		applyMinPodUptime(collectedMetrics, uptimes, minUptime.Duration)
+	}
+	klog.V(2).InfoS("Collected workload metrics by scraping endpoints", "endpoints", len(scraped), "count", len(collectedMetrics), "parseErrors", parseErrors+seriesParseErrors)
+	return collectedMetrics, parseErrors + seriesParseErrors, nil
+}
+
+// scrapePort returns the port of the EndpointSlice to scrape: the one named metrics, or the only port of the slice.
+func scrapePort(slice *discoveryv1.EndpointSlice) (int32, bool) {
+	for _, port := range slice.Ports {
+		if port.Port != nil && port.Name != nil && *port.Name == scrapePortName {
+			return *port.Port, true
+		}
+	}
+	if len(slice.Ports) == 1 && slice.Ports[0].Port != nil {
+		return *slice.Ports[0].Port, true
+	}
+	return 0, false
+}
+
+// scrapeEndpoint scrapes the metrics of a single endpoint and returns the samples of the wanted metrics as series
+// of an instant vector, along with the number of their lines that could not be parsed.
+func (r *Reconciler) scrapeEndpoint(ctx context.Context, target string, wanted map[string]bool) ([]PrometheusResult, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	// Ask for the text format; the OpenMetrics and protobuf formats are not parsed
+	req.Header.Set("Accept", "text/plain;version=0.0.4")
+	userAgent := r.PrometheusUserAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent()
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scrape %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("scraping %s failed with status %d", target, resp.StatusCode)
+	}
+	series, parseErrors, err := parseTextSamples(io.LimitReader(resp.Body, maxScrapeBytes), wanted, time.Now())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read %s: %w", target, err)
+	}
+	return series, parseErrors, nil
+}
+
+// parseTextSamples parses the samples of the wanted metrics from the Prometheus text exposition format, returning
+// them as series of an instant vector taken at now. The metric name is set as the __name__ label. Lines of the wanted
+// metrics that cannot be parsed are skipped and counted; all other lines are ignored.
+func parseTextSamples(body io.Reader, wanted map[string]bool, now time.Time) ([]PrometheusResult, int, error) {
+	timestamp := float64(now.UnixNano()) / float64(time.Second)
+	var series []PrometheusResult
+	var parseErrors int
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxScrapeBytes)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		nameEnd := strings.IndexAny(line, "{ \t")
+		if nameEnd == -1 {
+			nameEnd = len(line)
+		}
+		name := line[:nameEnd]
+		if !wanted[name] {
+			continue
+		}
+		seriesLabels, value, err := parseTextSample(line[nameEnd:])
+		if err != nil {
+			klog.V(2).InfoS("Skipping malformed sample", "metric", name, "line", line, "err", err)
+			parseErrors++
+			continue
+		}
+		seriesLabels[metricNameLabel] = name
+		series = append(series, PrometheusResult{
+			Metric: seriesLabels,
+			Value:  []interface{}{timestamp, value},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	return series, parseErrors, nil
+}
+
+// parseTextSample parses the labels and value of a sample line following the metric name, e.g.
+// {workload_kind="Deployment"} 1 1700000000000. The optional timestamp is ignored.
+func parseTextSample(rest string) (map[string]string, string, error) {
+	seriesLabels := make(map[string]string)
+	if strings.HasPrefix(rest, "{") {
+		rest = rest[1:]
+		for {
+			rest = strings.TrimLeft(rest, " \t")
+			if strings.HasPrefix(rest, "}") {
+				rest = rest[1:]
+				break
+			}
+			key, afterKey, ok := strings.Cut(rest, "=")
+			key = strings.TrimSpace(key)
+			if !ok || key == "" {
+				return nil, "", fmt.Errorf("malformed label in %q", rest)
+			}
+			value, afterValue, err := parseLabelValue(strings.TrimLeft(afterKey, " \t"))
+			if err != nil {
+				return nil, "", err
+			}
+			seriesLabels[key] = value
+			rest = strings.TrimLeft(afterValue, " \t")
+			rest = strings.TrimPrefix(rest, ",")
+		}
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, "", fmt.Errorf("expected a value and an optional timestamp, got %q", rest)
+	}
+	return seriesLabels, fields[0], nil
+}
+
+// parseLabelValue parses a double-quoted label value with the \\, \" and \n escapes of the text format, returning
+// the value and the rest of the line after the closing quote.
+func parseLabelValue(s string) (string, string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", "", fmt.Errorf("label value in %q is not quoted", s)
+	}
+	var value strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return value.String(), s[i+1:], nil
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", "", fmt.Errorf("unterminated label value in %q", s)
+			}
+			switch s[i] {
+			case 'n':
+				value.WriteByte('\n')
+			default:
+				value.WriteByte(s[i])
+			}
+		default:
+			value.WriteByte(s[i])
+		}
+	}
+	return "", "", fmt.Errorf("unterminated label value in %q", s)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+func TestParseTextSamples(t *testing.T) {
+	now := time.Unix(1735689600, 0)
+	body := strings.Join([]string{
+		"# HELP workload_health Health of the workload.",
+		"# TYPE workload_health gauge",
+		`workload_health{workload_kind="Deployment",note="a \"quoted\" value"} 1 1735689600000`,
+		`workload_health{workload_kind="Deployment",} 0`,
+		`workload_health{workload_kind=Deployment} 1`,
+		`workload_health{workload_kind="Deployment"} 1 2 3`,
+		"workload_health 1",
+		`process_start_time_seconds{workload_kind="Deployment"} 1700000000`,
+		"",
+	}, "\n")
+	got, parseErrors, err := parseTextSamples(strings.NewReader(body), map[string]bool{workloadHealthMetric: true}, now)
+	if err != nil {
+		t.Fatalf("parseTextSamples() error = %v, want nil", err)
+	}
+	want := []PrometheusResult{
+		{Metric: map[string]string{metricNameLabel: workloadHealthMetric, "workload_kind": "Deployment", "note": `a "quoted" value`}, Value: []interface{}{float64(1735689600), "1"}},
+		{Metric: map[string]string{metricNameLabel: workloadHealthMetric, "workload_kind": "Deployment"}, Value: []interface{}{float64(1735689600), "0"}},
+		{Metric: map[string]string{metricNameLabel: workloadHealthMetric}, Value: []interface{}{float64(1735689600), "1"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseTextSamples() mismatch (-want +got):\n%s", diff)
+	}
+	if parseErrors != 2 {
+		t.Errorf("parseTextSamples() parse errors = %d, want 2", parseErrors)
+	}
+}
+
+// newTestExporter returns the port of a server exposing body as its /metrics, failing with status if it is not 200.
+func newTestExporter(t *testing.T, status int, body string) int32 {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/metrics" || status != http.StatusOK {
+			http.Error(w, "unavailable", status)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to split the exporter address: %v", err)
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatalf("failed to parse the exporter port: %v", err)
+	}
+	return int32(n)
+}
+
+// newTestEndpointSlice returns a slice of app-ns selected by scrape=true with a single endpoint per pod, all at the
+// address of the test exporters.
+func newTestEndpointSlice(name string, ports []discoveryv1.EndpointPort, pods ...string) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Name: name, Namespace: "app-ns", Labels: map[string]string{"scrape": "true"}},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports:       ports,
+	}
+	for _, pod := range pods {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses: []string{"127.0.0.1"},
+			TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "app-ns", Name: pod},
+		})
+	}
+	return slice
+}
+
+func newTestPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app-ns", Labels: map[string]string{scrapeWorkloadLabel: "app"}}}
+}
+
+func TestCollectScrapedMetrics(t *testing.T) {
+	healthyPort := newTestExporter(t, http.StatusOK, "# TYPE workload_health gauge\nworkload_health{workload_kind=\"Deployment\",pod=\"ignored\"} 1\nother_metric 5\n")
+	unhealthyPort := newTestExporter(t, http.StatusOK, "workload_health{workload_kind=\"Deployment\"} 0\n")
+	failingPort := newTestExporter(t, http.StatusServiceUnavailable, "")
+
+	// slice-a exposes app-0 on its metrics port, next to another port, and app-2 which is terminating
+	sliceA := newTestEndpointSlice("slice-a", []discoveryv1.EndpointPort{
+		{Name: ptr.To("http"), Port: ptr.To(unhealthyPort)},
+		{Name: ptr.To(scrapePortName), Port: ptr.To(healthyPort)},
+	}, "app-0", "app-2")
+	sliceA.Endpoints[1].Conditions.Terminating = ptr.To(true)
+	// slice-b exposes app-1 on its only port, and app-0 again, which is not scraped twice
+	sliceB := newTestEndpointSlice("slice-b", []discoveryv1.EndpointPort{{Port: ptr.To(unhealthyPort)}}, "app-1", "app-0")
+	// unselected is not scraped, though its exporter fails
+	unselected := newTestEndpointSlice("unselected", []discoveryv1.EndpointPort{{Port: ptr.To(failingPort)}}, "app-3")
+	unselected.Labels = nil
+	// failing is only selected by the failure case
+	failing := newTestEndpointSlice("failing", []discoveryv1.EndpointPort{{Port: ptr.To(failingPort)}}, "app-4")
+	failing.Labels = map[string]string{"scrape": "failing"}
+	objs := []client.Object{sliceA, sliceB, unselected, failing, newTestPod("app-0"), newTestPod("app-1"), newTestPod("app-2"), newTestPod("app-3"), newTestPod("app-4")}
+
+	tests := []struct {
+		name        string
+		selector    string
+		wantMetrics []autoapprovev1alpha1.WorkloadMetric
+		wantErr     bool
+	}{
+		{
+			name:     "selected endpoints",
+			selector: "scrape=true",
+			wantMetrics: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true, HealthMetric: workloadHealthMetric, Value: "1"},
+				{Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false, HealthMetric: workloadHealthMetric, Value: "0"},
+			},
+		},
+		{
+			name:     "endpoint failing to be scraped",
+			selector: "scrape",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := labels.Parse(tt.selector)
+			if err != nil {
+				t.Fatalf("failed to parse selector %q: %v", tt.selector, err)
+			}
+			r := &Reconciler{
+				ScrapeEndpointSelector: selector,
+				EndpointReader:         newTestClientBuilder(t, objs...).Build(),
+			}
+			got, _, err := r.collectScrapedMetrics(context.Background(), nil, nil, nil, nil, false, nil)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("collectScrapedMetrics() error = %v, want error %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantMetrics, got); diff != "" {
+				t.Errorf("collectScrapedMetrics() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
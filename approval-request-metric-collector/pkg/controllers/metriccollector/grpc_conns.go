@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// grpcConn returns the cached gRPC connection for a grpc:// or grpcs:// URL, creating it on first use.
+// grpcs:// connections use TLS with the system roots. Connections are established lazily by gRPC, so
+// creating one does not contact the server.
+func (r *Reconciler) grpcConn(prometheusURL string) (*grpc.ClientConn, error) {
+	r.grpcConnsMu.Lock()
+	defer r.grpcConnsMu.Unlock()
+	if conn, ok := r.grpcConns[prometheusURL]; ok {
+		return conn, nil
+	}
+
+	u, err := url.Parse(prometheusURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prometheusUrl: %w", err)
+	}
+	creds := insecure.NewCredentials()
+	if u.Scheme == "grpcs" {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	userAgent := r.PrometheusUserAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent()
+	}
+	conn, err := grpc.NewClient(u.Host, grpc.WithTransportCredentials(creds), grpc.WithUserAgent(userAgent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", prometheusURL, err)
+	}
+	if r.grpcConns == nil {
+		r.grpcConns = make(map[string]*grpc.ClientConn)
+	}
+	r.grpcConns[prometheusURL] = conn
+	return conn, nil
+}
+
+// setGRPCURLs records the Prometheus URLs the report queries with the grpc protocol, none if it is deleted or does
+// not use the protocol, and closes the cached connections to URLs that no report queries anymore, e.g. after the
+// PrometheusURL of a report changed.
+func (r *Reconciler) setGRPCURLs(report types.NamespacedName, prometheusURLs []string) {
+	r.grpcConnsMu.Lock()
+	defer r.grpcConnsMu.Unlock()
+	if len(prometheusURLs) == 0 {
+		delete(r.grpcURLsByReport, report)
+	} else {
+		if r.grpcURLsByReport == nil {
+			r.grpcURLsByReport = make(map[types.NamespacedName][]string)
+		}
+		r.grpcURLsByReport[report] = prometheusURLs
+	}
+
+	inUse := make(map[string]bool)
+	for _, urls := range r.grpcURLsByReport {
+		for _, prometheusURL := range urls {
+			inUse[prometheusURL] = true
+		}
+	}
+	for prometheusURL, conn := range r.grpcConns {
+		if inUse[prometheusURL] {
+			continue
+		}
+		klog.V(2).InfoS("Closing gRPC connection no MetricCollectorReport queries anymore", "prometheusUrl", prometheusURL)
+		if err := conn.Close(); err != nil {
+			klog.ErrorS(err, "Failed to close gRPC connection", "prometheusUrl", prometheusURL)
+		}
+		delete(r.grpcConns, prometheusURL)
+	}
+}
+
+// Close closes the cached gRPC connections. It is called when the collector shuts down.
+func (r *Reconciler) Close() {
+	r.grpcConnsMu.Lock()
+	defer r.grpcConnsMu.Unlock()
+	for prometheusURL, conn := range r.grpcConns {
+		if err := conn.Close(); err != nil {
+			klog.ErrorS(err, "Failed to close gRPC connection", "prometheusUrl", prometheusURL)
+		}
+	}
+	r.grpcConns = nil
+	r.grpcURLsByReport = nil
+}
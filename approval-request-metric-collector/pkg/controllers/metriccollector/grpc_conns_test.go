@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+// cachedGRPCConn returns the cached connection to the URL, or nil if there is none.
+func cachedGRPCConn(r *Reconciler, prometheusURL string) *grpc.ClientConn {
+	r.grpcConnsMu.Lock()
+	defer r.grpcConnsMu.Unlock()
+	return r.grpcConns[prometheusURL]
+}
+
+func TestReconcileClosesUnusedGRPCConns(t *testing.T) {
+	// Nothing listens on these ports, so collections fail fast, after the connections are cached
+	const firstURL, secondURL = "grpc://127.0.0.1:1", "grpc://127.0.0.1:2"
+	report := newTestReport(firstURL)
+	report.Spec.Protocol = autoapprovev1alpha1.PrometheusProtocolGRPC
+	r := newTestReconciler(t, report)
+	t.Cleanup(r.Close)
+
+	got := reconcileReport(t, r)
+	first := cachedGRPCConn(r, firstURL)
+	if first == nil {
+		t.Fatalf("no connection cached for %s", firstURL)
+	}
+
+	// Changing the URL closes the connection to the previous one
+	got.Spec.PrometheusURL = secondURL
+	if err := r.HubClient.Update(context.Background(), got); err != nil {
+		t.Fatalf("failed to update MetricCollectorReport: %v", err)
+	}
+	reconcileReport(t, r)
+	if state := first.GetState(); state != connectivity.Shutdown {
+		t.Errorf("connection to %s state = %s, want %s", firstURL, state, connectivity.Shutdown)
+	}
+	if cachedGRPCConn(r, firstURL) != nil {
+		t.Errorf("connection to %s still cached", firstURL)
+	}
+	second := cachedGRPCConn(r, secondURL)
+	if second == nil {
+		t.Fatalf("no connection cached for %s", secondURL)
+	}
+
+	// Deleting the report closes the connection to its URL
+	if err := r.HubClient.Delete(context.Background(), got); err != nil {
+		t.Fatalf("failed to delete MetricCollectorReport: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: testReportKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+	if state := second.GetState(); state != connectivity.Shutdown {
+		t.Errorf("connection to %s state = %s, want %s", secondURL, state, connectivity.Shutdown)
+	}
+	if cachedGRPCConn(r, secondURL) != nil {
+		t.Errorf("connection to %s still cached", secondURL)
+	}
+}
+
+func TestSetGRPCURLsKeepsSharedConns(t *testing.T) {
+	const sharedURL = "grpc://thanos-query:10901"
+	r := &Reconciler{}
+	t.Cleanup(r.Close)
+	first, second := testReportKey, testReportKey
+	second.Name = "mc-test-run-prod"
+
+	r.setGRPCURLs(first, []string{sharedURL})
+	r.setGRPCURLs(second, []string{sharedURL})
+	conn, err := r.grpcConn(sharedURL)
+	if err != nil {
+		t.Fatalf("grpcConn() error = %v, want nil", err)
+	}
+
+	// Another report still queries the URL
+	r.setGRPCURLs(first, nil)
+	if state := conn.GetState(); state == connectivity.Shutdown {
+		t.Errorf("connection to %s closed while a report still queries it", sharedURL)
+	}
+
+	r.setGRPCURLs(second, nil)
+	if state := conn.GetState(); state != connectivity.Shutdown {
+		t.Errorf("connection to %s state = %s, want %s", sharedURL, state, connectivity.Shutdown)
+	}
+}
+
+func TestCloseClosesGRPCConns(t *testing.T) {
+	r := &Reconciler{}
+	r.setGRPCURLs(testReportKey, []string{"grpc://thanos-a:10901", "grpcs://thanos-b:10901"})
+	var conns []*grpc.ClientConn
+	for _, prometheusURL := range []string{"grpc://thanos-a:10901", "grpcs://thanos-b:10901"} {
+		conn, err := r.grpcConn(prometheusURL)
+		if err != nil {
+			t.Fatalf("grpcConn(%q) error = %v, want nil", prometheusURL, err)
+		}
+		conns = append(conns, conn)
+	}
+
+	r.Close()
+	for i, conn := range conns {
+		if state := conn.GetState(); state != connectivity.Shutdown {
+			t.Errorf("connection %d state = %s, want %s", i, state, connectivity.Shutdown)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+// collectKubeStatusMetrics derives the health of the pods of the tracked workloads from their Ready condition on the
+// member cluster, as a coarse fallback when Prometheus cannot be queried. Workloads that do not exist or are of kinds
+// other than Deployment, StatefulSet and DaemonSet are left out, so that approval sees them as missing. If minUptime
+// is set, pods that have not been up that long according to their start time are unhealthy, as with Prometheus.
+func (r *Reconciler) collectKubeStatusMetrics(
+	ctx context.Context,
+	workloads []autoapprovev1alpha1.WorkloadReference,
+	minUptime *metav1.Duration,
+) ([]autoapprovev1alpha1.WorkloadMetric, error) {
+	var metrics []autoapprovev1alpha1.WorkloadMetric
+	uptimes := make(map[types.NamespacedName]float64)
+	for _, workload := range workloads {
+		selector, err := r.workloadPodSelector(ctx, workload)
+		if err != nil {
+			return nil, err
+		}
+		if selector == nil {
+			continue
+		}
+		pods := &corev1.PodList{}
+		if err := r.KubeStatusReader.List(ctx, pods, client.InNamespace(workload.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list pods of %s %s/%s: %w", workload.Kind, workload.Namespace, workload.Name, err)
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			metric := autoapprovev1alpha1.WorkloadMetric{
+				Namespace:    workload.Namespace,
+				WorkloadName: workload.Name,
+				WorkloadKind: workload.Kind,
+				PodName:      pod.Name,
+				Health:       isPodReady(pod),
+				Source:       autoapprovev1alpha1.WorkloadMetricSourceKubeStatus,
+			}
+			if !metric.Health {
+				metric.UnhealthyReason = autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNotReady
+			}
+			if pod.Status.StartTime != nil {
+				uptimes[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = time.Since(pod.Status.StartTime.Time).Seconds()
+			}
+			metrics = append(metrics, metric)
+		}
+	}
+	if minUptime != nil {
+		applyMinPodUptime(metrics, uptimes, minUptime.Duration)
+	}
+	return metrics, nil
+}
+
+// workloadPodSelector returns the pod selector of a tracked workload on the member cluster, or nil if the workload
+// does not exist or its kind is not supported.
+func (r *Reconciler) workloadPodSelector(ctx context.Context, workload autoapprovev1alpha1.WorkloadReference) (labels.Selector, error) {
+	newObject, ok := memberCacheObjects[workload.Kind]
+	if !ok {
+		klog.V(2).InfoS("Pod status fallback is not supported for workload kind", "namespace", workload.Namespace, "workload", workload.Name, "kind", workload.Kind)
+		return nil, nil
+	}
+	obj := newObject()
+	if err := r.getMemberWorkload(ctx, types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name}, obj); err != nil {
+		if errors.IsNotFound(err) {
+			klog.V(2).InfoS("Tracked workload not found on the member cluster", "namespace", workload.Namespace, "workload", workload.Name, "kind", workload.Kind)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", workload.Kind, workload.Namespace, workload.Name, err)
+	}
+
+	var labelSelector *metav1.LabelSelector
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		labelSelector = o.Spec.Selector
+	case *appsv1.StatefulSet:
+		labelSelector = o.Spec.Selector
+	case *appsv1.DaemonSet:
+		labelSelector = o.Spec.Selector
+	}
+	if labelSelector == nil {
+		return nil, fmt.Errorf("%s %s/%s has no pod selector", workload.Kind, workload.Namespace, workload.Name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod selector of %s %s/%s: %w", workload.Kind, workload.Namespace, workload.Name, err)
+	}
+	if selector.Empty() {
+		// An empty selector would match every pod in the namespace
+		return nil, fmt.Errorf("%s %s/%s has an empty pod selector", workload.Kind, workload.Namespace, workload.Name)
+	}
+	return selector, nil
+}
+
+// getMemberWorkload reads a workload on the member cluster from MemberClient, if set, and otherwise, or if the
+// member cache does not hold its kind or namespace, from KubeStatusReader.
+func (r *Reconciler) getMemberWorkload(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+	if r.MemberClient != nil {
+		err := r.MemberClient.Get(ctx, key, obj)
+		if err == nil || errors.IsNotFound(err) {
+			return err
+		}
+		klog.V(4).InfoS("Workload cannot be read from the member cache, reading it from the API server", "workload", key, "kind", fmt.Sprintf("%T", obj), "err", err)
+	}
+	return r.KubeStatusReader.Get(ctx, key, obj)
+}
+
+// isPodReady reports whether the Ready condition of the pod is true.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+// newTestDeployment returns a Deployment in app-ns whose pods carry the app=<name> label.
+func newTestDeployment(name string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app-ns"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+		},
+	}
+}
+
+// failingReader is a member reader whose reads all fail, to tell which reader a lookup went to.
+func failingReader() client.Reader {
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+			return errors.New("unexpected read from the member API server")
+		},
+	}).Build()
+}
+
+func TestGetMemberWorkload(t *testing.T) {
+	key := types.NamespacedName{Namespace: "app-ns", Name: "app"}
+	tests := []struct {
+		name             string
+		memberClient     client.Reader
+		kubeStatusReader client.Reader
+		wantErr          bool
+	}{
+		{
+			name:             "no member cache",
+			kubeStatusReader: fake.NewClientBuilder().WithObjects(newTestDeployment("app")).Build(),
+		},
+		{
+			name:             "workload in the member cache",
+			memberClient:     fake.NewClientBuilder().WithObjects(newTestDeployment("app")).Build(),
+			kubeStatusReader: failingReader(),
+		},
+		{
+			name:             "workload not found in the member cache",
+			memberClient:     fake.NewClientBuilder().Build(),
+			kubeStatusReader: failingReader(),
+			wantErr:          true,
+		},
+		{
+			name: "workload kind not cached",
+			memberClient: fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+					return &cache.ErrResourceNotCached{}
+				},
+			}).Build(),
+			kubeStatusReader: fake.NewClientBuilder().WithObjects(newTestDeployment("app")).Build(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{MemberClient: tt.memberClient, KubeStatusReader: tt.kubeStatusReader}
+			deployment := &appsv1.Deployment{}
+			err := r.getMemberWorkload(context.Background(), key, deployment)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getMemberWorkload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && deployment.Name != key.Name {
+				t.Errorf("getMemberWorkload() read %q, want %q", deployment.Name, key.Name)
+			}
+		})
+	}
+}
+
+func TestReconcileFallsBackToKubeStatus(t *testing.T) {
+	newPod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app-ns", Labels: map[string]string{"app": "app"}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	tests := []struct {
+		name                 string
+		fallbackToKubeStatus bool
+		wantStatus           metav1.ConditionStatus
+		wantReason           string
+		wantMetrics          []autoapprovev1alpha1.WorkloadMetric
+	}{
+		{
+			name:       "fallback disabled",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionFailed,
+		},
+		{
+			name:                 "fallback enabled",
+			fallbackToKubeStatus: true,
+			wantStatus:           metav1.ConditionTrue,
+			wantReason:           autoapprovev1alpha1.MetricCollectorReportConditionReasonKubeStatusFallback,
+			wantMetrics: []autoapprovev1alpha1.WorkloadMetric{
+				{
+					Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-0", Health: true,
+					Source: autoapprovev1alpha1.WorkloadMetricSourceKubeStatus,
+				},
+				{
+					Namespace: "app-ns", WorkloadName: "app", WorkloadKind: "Deployment", PodName: "app-1", Health: false,
+					UnhealthyReason: autoapprovev1alpha1.WorkloadMetricUnhealthyReasonNotReady,
+					Source:          autoapprovev1alpha1.WorkloadMetricSourceKubeStatus,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prometheusDown := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			}))
+			defer prometheusDown.Close()
+			tracker := &autoapprovev1alpha1.StagedWorkloadTracker{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-run", Namespace: "test-ns"},
+				Workloads:  []autoapprovev1alpha1.WorkloadReference{{Name: "app", Namespace: "app-ns", Kind: "Deployment", HealthyReplicas: 1}},
+			}
+			report := newTestReport(prometheusDown.URL)
+			report.Labels = map[string]string{stageLabel: "canary"}
+			report.Spec.WorkloadTrackerRef = &autoapprovev1alpha1.WorkloadTrackerReference{
+				Kind:      autoapprovev1alpha1.StagedWorkloadTrackerKind,
+				Name:      tracker.Name,
+				Namespace: tracker.Namespace,
+			}
+			r := &Reconciler{
+				HubClient: newTestClientBuilder(t, report, tracker).Build(),
+				// The tracked Deployment is read from the member cache, and only the pods from the API server
+				MemberClient:         fake.NewClientBuilder().WithObjects(newTestDeployment("app")).Build(),
+				KubeStatusReader:     fake.NewClientBuilder().WithObjects(newPod("app-0", corev1.ConditionTrue), newPod("app-1", corev1.ConditionFalse)).Build(),
+				FallbackToKubeStatus: tt.fallbackToKubeStatus,
+			}
+
+			got := reconcileReport(t, r)
+			cond := meta.FindStatusCondition(got.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+			if cond == nil || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("MetricsCollected condition = %+v, want %s with reason %s", cond, tt.wantStatus, tt.wantReason)
+			}
+			if diff := cmp.Diff(tt.wantMetrics, got.Status.CollectedMetrics); diff != "" {
+				t.Errorf("CollectedMetrics mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// memberCacheObjects maps the workload kinds that can be cached on the member cluster to their typed objects.
+var memberCacheObjects = map[string]func() client.Object{
+	"Deployment":  func() client.Object { return &appsv1.Deployment{} },
+	"StatefulSet": func() client.Object { return &appsv1.StatefulSet{} },
+	"DaemonSet":   func() client.Object { return &appsv1.DaemonSet{} },
+}
+
+// NewMemberCluster returns a member cluster whose client reads workloads from an informer cache instead of the API server.
+// To bound memory, only the given workload kinds are cached, only in the given namespaces (all namespaces if empty),
+// and managed fields are stripped. Reading any other kind fails with ErrResourceNotCached instead of starting a new informer.
+// The returned cluster must be added to a manager so that its cache is started.
+func NewMemberCluster(ctx context.Context, cfg *rest.Config, scheme *runtime.Scheme, namespaces, kinds []string) (cluster.Cluster, error) {
+	objects := make([]client.Object, 0, len(kinds))
+	for _, kind := range kinds {
+		newObject, ok := memberCacheObjects[kind]
+		if !ok {
+			return nil, fmt.Errorf("unsupported workload kind %q, must be one of Deployment, StatefulSet or DaemonSet", kind)
+		}
+		objects = append(objects, newObject())
+	}
+
+	var defaultNamespaces map[string]cache.Config
+	if len(namespaces) > 0 {
+		defaultNamespaces = make(map[string]cache.Config, len(namespaces))
+		for _, namespace := range namespaces {
+			defaultNamespaces[namespace] = cache.Config{}
+		}
+	}
+
+	memberCluster, err := cluster.New(cfg, func(o *cluster.Options) {
+		o.Scheme = scheme
+		o.Cache = cache.Options{
+			DefaultNamespaces:           defaultNamespaces,
+			DefaultTransform:            cache.TransformStripManagedFields(),
+			ReaderFailOnMissingInformer: true,
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create member cluster: %w", err)
+	}
+
+	// Register the informers up front; they start syncing together with the cache
+	for _, obj := range objects {
+		if _, err := memberCluster.GetCache().GetInformer(ctx, obj); err != nil {
+			return nil, fmt.Errorf("failed to set up informer for %T: %w", obj, err)
+		}
+	}
+	return memberCluster, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	collectionResultSuccess = "success"
+	collectionResultFailure = "failure"
+	// collectionResultFallback counts collections that fell back to pod status because Prometheus failed
+	collectionResultFallback = "fallback"
+)
+
+var (
+	// reportLastCollectionAgeSeconds is the age of a report's LastCollectionTime. The age is computed when the
+	// metrics are scraped, so it keeps growing while collection of the report stalls.
+	reportLastCollectionAgeSeconds = newLastCollectionAgeCollector()
+
+	// reportCollectionTotal counts metric collections by result.
+	reportCollectionTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "metriccollectorreport_collection_total",
+		Help: "Total number of MetricCollectorReport metric collections by result (success, failure, or fallback to pod status).",
+	}, []string{"result"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(reportLastCollectionAgeSeconds, reportCollectionTotal)
+}
+
+// lastCollectionAgeCollector exports the age of the last collection time of every report it observed.
+type lastCollectionAgeCollector struct {
+	desc *prometheus.Desc
+
+	mu              sync.Mutex
+	lastCollections map[types.NamespacedName]time.Time
+}
+
+func newLastCollectionAgeCollector() *lastCollectionAgeCollector {
+	return &lastCollectionAgeCollector{
+		desc: prometheus.NewDesc("metriccollectorreport_last_collection_age_seconds",
+			"Seconds since the last metric collection of a MetricCollectorReport.",
+			[]string{"namespace", "name"}, nil),
+		lastCollections: make(map[types.NamespacedName]time.Time),
+	}
+}
+
+// observe records the last collection time of the report.
+func (c *lastCollectionAgeCollector) observe(key types.NamespacedName, lastCollection time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastCollections[key] = lastCollection
+}
+
+// forget stops exporting the age of the report, e.g. once it is deleted.
+func (c *lastCollectionAgeCollector) forget(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.lastCollections, key)
+}
+
+// Describe implements prometheus.Collector.
+func (c *lastCollectionAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *lastCollectionAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, lastCollection := range c.lastCollections {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, time.Since(lastCollection).Seconds(), key.Namespace, key.Name)
+	}
+}
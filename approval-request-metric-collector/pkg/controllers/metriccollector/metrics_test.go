@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestLastCollectionAgeCollector(t *testing.T) {
+	collector := newLastCollectionAgeCollector()
+	key := types.NamespacedName{Namespace: "fleet-member-cluster-1", Name: "mc-run-stage"}
+	collector.observe(key, time.Now().Add(-90*time.Second))
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	if len(families) != 1 || len(families[0].GetMetric()) != 1 {
+		t.Fatalf("Gather() = %v, want a single metric", families)
+	}
+	metric := families[0].GetMetric()[0]
+	// The age is computed at scrape time, so it reflects the time since the collection, not since observe
+	if age := metric.GetGauge().GetValue(); age < 90 || age > 120 {
+		t.Errorf("age = %v, want about 90 seconds", age)
+	}
+	labels := map[string]string{}
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	if labels["namespace"] != key.Namespace || labels["name"] != key.Name {
+		t.Errorf("labels = %v, want namespace %s and name %s", labels, key.Namespace, key.Name)
+	}
+
+	collector.forget(key)
+	if got := testutil.CollectAndCount(collector); got != 0 {
+		t.Errorf("CollectAndCount() after forget = %d, want 0", got)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+const (
+	// prometheusAuthTypeBearer sends the "token" key of the auth Secret as a bearer token.
+	prometheusAuthTypeBearer = "bearer"
+	// prometheusAuthTypeBasic sends the "username" and "password" keys of the auth Secret as basic auth.
+	prometheusAuthTypeBasic = "basic"
+)
+
+// prometheusAuth is the authentication used for the Prometheus queries of a report.
+// The zero value queries Prometheus without authentication.
+type prometheusAuth struct {
+	authType string
+	secret   *corev1.Secret
+}
+
+// resolvePrometheusAuth looks up the auth Secret mapped to the report namespace in the PrometheusAuthConfigMap
+// and loads it, from the report namespace unless the mapping names another namespace and AllowCrossNamespaceAuthSecrets
+// is set. A missing mapping or Secret, or a rejected cross-namespace reference, falls back to no authentication and
+// is surfaced on the report as a PrometheusAuthResolved=False condition. Nothing is resolved if no ConfigMap is configured.
+func (r *Reconciler) resolvePrometheusAuth(ctx context.Context, report *autoapprovev1alpha1.MetricCollectorReport) (prometheusAuth, error) {
+	if r.PrometheusAuthConfigMap.Name == "" {
+		return prometheusAuth{}, nil
+	}
+
+	mapping, err := r.mappedPrometheusAuthSecret(ctx, report.Namespace)
+	if err != nil {
+		return prometheusAuth{}, err
+	}
+	if mapping == "" {
+		klog.InfoS("No Prometheus auth Secret mapped to the report namespace, querying Prometheus without authentication",
+			"report", klog.KObj(report), "configMap", r.PrometheusAuthConfigMap)
+		setPrometheusAuthCondition(report, metav1.ConditionFalse, autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthMappingNotFound,
+			fmt.Sprintf("ConfigMap %s maps no auth Secret to namespace %s; querying Prometheus without authentication", r.PrometheusAuthConfigMap, report.Namespace))
+		return prometheusAuth{}, nil
+	}
+
+	secretKey := authSecretKey(report.Namespace, mapping)
+	if secretKey.Namespace != report.Namespace && !r.AllowCrossNamespaceAuthSecrets {
+		klog.InfoS("Mapped Prometheus auth Secret is outside the report namespace, querying Prometheus without authentication",
+			"report", klog.KObj(report), "secret", secretKey)
+		setPrometheusAuthCondition(report, metav1.ConditionFalse, autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthSecretCrossNamespace,
+			fmt.Sprintf("Auth Secret %s is outside namespace %s and cross-namespace auth Secrets are not allowed; querying Prometheus without authentication", secretKey, report.Namespace))
+		return prometheusAuth{}, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.HubClient.Get(ctx, secretKey, secret); err != nil {
+		if errors.IsNotFound(err) {
+			klog.InfoS("Mapped Prometheus auth Secret not found, querying Prometheus without authentication",
+				"report", klog.KObj(report), "secret", secretKey)
+			setPrometheusAuthCondition(report, metav1.ConditionFalse, autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthSecretNotFound,
+				fmt.Sprintf("Auth Secret %s not found; querying Prometheus without authentication", secretKey))
+			return prometheusAuth{}, nil
+		}
+		return prometheusAuth{}, fmt.Errorf("failed to get Prometheus auth Secret %s: %w", secretKey, err)
+	}
+
+	authType := prometheusAuthType(secret)
+	setPrometheusAuthCondition(report, metav1.ConditionTrue, autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthSecretResolved,
+		fmt.Sprintf("Querying Prometheus with %s auth from Secret %s", authType, secretKey))
+	return prometheusAuth{authType: authType, secret: secret}, nil
+}
+
+// authSecretKey resolves a PrometheusAuthConfigMap entry, either a Secret name or <namespace>/<name>, relative to
+// the report namespace.
+func authSecretKey(reportNamespace, mapping string) types.NamespacedName {
+	if namespace, name, ok := strings.Cut(mapping, "/"); ok {
+		return types.NamespacedName{Namespace: namespace, Name: name}
+	}
+	return types.NamespacedName{Namespace: reportNamespace, Name: mapping}
+}
+
+// mappedPrometheusAuthSecret returns the auth Secret reference that the PrometheusAuthConfigMap maps to
+// the given report namespace, or an empty string if the ConfigMap or the entry does not exist.
+func (r *Reconciler) mappedPrometheusAuthSecret(ctx context.Context, reportNamespace string) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.HubClient.Get(ctx, r.PrometheusAuthConfigMap, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			klog.V(2).InfoS("Prometheus auth ConfigMap not found", "configMap", r.PrometheusAuthConfigMap)
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get Prometheus auth ConfigMap %s: %w", r.PrometheusAuthConfigMap, err)
+	}
+	return configMap.Data[reportNamespace], nil
+}
+
+// prometheusAuthType infers the auth type from the keys of the auth Secret: basic auth if it has a username,
+// e.g. a kubernetes.io/basic-auth Secret, and a bearer token otherwise.
+func prometheusAuthType(secret *corev1.Secret) string {
+	if _, ok := secret.Data["username"]; ok {
+		return prometheusAuthTypeBasic
+	}
+	return prometheusAuthTypeBearer
+}
+
+// setPrometheusAuthCondition sets the PrometheusAuthResolved condition of the report.
+func setPrometheusAuthCondition(report *autoapprovev1alpha1.MetricCollectorReport, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&report.Status.Conditions, metav1.Condition{
+		Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypePrometheusAuthResolved,
+		Status:             status,
+		ObservedGeneration: report.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
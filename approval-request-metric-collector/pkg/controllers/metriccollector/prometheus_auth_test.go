@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+func TestResolvePrometheusAuth(t *testing.T) {
+	authConfigMap := types.NamespacedName{Namespace: "fleet-system", Name: "prometheus-auth"}
+	newAuthConfigMap := func(mapping string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: authConfigMap.Namespace, Name: authConfigMap.Name},
+			Data:       map[string]string{testReportNamespace: mapping},
+		}
+	}
+	newSecret := func(namespace string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "prom-auth"}, Data: data}
+	}
+	token := map[string][]byte{"token": []byte("secret-token")}
+	tests := []struct {
+		name                string
+		objs                []client.Object
+		allowCrossNamespace bool
+		wantAuthType        string
+		wantSecretNamespace string
+		wantReason          string
+	}{
+		{
+			name:                "bearer token in the report namespace",
+			objs:                []client.Object{newAuthConfigMap("prom-auth"), newSecret(testReportNamespace, token)},
+			wantAuthType:        prometheusAuthTypeBearer,
+			wantSecretNamespace: testReportNamespace,
+			wantReason:          autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthSecretResolved,
+		},
+		{
+			name: "basic auth in the report namespace",
+			objs: []client.Object{
+				newAuthConfigMap("prom-auth"),
+				newSecret(testReportNamespace, map[string][]byte{"username": []byte("user"), "password": []byte("pass")}),
+			},
+			wantAuthType:        prometheusAuthTypeBasic,
+			wantSecretNamespace: testReportNamespace,
+			wantReason:          autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthSecretResolved,
+		},
+		{
+			name:       "cross-namespace Secret rejected",
+			objs:       []client.Object{newAuthConfigMap("monitoring/prom-auth"), newSecret("monitoring", token)},
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthSecretCrossNamespace,
+		},
+		{
+			name:                "cross-namespace Secret allowed",
+			objs:                []client.Object{newAuthConfigMap("monitoring/prom-auth"), newSecret("monitoring", token)},
+			allowCrossNamespace: true,
+			wantAuthType:        prometheusAuthTypeBearer,
+			wantSecretNamespace: "monitoring",
+			wantReason:          autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthSecretResolved,
+		},
+		{
+			name:       "no mapping for the report namespace",
+			objs:       []client.Object{newAuthConfigMap("")},
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthMappingNotFound,
+		},
+		{
+			name:       "mapped Secret not found",
+			objs:       []client.Object{newAuthConfigMap("prom-auth")},
+			wantReason: autoapprovev1alpha1.MetricCollectorReportConditionReasonAuthSecretNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{
+				HubClient:                      newTestClientBuilder(t, tt.objs...).Build(),
+				PrometheusAuthConfigMap:        authConfigMap,
+				AllowCrossNamespaceAuthSecrets: tt.allowCrossNamespace,
+			}
+			report := newTestReport("http://prometheus:9090")
+
+			auth, err := r.resolvePrometheusAuth(context.Background(), report)
+			if err != nil {
+				t.Fatalf("resolvePrometheusAuth() error = %v", err)
+			}
+			if auth.authType != tt.wantAuthType {
+				t.Errorf("auth type = %q, want %q", auth.authType, tt.wantAuthType)
+			}
+			var gotSecretNamespace string
+			if auth.secret != nil {
+				gotSecretNamespace = auth.secret.Namespace
+			}
+			if gotSecretNamespace != tt.wantSecretNamespace {
+				t.Errorf("auth Secret namespace = %q, want %q", gotSecretNamespace, tt.wantSecretNamespace)
+			}
+			cond := meta.FindStatusCondition(report.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypePrometheusAuthResolved)
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Errorf("PrometheusAuthResolved condition = %+v, want reason %s", cond, tt.wantReason)
+			}
+		})
+	}
+}
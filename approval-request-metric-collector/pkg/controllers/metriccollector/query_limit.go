@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"fmt"
+)
+
+// limitedPrometheusClient is a PrometheusClient that takes a slot of a semaphore shared by all clients of
+// the reconciler for the duration of each query, bounding the Prometheus queries in flight across reports.
+type limitedPrometheusClient struct {
+	PrometheusClient
+	slots chan struct{}
+}
+
+// Query waits for a free slot before running the query, or fails once the context is done.
+func (c *limitedPrometheusClient) Query(ctx context.Context, query string) (PrometheusData, error) {
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return PrometheusData{}, fmt.Errorf("failed to wait for a Prometheus query slot: %w", ctx.Err())
+	}
+	defer func() { <-c.slots }()
+	return c.PrometheusClient.Query(ctx, query)
+}
+
+// limitQueries wraps promClient so that its queries count against MaxConcurrentQueries.
+// The client is returned as is if MaxConcurrentQueries is not set.
+func (r *Reconciler) limitQueries(promClient PrometheusClient) PrometheusClient {
+	if r.MaxConcurrentQueries <= 0 {
+		return promClient
+	}
+	r.querySlotsOnce.Do(func() {
+		r.querySlots = make(chan struct{}, r.MaxConcurrentQueries)
+	})
+	return &limitedPrometheusClient{PrometheusClient: promClient, slots: r.querySlots}
+}
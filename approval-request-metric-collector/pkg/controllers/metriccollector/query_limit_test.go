@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingPrometheusClient records the queries in flight and holds each one until release is closed.
+type blockingPrometheusClient struct {
+	inFlight, maxInFlight atomic.Int32
+	started               chan struct{}
+	release               chan struct{}
+}
+
+func (c *blockingPrometheusClient) Query(ctx context.Context, _ string) (PrometheusData, error) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		maxInFlight := c.maxInFlight.Load()
+		if n <= maxInFlight || c.maxInFlight.CompareAndSwap(maxInFlight, n) {
+			break
+		}
+	}
+	c.started <- struct{}{}
+	select {
+	case <-c.release:
+	case <-ctx.Done():
+	}
+	return PrometheusData{}, nil
+}
+
+func TestLimitQueries(t *testing.T) {
+	const maxQueries, queries = 2, 6
+	promClient := &blockingPrometheusClient{started: make(chan struct{}, queries), release: make(chan struct{})}
+	r := &Reconciler{MaxConcurrentQueries: maxQueries}
+
+	// Each report gets its own client, which share the slots of the reconciler
+	var wg sync.WaitGroup
+	for i := 0; i < queries; i++ {
+		limited := r.limitQueries(promClient)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := limited.Query(context.Background(), "workload_health"); err != nil {
+				t.Errorf("Query() error = %v", err)
+			}
+		}()
+	}
+	for i := 0; i < maxQueries; i++ {
+		<-promClient.started
+	}
+	select {
+	case <-promClient.started:
+		t.Errorf("query started beyond the limit of %d", maxQueries)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(promClient.release)
+	wg.Wait()
+
+	if got := promClient.maxInFlight.Load(); got != maxQueries {
+		t.Errorf("max queries in flight = %d, want %d", got, maxQueries)
+	}
+}
+
+func TestLimitQueriesContextDone(t *testing.T) {
+	promClient := &blockingPrometheusClient{started: make(chan struct{}, 1), release: make(chan struct{})}
+	defer close(promClient.release)
+	r := &Reconciler{MaxConcurrentQueries: 1}
+	go func() {
+		_, _ = r.limitQueries(promClient).Query(context.Background(), "workload_health")
+	}()
+	<-promClient.started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.limitQueries(promClient).Query(ctx, "workload_health"); err == nil {
+		t.Errorf("Query() without a free slot after the context is done error = nil, want an error")
+	}
+}
+
+func TestLimitQueriesDisabled(t *testing.T) {
+	promClient := &blockingPrometheusClient{}
+	r := &Reconciler{}
+	if got := r.limitQueries(promClient); got != PrometheusClient(promClient) {
+		t.Errorf("limitQueries() without MaxConcurrentQueries = %T, want the client unchanged", got)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+const (
+	// Labels set on MetricCollectorReports by the approval-request-controller.
+	updateRunLabel     = "kubernetes-fleet.io/update-run"
+	stageLabel         = "kubernetes-fleet.io/stage"
+	memberClusterLabel = "kubernetes-fleet.io/member-cluster"
+)
+
+// queryTemplateData holds the values available to a report's query template.
+// Its fields are the only variables a template may reference.
+type queryTemplateData struct {
+	Cluster   string
+	Stage     string
+	UpdateRun string
+}
+
+// allowedQueryTemplateFields is the whitelist of fields a query template may reference.
+var allowedQueryTemplateFields = map[string]bool{
+	"Cluster":   true,
+	"Stage":     true,
+	"UpdateRun": true,
+}
+
+// renderQueryTemplate renders the query template with the cluster, stage and update run
+// taken from the report labels. Templates that reference anything other than the whitelisted
+// fields, or that use actions other than plain field substitution, are rejected.
+func renderQueryTemplate(queryTemplate string, labels map[string]string) (string, error) {
+	tmpl, err := template.New("query").Parse(queryTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse query template: %w", err)
+	}
+	if err := validateQueryTemplateNode(tmpl.Root); err != nil {
+		return "", err
+	}
+
+	data := queryTemplateData{
+		Cluster:   labels[memberClusterLabel],
+		Stage:     labels[stageLabel],
+		UpdateRun: labels[updateRunLabel],
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render query template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// validateQueryTemplateNode walks the template parse tree and only accepts text and
+// {{.Field}} actions on whitelisted fields, so that templates cannot call functions,
+// define variables or reach anything beyond the substitution values.
+func validateQueryTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		for _, child := range n.Nodes {
+			if err := validateQueryTemplateNode(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.TextNode:
+		return nil
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) != 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+			return fmt.Errorf("unsupported query template action %q: only {{.Cluster}}, {{.Stage}} and {{.UpdateRun}} are allowed", n.String())
+		}
+		field, ok := n.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+		if !ok || len(field.Ident) != 1 || !allowedQueryTemplateFields[field.Ident[0]] {
+			return fmt.Errorf("unknown query template variable %q: only {{.Cluster}}, {{.Stage}} and {{.UpdateRun}} are allowed", n.String())
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported query template construct %q: only {{.Cluster}}, {{.Stage}} and {{.UpdateRun}} are allowed", node.String())
+	}
+}
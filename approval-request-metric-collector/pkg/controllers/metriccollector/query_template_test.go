@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"testing"
+)
+
+func TestRenderQueryTemplate(t *testing.T) {
+	labels := map[string]string{
+		memberClusterLabel: "member-1",
+		stageLabel:         "canary",
+		updateRunLabel:     "run-1",
+	}
+	tests := []struct {
+		name          string
+		queryTemplate string
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:          "all variables",
+			queryTemplate: `workload_health{cluster="{{.Cluster}}",stage="{{.Stage}}",update_run="{{.UpdateRun}}"}`,
+			want:          `workload_health{cluster="member-1",stage="canary",update_run="run-1"}`,
+		},
+		{
+			name:          "no variables",
+			queryTemplate: `workload_health`,
+			want:          `workload_health`,
+		},
+		{
+			name:          "unknown variable",
+			queryTemplate: `workload_health{namespace="{{.Namespace}}"}`,
+			wantErr:       true,
+		},
+		{
+			name:          "nested field",
+			queryTemplate: `workload_health{cluster="{{.Cluster.Name}}"}`,
+			wantErr:       true,
+		},
+		{
+			name:          "function call",
+			queryTemplate: `workload_health{cluster="{{printf "%s" .Cluster}}"}`,
+			wantErr:       true,
+		},
+		{
+			name:          "variable declaration",
+			queryTemplate: `{{$c := .Cluster}}workload_health{cluster="{{$c}}"}`,
+			wantErr:       true,
+		},
+		{
+			name:          "control structure",
+			queryTemplate: `{{if .Cluster}}workload_health{{end}}`,
+			wantErr:       true,
+		},
+		{
+			name:          "parse error",
+			queryTemplate: `workload_health{cluster="{{.Cluster"}`,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderQueryTemplate(tt.queryTemplate, labels)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderQueryTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("renderQueryTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
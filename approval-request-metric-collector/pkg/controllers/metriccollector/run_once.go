@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+// CollectOnce reconciles every MetricCollectorReport in the namespace once, without a manager or requeues,
+// for running the collector as a batch job. It returns an error if any report could not be reconciled or
+// its collection failed; the remaining reports are still collected.
+func (r *Reconciler) CollectOnce(ctx context.Context, namespace string) error {
+	reportList := &autoapprovev1alpha1.MetricCollectorReportList{}
+	if err := r.HubClient.List(ctx, reportList, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list MetricCollectorReports: %w", err)
+	}
+	klog.InfoS("Collecting metrics once for all MetricCollectorReports", "namespace", namespace, "reports", len(reportList.Items))
+
+	var errs []error
+	for i := range reportList.Items {
+		reportKey := types.NamespacedName{Namespace: reportList.Items[i].Namespace, Name: reportList.Items[i].Name}
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: reportKey}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", reportKey, err))
+			continue
+		}
+
+		// Reconcile records a failed collection on the report rather than returning an error
+		report := &autoapprovev1alpha1.MetricCollectorReport{}
+		if err := r.HubClient.Get(ctx, reportKey, report); err != nil {
+			errs = append(errs, fmt.Errorf("%s: failed to get MetricCollectorReport: %w", reportKey, err))
+			continue
+		}
+		cond := meta.FindStatusCondition(report.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeMetricsCollected)
+		if cond == nil || cond.ObservedGeneration != report.Generation || cond.Status != metav1.ConditionTrue {
+			message := "no collection recorded"
+			if cond != nil {
+				message = cond.Message
+			}
+			errs = append(errs, fmt.Errorf("%s: %s", reportKey, message))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// startupCheckTimeout bounds the startup check of Prometheus, which runs before the controller starts.
+	startupCheckTimeout = 10 * time.Second
+
+	// startupCheckQuery is the trivial query of the startup check. It returns a single series, however many
+	// targets Prometheus scrapes.
+	startupCheckQuery = "count(up)"
+)
+
+// CheckPrometheus queries prometheusURL with a trivial query, so that a Prometheus that cannot be reached is
+// reported at startup rather than with the first failed collection of a report. It queries over HTTP with the proxy,
+// user agent and POST threshold of the reconciler, but without credentials, since those are looked up per report;
+// a Prometheus that requires them fails the check with an authentication error, which still shows it is reachable.
+// The caller is expected to log a returned error rather than fail startup.
+func (r *Reconciler) CheckPrometheus(ctx context.Context, prometheusURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, startupCheckTimeout)
+	defer cancel()
+	promClient := NewPrometheusClient(prometheusURL, "", nil, r.prometheusClientOptions(0)...)
+	data, err := promClient.Query(ctx, startupCheckQuery)
+	if err != nil {
+		return fmt.Errorf("failed to query Prometheus at %s: %w", prometheusURL, err)
+	}
+	targets := "0"
+	if len(data.Result) > 0 {
+		if value, err := data.Result[0].latestSample(); err == nil {
+			targets = value
+		}
+	}
+	klog.InfoS("Prometheus is reachable", "url", prometheusURL, "scrapeTargets", targets)
+	return nil
+}
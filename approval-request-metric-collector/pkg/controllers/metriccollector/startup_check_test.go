@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckPrometheus(t *testing.T) {
+	t.Run("reachable", func(t *testing.T) {
+		prom := newTestPrometheus(t, []PrometheusResult{{Metric: map[string]string{}, Value: []interface{}{float64(1735689600), "12"}}})
+		r := &Reconciler{}
+		if err := r.CheckPrometheus(context.Background(), prom.URL); err != nil {
+			t.Fatalf("CheckPrometheus() error = %v, want nil", err)
+		}
+		if got := prom.receivedQueries(); !slices.Equal(got, []string{startupCheckQuery}) {
+			t.Errorf("queries = %q, want %q", got, []string{startupCheckQuery})
+		}
+	})
+
+	t.Run("user agent", func(t *testing.T) {
+		var userAgent string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			userAgent = req.UserAgent()
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		}))
+		defer server.Close()
+		r := &Reconciler{PrometheusUserAgent: "collector-test/1.0"}
+		if err := r.CheckPrometheus(context.Background(), server.URL); err != nil {
+			t.Fatalf("CheckPrometheus() error = %v, want nil", err)
+		}
+		if userAgent != "collector-test/1.0" {
+			t.Errorf("User-Agent = %q, want %q", userAgent, "collector-test/1.0")
+		}
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		err := (&Reconciler{}).CheckPrometheus(context.Background(), server.URL)
+		if err == nil || !strings.Contains(err.Error(), server.URL) {
+			t.Errorf("CheckPrometheus() error = %v, want an error naming %s", err, server.URL)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		if err := (&Reconciler{}).CheckPrometheus(context.Background(), server.URL); err == nil {
+			t.Errorf("CheckPrometheus() error = nil, want an error for a closed server")
+		}
+	})
+}
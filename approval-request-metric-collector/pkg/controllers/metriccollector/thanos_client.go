@@ -0,0 +1,323 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/pkg/tracing"
+)
+
+// thanosQueryMethod is the server-streaming instant query method of the Thanos Query gRPC API.
+const thanosQueryMethod = "/thanos.Query/Query"
+
+// thanosQueryTimeout bounds a single gRPC query, matching the timeout of the HTTP client.
+const thanosQueryTimeout = 30 * time.Second
+
+// Field numbers of the Thanos query API messages (api/query/querypb/query.proto) and of the
+// Prometheus remote-read TimeSeries they carry (prompb/types.proto). The messages are encoded
+// by hand so that the collector does not depend on the Thanos module.
+const (
+	thanosQueryRequestQuery                 protowire.Number = 1
+	thanosQueryRequestTimeSeconds           protowire.Number = 2
+	thanosQueryRequestTimeoutSeconds        protowire.Number = 3
+	thanosQueryRequestEnableDedup           protowire.Number = 7
+	thanosQueryRequestEnablePartialResponse protowire.Number = 8
+
+	thanosQueryResponseWarnings   protowire.Number = 1
+	thanosQueryResponseTimeseries protowire.Number = 2
+
+	timeSeriesLabels  protowire.Number = 1
+	timeSeriesSamples protowire.Number = 2
+	labelName         protowire.Number = 1
+	labelValue        protowire.Number = 2
+	sampleValueField  protowire.Number = 1
+	sampleTimestamp   protowire.Number = 2
+)
+
+// thanosClient implements PrometheusClient over the Thanos Query gRPC API.
+type thanosClient struct {
+	conn       grpc.ClientConnInterface
+	target     string
+	authType   string
+	authSecret *corev1.Secret
+	// tracer records a span around every query.
+	tracer trace.Tracer
+	// queryTimeout is the server-side timeout sent with every query.
+	queryTimeout time.Duration
+}
+
+// NewThanosClient creates a PrometheusClient that sends instant queries to the Thanos Query gRPC API
+// over conn. target is only used to identify the endpoint in spans and errors. Results are returned in
+// the same form as the HTTP API's instant vectors, so callers can use either client interchangeably.
+// queryTimeout, if positive and shorter than the client-side timeout, is sent as the server-side timeout
+// of every query instead of the client-side one.
+func NewThanosClient(conn grpc.ClientConnInterface, target, authType string, authSecret *corev1.Secret, tracer trace.Tracer, queryTimeout time.Duration) PrometheusClient {
+	if queryTimeout <= 0 || queryTimeout > thanosQueryTimeout {
+		queryTimeout = thanosQueryTimeout
+	}
+	return &thanosClient{
+		conn:         conn,
+		target:       target,
+		authType:     authType,
+		authSecret:   authSecret,
+		tracer:       tracing.OrNoop(tracer),
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Query executes a PromQL instant query against the Thanos Query gRPC API
+func (c *thanosClient) Query(ctx context.Context, query string) (PrometheusData, error) {
+	ctx, span := c.tracer.Start(ctx, "Prometheus.Query", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("prometheus.url", c.target),
+		attribute.String("prometheus.protocol", "grpc"),
+		attribute.String("prometheus.query", query),
+	))
+	defer span.End()
+
+	data, err := c.query(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return PrometheusData{}, err
+	}
+	span.SetAttributes(attribute.Int("prometheus.series", len(data.Result)))
+	return data, nil
+}
+
+// query streams the series of a PromQL instant query and collects them into an instant vector.
+func (c *thanosClient) query(ctx context.Context, query string) (PrometheusData, error) {
+	ctx, cancel := context.WithTimeout(ctx, thanosQueryTimeout)
+	defer cancel()
+
+	authorization, err := authorizationHeader(c.authType, c.authSecret)
+	if err != nil {
+		return PrometheusData{}, fmt.Errorf("failed to add auth: %w", err)
+	}
+	if authorization != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+	}
+
+	stream, err := c.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, thanosQueryMethod, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return PrometheusData{}, fmt.Errorf("failed to open query stream: %w", err)
+	}
+	request := encodeThanosQueryRequest(query, time.Now(), c.queryTimeout)
+	if err := stream.SendMsg(&request); err != nil {
+		return PrometheusData{}, fmt.Errorf("failed to send query: %w", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return PrometheusData{}, fmt.Errorf("failed to close query stream: %w", err)
+	}
+
+	data := PrometheusData{ResultType: prometheusResultTypeVector, Result: []PrometheusResult{}}
+	for {
+		var response []byte
+		if err := stream.RecvMsg(&response); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return PrometheusData{}, fmt.Errorf("query failed: %w", err)
+		}
+		result, warning, err := decodeThanosQueryResponse(response)
+		if err != nil {
+			return PrometheusData{}, fmt.Errorf("failed to decode response: %w", err)
+		}
+		if warning != "" {
+			klog.V(2).InfoS("Thanos query returned a warning", "target", c.target, "warning", warning)
+			continue
+		}
+		if result != nil {
+			data.Result = append(data.Result, *result)
+		}
+	}
+	return data, nil
+}
+
+// encodeThanosQueryRequest encodes a thanos.QueryRequest for an instant query at ts. Deduplication is enabled
+// so that replicas behind the querier yield a single series per pod; partial responses are disabled so that
+// an unreachable store fails the query instead of silently dropping pods.
+func encodeThanosQueryRequest(query string, ts time.Time, timeout time.Duration) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, thanosQueryRequestQuery, protowire.BytesType)
+	b = protowire.AppendString(b, query)
+	b = protowire.AppendTag(b, thanosQueryRequestTimeSeconds, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(ts.Unix()))
+	b = protowire.AppendTag(b, thanosQueryRequestTimeoutSeconds, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(timeout/time.Second))
+	b = protowire.AppendTag(b, thanosQueryRequestEnableDedup, protowire.VarintType)
+	b = protowire.AppendVarint(b, protowire.EncodeBool(true))
+	b = protowire.AppendTag(b, thanosQueryRequestEnablePartialResponse, protowire.VarintType)
+	b = protowire.AppendVarint(b, protowire.EncodeBool(false))
+	return b
+}
+
+// decodeThanosQueryResponse decodes a thanos.QueryResponse, which carries either a warning or a single series.
+// The series is returned as an instant vector result holding its latest sample, or nil if it has no samples.
+func decodeThanosQueryResponse(b []byte) (*PrometheusResult, string, error) {
+	var result *PrometheusResult
+	var warning string
+	err := rangeFields(b, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case thanosQueryResponseWarnings:
+			warning = string(value)
+		case thanosQueryResponseTimeseries:
+			var err error
+			result, err = decodeTimeSeries(value)
+			return err
+		}
+		return nil
+	})
+	return result, warning, err
+}
+
+// decodeTimeSeries decodes a prompb.TimeSeries into a PrometheusResult with a [timestamp, value] pair
+// in the form returned by the HTTP API: the timestamp in seconds and the value as a string.
+func decodeTimeSeries(b []byte) (*PrometheusResult, error) {
+	metric := map[string]string{}
+	found := false
+	var latestTimestamp int64
+	var latestValue float64
+	err := rangeFields(b, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case timeSeriesLabels:
+			var name, val string
+			if err := rangeFields(value, func(num protowire.Number, typ protowire.Type, field []byte) error {
+				switch {
+				case num == labelName && typ == protowire.BytesType:
+					name = string(field)
+				case num == labelValue && typ == protowire.BytesType:
+					val = string(field)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			metric[name] = val
+		case timeSeriesSamples:
+			var timestamp int64
+			var sample float64
+			if err := rangeFields(value, func(num protowire.Number, typ protowire.Type, field []byte) error {
+				switch {
+				case num == sampleValueField && typ == protowire.Fixed64Type:
+					v, n := protowire.ConsumeFixed64(field)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					sample = math.Float64frombits(v)
+				case num == sampleTimestamp && typ == protowire.VarintType:
+					v, n := protowire.ConsumeVarint(field)
+					if n < 0 {
+						return protowire.ParseError(n)
+					}
+					timestamp = int64(v)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			if !found || timestamp >= latestTimestamp {
+				found = true
+				latestTimestamp = timestamp
+				latestValue = sample
+			}
+		}
+		return nil
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+	return &PrometheusResult{
+		Metric: metric,
+		Value:  []interface{}{float64(latestTimestamp) / 1000, strconv.FormatFloat(latestValue, 'f', -1, 64)},
+	}, nil
+}
+
+// rangeFields calls fn for every field of the encoded message b. For length-delimited fields value is the
+// field's payload; for other wire types it is the raw encoded value.
+func rangeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		var value []byte
+		if typ == protowire.BytesType {
+			v, m := protowire.ConsumeBytes(b)
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			value, n = v, m
+		} else {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			value = b[:n]
+		}
+		if err := fn(num, typ, value); err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// rawCodec passes pre-encoded protobuf messages through gRPC unchanged. It is named "proto"
+// so that the request content type matches what Thanos expects.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccollector
+
+import (
+	"context"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protowire"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// testSample is a sample of a series served by the mock Thanos querier, with the timestamp in milliseconds.
+type testSample struct {
+	timestamp int64
+	value     float64
+}
+
+// encodeTestSeriesResponse encodes a thanos.QueryResponse carrying a single series.
+func encodeTestSeriesResponse(labels map[string]string, samples ...testSample) []byte {
+	var series []byte
+	for name, value := range labels {
+		var label []byte
+		label = protowire.AppendTag(label, labelName, protowire.BytesType)
+		label = protowire.AppendString(label, name)
+		label = protowire.AppendTag(label, labelValue, protowire.BytesType)
+		label = protowire.AppendString(label, value)
+		series = protowire.AppendTag(series, timeSeriesLabels, protowire.BytesType)
+		series = protowire.AppendBytes(series, label)
+	}
+	for _, s := range samples {
+		var sample []byte
+		sample = protowire.AppendTag(sample, sampleValueField, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, sampleTimestamp, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(s.timestamp))
+		series = protowire.AppendTag(series, timeSeriesSamples, protowire.BytesType)
+		series = protowire.AppendBytes(series, sample)
+	}
+	var response []byte
+	response = protowire.AppendTag(response, thanosQueryResponseTimeseries, protowire.BytesType)
+	return protowire.AppendBytes(response, series)
+}
+
+// encodeTestWarningResponse encodes a thanos.QueryResponse carrying a warning.
+func encodeTestWarningResponse(warning string) []byte {
+	var response []byte
+	response = protowire.AppendTag(response, thanosQueryResponseWarnings, protowire.BytesType)
+	return protowire.AppendString(response, warning)
+}
+
+// mockThanosQuerier serves the Thanos Query gRPC API on an in-memory listener. It records the query and the
+// authorization metadata of the last request and answers it with responses, or fails it with err.
+type mockThanosQuerier struct {
+	responses [][]byte
+	err       error
+
+	gotMethod        string
+	gotQuery         string
+	gotAuthorization []string
+}
+
+func (m *mockThanosQuerier) handle(_ interface{}, stream grpc.ServerStream) error {
+	m.gotMethod, _ = grpc.MethodFromServerStream(stream)
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		m.gotAuthorization = md.Get("authorization")
+	}
+	var request []byte
+	if err := stream.RecvMsg(&request); err != nil {
+		return err
+	}
+	if err := rangeFields(request, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num == thanosQueryRequestQuery && typ == protowire.BytesType {
+			m.gotQuery = string(value)
+		}
+		return nil
+	}); err != nil {
+		return status.Errorf(codes.InvalidArgument, "malformed request: %v", err)
+	}
+	if m.err != nil {
+		return m.err
+	}
+	for i := range m.responses {
+		if err := stream.SendMsg(&m.responses[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newMockThanosConn starts the mock querier and returns a client connection to it.
+func newMockThanosConn(t *testing.T, m *mockThanosQuerier) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(m.handle))
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///thanos-query",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create gRPC client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestThanosClientQuery(t *testing.T) {
+	podLabels := func(pod string) map[string]string {
+		return map[string]string{"namespace": "app-ns", "app": "app", "workload_kind": "Deployment", "pod": pod}
+	}
+	m := &mockThanosQuerier{responses: [][]byte{
+		encodeTestWarningResponse("store unavailable"),
+		// The latest sample wins regardless of its position in the series
+		encodeTestSeriesResponse(podLabels("app-0"), testSample{timestamp: 2000, value: 1}, testSample{timestamp: 1000, value: 0}),
+		encodeTestSeriesResponse(podLabels("app-1"), testSample{timestamp: 1500, value: 0}),
+		// A series without samples is dropped
+		encodeTestSeriesResponse(podLabels("app-2")),
+	}}
+	secret := &corev1.Secret{Data: map[string][]byte{"token": []byte("secret-token")}}
+	c := NewThanosClient(newMockThanosConn(t, m), "grpc://thanos-query:10901", prometheusAuthTypeBearer, secret, nil, 0)
+
+	got, err := c.Query(context.Background(), "workload_health")
+	if err != nil {
+		t.Fatalf("Query() error = %v, want nil", err)
+	}
+	want := PrometheusData{
+		ResultType: prometheusResultTypeVector,
+		Result: []PrometheusResult{
+			{Metric: podLabels("app-0"), Value: []interface{}{float64(2), "1"}},
+			{Metric: podLabels("app-1"), Value: []interface{}{1.5, "0"}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Query() mismatch (-want +got):\n%s", diff)
+	}
+	if m.gotMethod != thanosQueryMethod {
+		t.Errorf("method = %q, want %q", m.gotMethod, thanosQueryMethod)
+	}
+	if m.gotQuery != "workload_health" {
+		t.Errorf("query = %q, want %q", m.gotQuery, "workload_health")
+	}
+	if diff := cmp.Diff([]string{"Bearer secret-token"}, m.gotAuthorization); diff != "" {
+		t.Errorf("authorization metadata mismatch (-want +got):\n%s", diff)
+	}
+
+	// The results convert like those of the HTTP API
+	metrics, parseErrors := workloadMetricsFromSeries(got.Result, nil, nil, nil, false)
+	if parseErrors != 0 || len(metrics) != 2 || !metrics[0].Health || metrics[1].Health {
+		t.Errorf("workloadMetricsFromSeries() = %+v, %d parse errors, want app-0 healthy and app-1 unhealthy", metrics, parseErrors)
+	}
+}
+
+func TestThanosClientQueryError(t *testing.T) {
+	m := &mockThanosQuerier{err: status.Error(codes.Unavailable, "no store matched")}
+	c := NewThanosClient(newMockThanosConn(t, m), "grpc://thanos-query:10901", "", nil, nil, 0)
+
+	if _, err := c.Query(context.Background(), "workload_health"); status.Code(err) != codes.Unavailable {
+		t.Errorf("Query() error = %v, want code %s", err, codes.Unavailable)
+	}
+	if m.gotAuthorization != nil {
+		t.Errorf("authorization metadata = %v, want none", m.gotAuthorization)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reportwatchdog features a hub-side watchdog that flags MetricCollectorReports
+// whose metric collector has stopped updating them.
+package reportwatchdog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+// Watchdog periodically scans all MetricCollectorReports on the hub and sets the StaleMetricsReporter condition
+// on those whose last collection is older than the threshold, emitting a warning event when a report turns stale.
+// It is a health signal about the metric collectors themselves, independent of approval.
+type Watchdog struct {
+	Client client.Client
+	// Threshold is the age of the last collection beyond which a report is stale. Reports that were never
+	// collected are stale once they are older than the threshold.
+	Threshold time.Duration
+	// Interval is how often the reports are scanned.
+	Interval time.Duration
+	recorder record.EventRecorder
+}
+
+// SetupWithManager adds the watchdog to the manager, which starts it once it becomes the leader.
+func (w *Watchdog) SetupWithManager(mgr ctrl.Manager) error {
+	w.recorder = mgr.GetEventRecorderFor("metriccollectorreport-watchdog")
+	return mgr.Add(w)
+}
+
+// Start scans the reports every interval until the context is done.
+func (w *Watchdog) Start(ctx context.Context) error {
+	klog.InfoS("Starting MetricCollectorReport watchdog", "threshold", w.Threshold, "interval", w.Interval)
+	wait.UntilWithContext(ctx, w.scan, w.Interval)
+	return nil
+}
+
+// scan checks every MetricCollectorReport on the hub once.
+func (w *Watchdog) scan(ctx context.Context) {
+	reportList := &autoapprovev1alpha1.MetricCollectorReportList{}
+	if err := w.Client.List(ctx, reportList); err != nil {
+		klog.ErrorS(err, "Failed to list MetricCollectorReports")
+		return
+	}
+	now := time.Now()
+	for i := range reportList.Items {
+		if err := w.checkReport(ctx, &reportList.Items[i], now); err != nil {
+			klog.ErrorS(err, "Failed to check MetricCollectorReport staleness", "report", klog.KObj(&reportList.Items[i]))
+		}
+	}
+}
+
+// checkReport updates the StaleMetricsReporter condition of the report if its staleness changed.
+// Fresh reports only get the condition once they recover from being stale, to avoid writing to every report.
+func (w *Watchdog) checkReport(ctx context.Context, report *autoapprovev1alpha1.MetricCollectorReport, now time.Time) error {
+	lastUpdate := report.CreationTimestamp.Time
+	if report.Status.LastCollectionTime != nil {
+		lastUpdate = report.Status.LastCollectionTime.Time
+	}
+	stale := now.Sub(lastUpdate) > w.Threshold
+
+	existing := meta.FindStatusCondition(report.Status.Conditions, autoapprovev1alpha1.MetricCollectorReportConditionTypeStaleMetricsReporter)
+	wasStale := existing != nil && existing.Status == metav1.ConditionTrue
+	if stale == wasStale {
+		return nil
+	}
+
+	cond := metav1.Condition{
+		Type:               autoapprovev1alpha1.MetricCollectorReportConditionTypeStaleMetricsReporter,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: report.Generation,
+		Reason:             autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionResumed,
+		Message:            fmt.Sprintf("Metrics were collected at %s", lastUpdate.UTC().Format(time.RFC3339)),
+	}
+	if stale {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = autoapprovev1alpha1.MetricCollectorReportConditionReasonCollectionStale
+		if report.Status.LastCollectionTime == nil {
+			cond.Message = fmt.Sprintf("No metrics were collected since the report was created at %s, more than %s ago; the metric collector of the cluster may be down",
+				lastUpdate.UTC().Format(time.RFC3339), w.Threshold)
+		} else {
+			cond.Message = fmt.Sprintf("Metrics were last collected at %s, more than %s ago; the metric collector of the cluster may be down",
+				lastUpdate.UTC().Format(time.RFC3339), w.Threshold)
+		}
+	}
+
+	// Lock on the resource version so that a concurrent status update by the metric collector is not overwritten
+	patch := client.MergeFromWithOptions(report.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	meta.SetStatusCondition(&report.Status.Conditions, cond)
+	if err := w.Client.Status().Patch(ctx, report, patch); err != nil {
+		return fmt.Errorf("failed to update the %s condition: %w", cond.Type, err)
+	}
+
+	if stale {
+		klog.InfoS("MetricCollectorReport is stale", "report", klog.KObj(report), "lastUpdate", lastUpdate, "threshold", w.Threshold)
+		w.recorder.Event(report, "Warning", autoapprovev1alpha1.MetricCollectorReportConditionTypeStaleMetricsReporter, cond.Message)
+	} else {
+		klog.InfoS("MetricCollectorReport is collected again", "report", klog.KObj(report), "lastUpdate", lastUpdate)
+	}
+	return nil
+}
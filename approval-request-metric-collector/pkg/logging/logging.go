@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging sets up the structured logging shared by the binaries of the approval-request-controller,
+// metric-collector and trackercheck.
+package logging
+
+import (
+	"flag"
+	"strconv"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// BindFlags registers the logging flags on fs: klog's flags such as -v, and controller-runtime's zap flags
+// --zap-devel, --zap-encoder, --zap-log-level, --zap-stacktrace-level and --zap-time-encoding.
+// Logs use zap's production settings, JSON lines with stack traces from the error level, unless --zap-devel opts
+// into the development settings: human-readable console lines with stack traces from the warn level.
+// --zap-encoder and --zap-stacktrace-level override either.
+func BindFlags(fs *flag.FlagSet) *zap.Options {
+	klog.InitFlags(fs)
+	opts := &zap.Options{}
+	opts.BindFlags(fs)
+	return opts
+}
+
+// Setup routes the logs of both klog and controller-runtime through one zap logger built from opts, so that every
+// line shares the encoder, e.g. JSON for log pipelines. It must be called after fs is parsed. Unless
+// --zap-log-level is set, the zap level follows -v so that klog.V(n) lines are kept at verbosity n.
+func Setup(fs *flag.FlagSet, opts *zap.Options) {
+	if opts.Level == nil {
+		verbosity := 0
+		if f := fs.Lookup("v"); f != nil {
+			verbosity, _ = strconv.Atoi(f.Value.String())
+		}
+		opts.Level = zapcore.Level(-verbosity)
+	}
+	logger := zap.New(zap.UseFlagOptions(opts))
+	ctrl.SetLogger(logger)
+	klog.SetLogger(logger)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestBindFlagsDevelopment(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		wantDevelopment bool
+	}{
+		{name: "production by default"},
+		{name: "development opted into", args: []string{"--zap-devel"}, wantDevelopment: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			opts := BindFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse(%v) error = %v", tt.args, err)
+			}
+			if opts.Development != tt.wantDevelopment {
+				t.Errorf("Development = %t, want %t", opts.Development, tt.wantDevelopment)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateMemberNamespaceFormat checks that format, such as the upstream fleet-member-%s, formats the hub namespace
+// of a member cluster: it must contain exactly one %s for the cluster name and no other verb, and format a valid
+// namespace name.
+func ValidateMemberNamespaceFormat(format string) error {
+	if strings.Count(format, "%s") != 1 || strings.Count(format, "%") != 1 {
+		return fmt.Errorf("member namespace format %q must contain exactly one %%s and no other verb", format)
+	}
+	if errs := validation.IsDNS1123Label(fmt.Sprintf(format, "cluster")); len(errs) > 0 {
+		return fmt.Errorf("member namespace format %q does not format valid namespace names: %s", format, strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestValidateMemberNamespaceFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{name: "upstream format", format: "fleet-member-%s"},
+		{name: "custom prefix", format: "acme-fleet-%s"},
+		{name: "suffix", format: "%s-fleet"},
+		{name: "no verb", format: "fleet-member", wantErr: true},
+		{name: "two verbs", format: "fleet-%s-%s", wantErr: true},
+		{name: "other verb", format: "fleet-%s-%d", wantErr: true},
+		{name: "invalid namespace name", format: "Fleet_%s", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMemberNamespaceFormat(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMemberNamespaceFormat(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"net/url"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+// ValidatePrometheusURL checks that the Prometheus URL is an absolute http(s) URL or a unix URL naming the path of
+// a Unix domain socket, or a grpc(s) URL if the protocol is grpc. It is used both for the PrometheusURL of reports
+// and for the Prometheus URL flags of the controllers, so that a URL that cannot be queried with the protocol is
+// rejected at startup rather than with the first collection.
+func ValidatePrometheusURL(prometheusURL string, protocol autoapprovev1alpha1.PrometheusProtocol) error {
+	if prometheusURL == "" {
+		return fmt.Errorf("prometheusUrl is empty")
+	}
+	u, err := url.Parse(prometheusURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse prometheusUrl: %w", err)
+	}
+	if protocol == autoapprovev1alpha1.PrometheusProtocolGRPC {
+		if u.Scheme != "grpc" && u.Scheme != "grpcs" {
+			return fmt.Errorf("prometheusUrl %q must use the grpc or grpcs scheme with the grpc protocol", prometheusURL)
+		}
+	} else if u.Scheme == "unix" {
+		if u.Host != "" || u.Path == "" {
+			return fmt.Errorf("prometheusUrl %q must name an absolute socket path, e.g. unix:///var/run/prometheus.sock", prometheusURL)
+		}
+		return nil
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("prometheusUrl %q must use the http, https or unix scheme", prometheusURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("prometheusUrl %q has no host", prometheusURL)
+	}
+	return nil
+}
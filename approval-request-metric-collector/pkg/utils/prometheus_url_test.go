@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+func TestValidatePrometheusURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		protocol autoapprovev1alpha1.PrometheusProtocol
+		wantErr  bool
+	}{
+		{name: "http URL", url: "http://prometheus.prometheus.svc:9090"},
+		{name: "https URL with the http protocol", url: "https://prometheus.example.com", protocol: autoapprovev1alpha1.PrometheusProtocolHTTP},
+		{name: "unix socket", url: "unix:///var/run/prometheus.sock"},
+		{name: "unix socket without a path", url: "unix://prometheus.sock", wantErr: true},
+		{name: "grpc URL with the http protocol", url: "grpc://thanos-query:10901", protocol: autoapprovev1alpha1.PrometheusProtocolHTTP, wantErr: true},
+		{name: "grpc URL", url: "grpc://thanos-query:10901", protocol: autoapprovev1alpha1.PrometheusProtocolGRPC},
+		{name: "grpcs URL", url: "grpcs://thanos-query:10901", protocol: autoapprovev1alpha1.PrometheusProtocolGRPC},
+		{name: "http URL with the grpc protocol", url: "http://prometheus.prometheus.svc:9090", protocol: autoapprovev1alpha1.PrometheusProtocolGRPC, wantErr: true},
+		{name: "unix socket with the grpc protocol", url: "unix:///var/run/prometheus.sock", protocol: autoapprovev1alpha1.PrometheusProtocolGRPC, wantErr: true},
+		{name: "empty URL", url: "", wantErr: true},
+		{name: "no host", url: "http:///api", wantErr: true},
+		{name: "unsupported scheme", url: "ftp://prometheus", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePrometheusURL(tt.url, tt.protocol)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePrometheusURL(%q, %q) error = %v, wantErr %v", tt.url, tt.protocol, err, tt.wantErr)
+			}
+		})
+	}
+}
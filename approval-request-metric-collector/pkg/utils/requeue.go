@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package utils contains helpers shared by the approval-request-controller and metric-collector.
+package utils
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// JitterDuration returns d adjusted by a random amount within ±fraction of d,
+// e.g. a fraction of 0.1 yields a duration in [0.9*d, 1.1*d]. The average duration stays d,
+// so the effective cadence is unchanged while requeues of many objects are spread out.
+// A fraction <= 0 returns d unchanged; fractions above 1 are capped at 1.
+func JitterDuration(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	delta := (rand.Float64()*2 - 1) * fraction * float64(d)
+	return d + time.Duration(delta)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+// CountHealthyPodsForWorkload counts the number of unique healthy pods for a given workload
+// from the collected metrics. It returns the count of healthy pods and the total count of pods found.
+func CountHealthyPodsForWorkload(
+	collectedMetrics []autoapprovev1alpha1.WorkloadMetric,
+	workload autoapprovev1alpha1.WorkloadReference,
+) (healthyCount int32, totalCount int32) {
+	// Use a map to track unique pods and their health status
+	healthyPods := make(map[string]bool)
+	allPods := make(map[string]bool)
+
+	for _, metric := range collectedMetrics {
+		// Match workload by namespace, name, and kind. WorkloadName comes from the pod's app label, which
+		// workloads in different namespaces may share, so the namespace must always be part of the match;
+		// it also keeps the pod name keys below unique, as pod names are only unique within a namespace
+		if metric.Namespace == workload.Namespace &&
+			metric.WorkloadName == workload.Name &&
+			workload.Kind == metric.WorkloadKind {
+			// Track all pods
+			allPods[metric.PodName] = true
+			// Track healthy pods
+			if metric.Health {
+				healthyPods[metric.PodName] = true
+			}
+		}
+	}
+
+	return int32(len(healthyPods)), int32(len(allPods))
+}
+
+// CountHealthyPodsInReport counts the healthy pods and all pods of a workload in a MetricCollectorReport.
+// If the report spec sets ReportUnhealthyOnly, the healthy pods are absent from the collected metrics and
+// are taken from the healthy pod counts of the report status instead.
+func CountHealthyPodsInReport(
+	report *autoapprovev1alpha1.MetricCollectorReport,
+	workload autoapprovev1alpha1.WorkloadReference,
+) (healthyCount int32, totalCount int32) {
+	healthyCount, totalCount = CountHealthyPodsForWorkload(report.Status.CollectedMetrics, workload)
+	if !report.Spec.ReportUnhealthyOnly {
+		return healthyCount, totalCount
+	}
+	for _, healthy := range report.Status.HealthyWorkloads {
+		if healthy.Namespace == workload.Namespace &&
+			healthy.WorkloadName == workload.Name &&
+			healthy.WorkloadKind == workload.Kind {
+			healthyCount += healthy.TotalHealthy
+			totalCount += healthy.TotalHealthy
+		}
+	}
+	return healthyCount, totalCount
+}
+
+// Value tiers of a workload with a WarnThreshold or CriticalThreshold.
+const (
+	// ValueTierOK means the lowest health value of the workload is at or above its thresholds.
+	ValueTierOK = "OK"
+	// ValueTierWarn means the lowest health value of the workload is below its WarnThreshold.
+	ValueTierWarn = "Warn"
+	// ValueTierCritical means the lowest health value of the workload is below its CriticalThreshold, or NaN.
+	ValueTierCritical = "Critical"
+)
+
+// HasValueThresholds reports whether the workload is judged by the value of its health metric.
+func HasValueThresholds(workload autoapprovev1alpha1.WorkloadReference) bool {
+	return workload.WarnThreshold != nil || workload.CriticalThreshold != nil
+}
+
+// LowestValueForWorkload returns the lowest health value among the pods of a workload in the collected metrics,
+// or false if the workload has no metrics. A NaN value is returned as the lowest. Metrics without a raw value,
+// e.g. of the KubeStatus source, count as 1 if healthy and 0 otherwise. A raw value that is not a number, which the
+// metric collector never records, is returned as an error rather than judged.
+func LowestValueForWorkload(
+	collectedMetrics []autoapprovev1alpha1.WorkloadMetric,
+	workload autoapprovev1alpha1.WorkloadReference,
+) (float64, bool, error) {
+	lowest, found := math.Inf(1), false
+	for _, metric := range collectedMetrics {
+		if metric.Namespace != workload.Namespace || metric.WorkloadName != workload.Name || metric.WorkloadKind != workload.Kind {
+			continue
+		}
+		value := 0.0
+		if metric.Health {
+			value = 1
+		}
+		if metric.Value != "" {
+			parsed, err := strconv.ParseFloat(metric.Value, 64)
+			if err != nil {
+				return 0, true, fmt.Errorf("failed to parse health value %q of pod %s: %w", metric.Value, metric.PodName, err)
+			}
+			value = parsed
+		}
+		if math.IsNaN(value) {
+			return value, true, nil
+		}
+		lowest, found = math.Min(lowest, value), true
+	}
+	return lowest, found, nil
+}
+
+// LowestValueInReport returns the lowest health value among the pods of a workload in a MetricCollectorReport,
+// or false if the workload has no metrics. If the report spec sets ReportUnhealthyOnly, the healthy pods counted in
+// the report status have a value of 1.
+func LowestValueInReport(
+	report *autoapprovev1alpha1.MetricCollectorReport,
+	workload autoapprovev1alpha1.WorkloadReference,
+) (float64, bool, error) {
+	lowest, found, err := LowestValueForWorkload(report.Status.CollectedMetrics, workload)
+	if err != nil || !report.Spec.ReportUnhealthyOnly || math.IsNaN(lowest) {
+		return lowest, found, err
+	}
+	for _, healthy := range report.Status.HealthyWorkloads {
+		if healthy.Namespace == workload.Namespace &&
+			healthy.WorkloadName == workload.Name &&
+			healthy.WorkloadKind == workload.Kind &&
+			healthy.TotalHealthy > 0 {
+			lowest, found = math.Min(lowest, 1), true
+		}
+	}
+	return lowest, found, nil
+}
+
+// ValueTier classifies the lowest health value of a workload against its WarnThreshold and CriticalThreshold.
+func ValueTier(value float64, workload autoapprovev1alpha1.WorkloadReference) string {
+	switch {
+	case math.IsNaN(value):
+		return ValueTierCritical
+	case workload.CriticalThreshold != nil && value < thresholdValue(workload.CriticalThreshold):
+		return ValueTierCritical
+	case workload.WarnThreshold != nil && value < thresholdValue(workload.WarnThreshold):
+		return ValueTierWarn
+	}
+	return ValueTierOK
+}
+
+// thresholdValue returns the float64 closest to the threshold. Unlike AsApproximateFloat64, which multiplies by a
+// power of ten and turns e.g. 0.95 into 0.9500000000000001, a health value equal to the threshold compares equal.
+func thresholdValue(threshold *resource.Quantity) float64 {
+	value, err := strconv.ParseFloat(threshold.AsDec().String(), 64)
+	if err != nil {
+		return threshold.AsApproximateFloat64()
+	}
+	return value
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The KubeFleet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"math"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	autoapprovev1alpha1 "github.com/kubefleet-dev/kubefleet-cookbook/approval-request-metric-collector/apis/autoapprove/v1alpha1"
+)
+
+func TestCountHealthyPodsForWorkloadSharedAppLabel(t *testing.T) {
+	// Both namespaces run a Deployment labeled app=web whose pods have the same names
+	metrics := []autoapprovev1alpha1.WorkloadMetric{
+		{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-0", Health: true},
+		{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-1", Health: true},
+		{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-0", Health: false},
+		{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-1", Health: false},
+		{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-2", Health: true},
+		{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "StatefulSet", PodName: "web-3", Health: true},
+	}
+	tests := []struct {
+		name        string
+		workload    autoapprovev1alpha1.WorkloadReference
+		wantHealthy int32
+		wantTotal   int32
+	}{
+		{
+			name:        "first namespace",
+			workload:    autoapprovev1alpha1.WorkloadReference{Namespace: "team-a", Name: "web", Kind: "Deployment"},
+			wantHealthy: 2,
+			wantTotal:   2,
+		},
+		{
+			name:        "second namespace",
+			workload:    autoapprovev1alpha1.WorkloadReference{Namespace: "team-b", Name: "web", Kind: "Deployment"},
+			wantHealthy: 1,
+			wantTotal:   3,
+		},
+		{
+			name:        "other kind in second namespace",
+			workload:    autoapprovev1alpha1.WorkloadReference{Namespace: "team-b", Name: "web", Kind: "StatefulSet"},
+			wantHealthy: 1,
+			wantTotal:   1,
+		},
+		{
+			name:     "namespace without the workload",
+			workload: autoapprovev1alpha1.WorkloadReference{Namespace: "team-c", Name: "web", Kind: "Deployment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthy, total := CountHealthyPodsForWorkload(metrics, tt.workload)
+			if healthy != tt.wantHealthy || total != tt.wantTotal {
+				t.Errorf("CountHealthyPodsForWorkload() = (%d, %d), want (%d, %d)", healthy, total, tt.wantHealthy, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestCountHealthyPodsInReportSharedAppLabel(t *testing.T) {
+	report := &autoapprovev1alpha1.MetricCollectorReport{
+		Spec: autoapprovev1alpha1.MetricCollectorReportSpec{ReportUnhealthyOnly: true},
+		Status: autoapprovev1alpha1.MetricCollectorReportStatus{
+			CollectedMetrics: []autoapprovev1alpha1.WorkloadMetric{
+				{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "Deployment", PodName: "web-0", Health: false},
+			},
+			HealthyWorkloads: []autoapprovev1alpha1.WorkloadHealthyCount{
+				{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", TotalHealthy: 3},
+				{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "Deployment", TotalHealthy: 1},
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		workload    autoapprovev1alpha1.WorkloadReference
+		wantHealthy int32
+		wantTotal   int32
+	}{
+		{
+			name:        "first namespace",
+			workload:    autoapprovev1alpha1.WorkloadReference{Namespace: "team-a", Name: "web", Kind: "Deployment"},
+			wantHealthy: 3,
+			wantTotal:   3,
+		},
+		{
+			name:        "second namespace",
+			workload:    autoapprovev1alpha1.WorkloadReference{Namespace: "team-b", Name: "web", Kind: "Deployment"},
+			wantHealthy: 1,
+			wantTotal:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthy, total := CountHealthyPodsInReport(report, tt.workload)
+			if healthy != tt.wantHealthy || total != tt.wantTotal {
+				t.Errorf("CountHealthyPodsInReport() = (%d, %d), want (%d, %d)", healthy, total, tt.wantHealthy, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestLowestValueForWorkload(t *testing.T) {
+	workload := autoapprovev1alpha1.WorkloadReference{Namespace: "team-a", Name: "web", Kind: "Deployment"}
+	metric := func(health bool, value string) autoapprovev1alpha1.WorkloadMetric {
+		return autoapprovev1alpha1.WorkloadMetric{Namespace: "team-a", WorkloadName: "web", WorkloadKind: "Deployment", Health: health, Value: value}
+	}
+	tests := []struct {
+		name      string
+		metrics   []autoapprovev1alpha1.WorkloadMetric
+		want      float64
+		wantFound bool
+		wantErr   bool
+	}{
+		{
+			name:      "lowest raw value",
+			metrics:   []autoapprovev1alpha1.WorkloadMetric{metric(true, "0.99"), metric(true, "0.97"), metric(true, "1")},
+			want:      0.97,
+			wantFound: true,
+		},
+		{
+			name:      "metrics without a raw value",
+			metrics:   []autoapprovev1alpha1.WorkloadMetric{metric(true, ""), metric(false, "")},
+			want:      0,
+			wantFound: true,
+		},
+		{
+			name:      "NaN",
+			metrics:   []autoapprovev1alpha1.WorkloadMetric{metric(true, "0.5"), metric(false, "NaN")},
+			want:      math.NaN(),
+			wantFound: true,
+		},
+		{
+			name:    "value that is not a number",
+			metrics: []autoapprovev1alpha1.WorkloadMetric{metric(true, "0.5"), metric(true, "high")},
+			wantErr: true,
+		},
+		{
+			name:    "other workload",
+			metrics: []autoapprovev1alpha1.WorkloadMetric{{Namespace: "team-b", WorkloadName: "web", WorkloadKind: "Deployment", Health: true, Value: "0.5"}},
+			want:    math.Inf(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := LowestValueForWorkload(tt.metrics, workload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LowestValueForWorkload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if found != tt.wantFound || !(got == tt.want || math.IsNaN(got) && math.IsNaN(tt.want)) {
+				t.Errorf("LowestValueForWorkload() = (%v, %v), want (%v, %v)", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestValueTier(t *testing.T) {
+	workload := autoapprovev1alpha1.WorkloadReference{
+		WarnThreshold:     ptr.To(resource.MustParse("0.95")),
+		CriticalThreshold: ptr.To(resource.MustParse("0.9")),
+	}
+	tests := []struct {
+		name     string
+		value    float64
+		workload autoapprovev1alpha1.WorkloadReference
+		want     string
+	}{
+		{name: "above warn threshold", value: 0.99, workload: workload, want: ValueTierOK},
+		{name: "at warn threshold", value: 0.95, workload: workload, want: ValueTierOK},
+		{name: "between thresholds", value: 0.92, workload: workload, want: ValueTierWarn},
+		{name: "at critical threshold", value: 0.9, workload: workload, want: ValueTierWarn},
+		{name: "below critical threshold", value: 0.5, workload: workload, want: ValueTierCritical},
+		{name: "NaN", value: math.NaN(), workload: workload, want: ValueTierCritical},
+		{
+			name:     "below warn threshold only",
+			value:    0.5,
+			workload: autoapprovev1alpha1.WorkloadReference{WarnThreshold: workload.WarnThreshold},
+			want:     ValueTierWarn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValueTier(tt.value, tt.workload); got != tt.want {
+				t.Errorf("ValueTier(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}